@@ -0,0 +1,81 @@
+// Path: ./api/access_token_api/access_token_create.go
+
+package access_token_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/access_token_service"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"time"
+)
+
+type AccessTokenCreateReq struct {
+	Name       string             `json:"name" binding:"required,max=64"`
+	Scopes     []enum.AccessScope `json:"scopes" binding:"required"`
+	ExpireDays int                `json:"expireDays" binding:"min=0,max=365"` // 0 为永不过期
+}
+
+type AccessTokenCreateResponse struct {
+	models.AccessTokenModel
+	Token string `json:"token"` // 明文只返回这一次
+}
+
+// AccessTokenCreateView 创建访问令牌
+func (AccessTokenApi) AccessTokenCreateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(AccessTokenCreateReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		res.FailWithMsg("请填写令牌名称", c)
+		return
+	}
+	if !access_token_service.ValidScopes(req.Scopes) {
+		res.FailWithMsg("权限范围错误", c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.AccessTokenModel{}).
+		Where("user_id = ? and revoked_at is null and (expires_at is null or expires_at > ?)", user.ID, time.Now()).
+		Count(&count)
+	if count >= access_token_service.MaxPerUser {
+		res.FailWithMsg(fmt.Sprintf("最多创建 %d 个访问令牌，请先撤销不用的", access_token_service.MaxPerUser), c)
+		return
+	}
+
+	token, err := access_token_service.Generate()
+	if err != nil {
+		res.Fail(err, "生成令牌失败", c)
+		return
+	}
+	t := models.AccessTokenModel{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Prefix:    token[:len(access_token_service.Prefix)+6],
+		TokenHash: access_token_service.Hash(token),
+		Scopes:    req.Scopes,
+	}
+	if req.ExpireDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpireDays)
+		t.ExpiresAt = &expiresAt
+	}
+	if err = global.DB.Create(&t).Error; err != nil {
+		res.Fail(err, "创建令牌失败", c)
+		return
+	}
+
+	log_service.NewSecurityLog(*user, "创建访问令牌", fmt.Sprintf("%s %v", t.Name, t.Scopes), true, c)
+	res.Success(AccessTokenCreateResponse{AccessTokenModel: t, Token: token}, "令牌创建成功，请立即保存，之后不会再显示", c)
+}
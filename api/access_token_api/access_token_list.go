@@ -0,0 +1,51 @@
+// Path: ./api/access_token_api/access_token_list.go
+
+package access_token_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// AccessTokenScopeOptionsView 可选的权限范围
+func (AccessTokenApi) AccessTokenScopeOptionsView(c *gin.Context) {
+	var resp []models.OptionsRequest[enum.AccessScope]
+	for _, s := range enum.AccessScopeList {
+		resp = append(resp, models.OptionsRequest[enum.AccessScope]{Label: s.String(), Value: s})
+	}
+	res.SuccessWithData(resp, c)
+}
+
+type AccessTokenListReq struct {
+	UserID uint `form:"userID"` // 管理员查看其他用户
+}
+
+type AccessTokenResponse struct {
+	models.AccessTokenModel
+	Expired bool `json:"expired"`
+}
+
+// AccessTokenListView 用户的访问令牌，已撤销的不显示
+func (AccessTokenApi) AccessTokenListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(AccessTokenListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	if claims.Role != enum.AdminRoleType || req.UserID == 0 {
+		req.UserID = claims.UserID
+	}
+
+	var _list []models.AccessTokenModel
+	global.DB.Where("user_id = ? and revoked_at is null", req.UserID).Order("id desc").Find(&_list)
+	list := make([]AccessTokenResponse, 0, len(_list))
+	for _, t := range _list {
+		list = append(list, AccessTokenResponse{
+			AccessTokenModel: t,
+			Expired:          t.ExpiresAt != nil && t.ExpiresAt.Before(time.Now()),
+		})
+	}
+	res.SuccessWithList(list, len(list), c)
+}
@@ -0,0 +1,39 @@
+// Path: ./api/access_token_api/access_token_remove.go
+
+package access_token_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/access_token_service"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenRemoveView 撤销访问令牌，管理员可以撤销任何人的
+func (AccessTokenApi) AccessTokenRemoveView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var t models.AccessTokenModel
+	err := global.DB.Preload("UserModel").Take(&t, req.ID).Error
+	if err != nil || (t.UserID != claims.UserID && claims.Role != enum.AdminRoleType) {
+		res.FailWithMsg("令牌不存在", c)
+		return
+	}
+	if err = access_token_service.Revoke(&t); err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	content := t.Name
+	if t.UserID != claims.UserID {
+		content = fmt.Sprintf("%s，操作人 %s", t.Name, claims.Username)
+	}
+	log_service.NewSecurityLog(t.UserModel, "撤销访问令牌", content, true, c)
+	res.SuccessWithMsg("令牌已撤销", c)
+}
@@ -0,0 +1,5 @@
+// Path: ./api/access_token_api/enter.go
+
+package access_token_api
+
+type AccessTokenApi struct{}
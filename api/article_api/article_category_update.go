@@ -8,6 +8,7 @@ import (
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"github.com/gin-gonic/gin"
 	"strings"
@@ -20,6 +21,10 @@ type ArticleCategoryUpdateReq struct {
 
 func (ArticleApi) ArticleCategoryUpdateView(c *gin.Context) {
 	req := c.MustGet("bindReq").(ArticleCategoryUpdateReq)
+	if err := sensitive_service.FilterNoReview(&req.Name); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
 	claims := jwts.MustGetClaimsFromRequest(c)
 
 	var cm models.CategoryModel
@@ -8,6 +8,7 @@ import (
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"errors"
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,10 @@ type ArticleCollectionCreateReq struct {
 // 有 id 就是更新，没有 id （id==0）就是创建
 func (ArticleApi) ArticleCollectionFolderCreateView(c *gin.Context) {
 	req := c.MustGet("bindReq").(ArticleCollectionCreateReq)
+	if err := sensitive_service.FilterNoReview(&req.Title, &req.Abstract); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
 	claims := jwts.MustGetClaimsFromRequest(c)
 	var cf models.CollectionFolderModel
 
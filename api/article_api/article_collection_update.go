@@ -8,6 +8,7 @@ import (
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"github.com/gin-gonic/gin"
 	"strings"
@@ -22,6 +23,10 @@ type ArticleCollectionUpdateReq struct {
 
 func (ArticleApi) ArticleCollectionFolderUpdateView(c *gin.Context) {
 	req := c.MustGet("bindReq").(ArticleCollectionUpdateReq)
+	if err := sensitive_service.FilterNoReview(&req.Title, &req.Abstract); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
 	claims := jwts.MustGetClaimsFromRequest(c)
 
 	var cf models.CollectionFolderModel
@@ -4,29 +4,37 @@ package article_api
 
 import (
 	"blogX_server/common/res"
+	"blogX_server/common/transaction"
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/models/ctype"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"blogX_server/utils/markdown"
 	"blogX_server/utils/xss"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"time"
 )
 
 type ArticleCreateReq struct {
-	Title          string             `json:"title" binding:"required"`
-	Abstract       string             `json:"abstract"`
-	CoverURL       string             `json:"coverURL"`
-	Content        string             `json:"content" binding:"required"`
-	CategoryID     *uint              `json:"categoryID"`
-	Category       string             `json:"category"`
-	Tags           ctype.List         `json:"tags"`
-	OpenForComment bool               `json:"openForComment"`
-	Status         enum.ArticleStatus `json:"status" binding:"required,oneof=1 2"` // 点提交就是 2，点存为草稿就是 1
+	Title               string                 `json:"title" binding:"required"`
+	Abstract            string                 `json:"abstract"`
+	CoverURL            string                 `json:"coverURL"`
+	Content             string                 `json:"content" binding:"required"`
+	CategoryID          *uint                  `json:"categoryID"`
+	Category            string                 `json:"category"`
+	Tags                ctype.List             `json:"tags"`
+	OpenForComment      bool                   `json:"openForComment"`
+	OpenForGuestComment bool                   `json:"openForGuestComment"`                       // 博客模式下允许游客评论
+	CommentModeration   enum.CommentModeration `json:"commentModeration" binding:"oneof=0 1 2 3"` // 0 跟随站点设置 1 不审核 2 含链接或新账号需审核 3 全部审核
+	Status              enum.ArticleStatus     `json:"status" binding:"required,oneof=1 2 5"`     // 点提交就是 2，点存为草稿就是 1，定时发布是 5
+	PublishAt           string                 `json:"publishAt"`                                 // 定时发布时间 "2006-01-02 15:04:05"，status 为 5 时必填
 }
 
 func (ArticleApi) ArticleAutoGenView(c *gin.Context) {
@@ -93,11 +101,12 @@ func (ArticleApi) ArticleAutoGenView(c *gin.Context) {
 	}
 
 	// 入库
-	err := global.DB.Create(&article).Error
+	err := transaction.CreateArticleWithRevisionTx(&article, uid, enum.RevisionSourceAutoGen)
 	if err != nil {
 		logrus.Errorf("文章自动发布失败，%v", err)
 		return
 	}
+	redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
 	logrus.Info("文章自动生成发布成功")
 }
 
@@ -109,6 +118,16 @@ func (ArticleApi) ArticleCreateView(c *gin.Context) {
 		return
 	}
 
+	// 定时发布
+	var publishAt *time.Time
+	if req.Status == enum.ArticleStatusSchedule {
+		publishAt, err = parsePublishAt(req.PublishAt)
+		if err != nil {
+			res.FailWithMsg(err.Error(), c)
+			return
+		}
+	}
+
 	// 取分类
 	var cat models.CategoryModel
 	if req.CategoryID != nil {
@@ -139,6 +158,17 @@ func (ArticleApi) ArticleCreateView(c *gin.Context) {
 		req.Abstract = txt
 	}
 
+	// 敏感词：拦截词直接拒绝，打码词替换为 *，需要审核的词不走自动审核
+	review, err := sensitive_service.Filter(&req.Title, &req.Abstract, &req.Content)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if review && req.Status == enum.ArticleStatusSchedule {
+		res.FailWithMsg("文章内容需要人工审核，不能定时发布", c)
+		return
+	}
+
 	// 正文内容图片转存给前端去做。后端留了个接口 ImageCache
 
 	// log
@@ -147,25 +177,32 @@ func (ArticleApi) ArticleCreateView(c *gin.Context) {
 	log.SetTitle("发布文章")
 
 	var article = models.ArticleModel{
-		Title:          req.Title,
-		Abstract:       req.Abstract,
-		CoverURL:       req.CoverURL,
-		Content:        req.Content,
-		CategoryID:     req.CategoryID,
-		Tags:           req.Tags,
-		OpenForComment: req.OpenForComment,
-		UserID:         u.ID,
-		Status:         req.Status,
+		Title:               req.Title,
+		Abstract:            req.Abstract,
+		CoverURL:            req.CoverURL,
+		Content:             req.Content,
+		CategoryID:          req.CategoryID,
+		Tags:                req.Tags,
+		OpenForComment:      req.OpenForComment,
+		OpenForGuestComment: req.OpenForGuestComment,
+		CommentModeration:   req.CommentModeration,
+		UserID:              u.ID,
+		Status:              req.Status,
+		PublishAt:           publishAt,
 	}
-	if req.Status == enum.ArticleStatusReview && global.Config.Site.Article.AutoApprove {
+	if req.Status == enum.ArticleStatusReview && global.Config.Site.Article.AutoApprove && !review {
 		article.Status = enum.ArticleStatusPublish
 	}
 
 	// 入库
-	err = global.DB.Create(&article).Error
+	err = transaction.CreateArticleWithRevisionTx(&article, u.ID, enum.RevisionSourceCreate)
 	if err != nil {
 		res.Fail(err, "文章创建失败", c)
 		return
 	}
+	if article.Status == enum.ArticleStatusPublish {
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	}
 	res.SuccessWithMsg("文章创建成功", c)
+	mention_service.SyncArticle(article)
 }
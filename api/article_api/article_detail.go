@@ -8,6 +8,7 @@ import (
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/redis_service/redis_article"
+	"blogX_server/service/series_service"
 	"blogX_server/utils/jwts"
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +20,8 @@ type ArticleDetailResp struct {
 	CategoryName  *string `json:"categoryName"`
 	IsLiked       bool    `json:"isLiked"`
 	IsCollected   bool    `json:"isCollected"`
+
+	Series *series_service.SeriesNav `json:"series"` // 所属系列及上一篇、下一篇
 }
 
 func (ArticleApi) ArticleDetailView(c *gin.Context) {
@@ -44,8 +47,9 @@ func (ArticleApi) ArticleDetailView(c *gin.Context) {
 		return
 	}
 	if err == nil && claims != nil {
-		// 非管理员无法看别人未发布的文章
-		if claims.Role != enum.AdminRoleType && a.UserID != claims.UserID && a.Status != enum.ArticleStatusPublish {
+		// 非管理员无法看别人未发布的文章，审核员可以看待审核的文章
+		reviewing := claims.Role == enum.ReviewerRoleType && a.Status == enum.ArticleStatusReview
+		if claims.Role != enum.AdminRoleType && a.UserID != claims.UserID && a.Status != enum.ArticleStatusPublish && !reviewing {
 			res.FailWithMsg("文章不存在", c)
 			return
 		}
@@ -71,5 +75,6 @@ func (ArticleApi) ArticleDetailView(c *gin.Context) {
 	if a.CategoryModel != nil {
 		resp.CategoryName = &a.CategoryModel.Name
 	}
+	resp.Series = series_service.GetArticleSeriesNav(a.ID)
 	res.SuccessWithData(resp, c)
 }
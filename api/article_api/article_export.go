@@ -0,0 +1,47 @@
+// Path: ./api/article_api/article_export.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/models/enum"
+	"blogX_server/service/archive_service"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ArticleExportReq struct {
+	Format archive_service.Format `form:"format" binding:"omitempty,oneof=hexo hugo"` // 默认 hexo
+	UserID uint                   `form:"userID"`                                     // 仅管理员可用，不传就是自己
+}
+
+// ArticleExportView 把自己的文章导出为 Hexo / Hugo 结构的 zip，可以再导入到其他博客或者 BlogX
+func (ArticleApi) ArticleExportView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleExportReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if req.Format == "" {
+		req.Format = archive_service.FormatHexo
+	}
+	if req.UserID == 0 || claims.Role != enum.AdminRoleType {
+		req.UserID = claims.UserID
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowRequest()
+	log.SetTitle("导出文章")
+
+	byteData, err := archive_service.Export(req.UserID, req.Format)
+	if err != nil {
+		res.Fail(err, "导出失败", c)
+		return
+	}
+
+	filename := fmt.Sprintf("blogx_%d_%s_%s.zip", req.UserID, req.Format, time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/zip", byteData)
+}
@@ -0,0 +1,68 @@
+// Path: ./api/article_api/article_import.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/service/archive_service"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+)
+
+// ArticleImportView 导入 Hexo / Hugo 的文章压缩包（zip），文章中引用的本地图片会一起上传
+// 已有同名文章的会跳过，部分失败时返回每篇的结果
+func (ArticleApi) ArticleImportView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	limit := global.Config.Upload.ArchiveSizeLimit
+	if limit <= 0 {
+		limit = 50
+	}
+	if fileHeader.Size > int64(limit)<<20 {
+		res.FailWithMsg(fmt.Sprintf("文件大小大于%dMB", limit), c)
+		return
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		res.Fail(err, "读取文件失败", c)
+		return
+	}
+	defer f.Close()
+	byteData, err := io.ReadAll(f)
+	if err != nil {
+		res.Fail(err, "读取文件失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowResponse()
+	log.SetTitle("导入文章")
+	log.SetItem("文件", fmt.Sprintf("%s (%d bytes)", fileHeader.Filename, fileHeader.Size))
+
+	list, err := archive_service.Import(byteData, claims.UserID)
+	if err != nil {
+		res.Fail(err, "导入失败", c)
+		return
+	}
+
+	var success int
+	for _, r := range list {
+		if r.Status != archive_service.ImportStatusFailed {
+			success++
+		}
+	}
+	if success == len(list) {
+		res.SuccessWithList(list, len(list), c)
+		return
+	}
+	res.WithList(list, len(list), success, c)
+}
@@ -0,0 +1,156 @@
+// Path: ./api/article_api/article_import_wordpress.go
+
+package article_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/wordpress_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+)
+
+// ImportJobResp 导入任务及其进度
+type ImportJobResp struct {
+	models.ImportJobModel
+	Running  bool `json:"running"`  // 是否有协程正在执行
+	Progress int  `json:"progress"` // 百分比
+}
+
+func newImportJobResp(job models.ImportJobModel) ImportJobResp {
+	data := ImportJobResp{
+		ImportJobModel: job,
+		Running:        wordpress_service.IsRunning(job.ID),
+	}
+	if job.Total > 0 {
+		data.Progress = job.Cursor * 100 / job.Total
+	}
+	return data
+}
+
+// ArticleImportWordpressView 上传 WordPress 导出的 WXR 文件，创建后台导入任务
+// 表单字段 dryRun=true 时只统计能导入多少文章、评论，不写入数据
+func (ArticleApi) ArticleImportWordpressView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	limit := global.Config.Upload.ArchiveSizeLimit
+	if limit <= 0 {
+		limit = 50
+	}
+	if fileHeader.Size > int64(limit)<<20 {
+		res.FailWithMsg(fmt.Sprintf("文件大小大于%dMB", limit), c)
+		return
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		res.Fail(err, "读取文件失败", c)
+		return
+	}
+	defer f.Close()
+	byteData, err := io.ReadAll(f)
+	if err != nil {
+		res.Fail(err, "读取文件失败", c)
+		return
+	}
+	dryRun := c.PostForm("dryRun") == "true"
+
+	log := log_service.GetActionLog(c)
+	log.ShowResponse()
+	log.SetTitle("导入 WordPress 文章")
+	log.SetItem("文件", fmt.Sprintf("%s (%d bytes) 试运行:%t", fileHeader.Filename, fileHeader.Size, dryRun))
+
+	job, err := wordpress_service.CreateJob(byteData, fileHeader.Filename, claims.UserID, dryRun)
+	if err != nil {
+		res.Fail(err, "创建导入任务失败", c)
+		return
+	}
+	wordpress_service.Start(job.ID)
+
+	res.SuccessWithData(newImportJobResp(job), c)
+}
+
+type ImportJobListReq struct {
+	common.PageInfo
+}
+
+// ArticleImportJobListView 自己的导入任务
+func (ArticleApi) ArticleImportJobListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ImportJobListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	req.PageInfo.Normalize()
+
+	_list, count, err := common.ListQuery(
+		models.ImportJobModel{UserID: claims.UserID},
+		common.Options{
+			PageInfo:     req.PageInfo,
+			Likes:        []string{"file_name"},
+			DefaultOrder: "id desc",
+		},
+	)
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ImportJobResp, 0, len(_list))
+	for _, job := range _list {
+		list = append(list, newImportJobResp(job))
+	}
+	res.SuccessWithList(list, count, c)
+}
+
+// ArticleImportJobView 导入任务的进度
+func (ArticleApi) ArticleImportJobView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	job, ok := takeImportJob(req.ID, claims, c)
+	if !ok {
+		return
+	}
+	res.SuccessWithData(newImportJobResp(job), c)
+}
+
+// ArticleImportJobResumeView 失败或中断的任务从断点继续
+func (ArticleApi) ArticleImportJobResumeView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	job, ok := takeImportJob(req.ID, claims, c)
+	if !ok {
+		return
+	}
+	if job.Status == enum.ImportJobStatusDone {
+		res.FailWithMsg("任务已完成", c)
+		return
+	}
+	if !wordpress_service.Start(job.ID) {
+		res.FailWithMsg("任务正在执行", c)
+		return
+	}
+	res.SuccessWithMsg("任务已继续执行", c)
+}
+
+func takeImportJob(id uint, claims *jwts.MyClaims, c *gin.Context) (job models.ImportJobModel, ok bool) {
+	err := global.DB.Take(&job, id).Error
+	if err != nil {
+		res.Fail(err, "任务不存在", c)
+		return
+	}
+	if claims.UserID != job.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能查看自己的导入任务", c)
+		return
+	}
+	return job, true
+}
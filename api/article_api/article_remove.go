@@ -16,7 +16,7 @@ import (
 )
 
 // 管理员随便删，用户只能删自己的
-// 如果是物理删除，那需要删除对应记录（点赞、收藏、置顶等）
+// 删除只是放入回收站（软删除），关联记录（点赞、收藏、置顶等）在回收站到期彻底删除时才一起删除
 
 // ArticleRemoveView 删除单篇
 func (ArticleApi) ArticleRemoveView(c *gin.Context) {
@@ -43,12 +43,12 @@ func (ArticleApi) ArticleRemoveView(c *gin.Context) {
 		log.ShowClaim(claims)
 	}
 
-	mps, err := transaction.RemoveArticleAndRelated(&a)
+	err = transaction.SoftRemoveArticleTx(&a, claims.UserID)
 	if err != nil {
 		res.Fail(err, "文章删除失败", c)
 		return
 	}
-	log.SetItem(fmt.Sprintf("删除文章 %d", a.ID), mps)
+	log.SetItem(fmt.Sprintf("删除文章 %d", a.ID), "已放入回收站")
 
 	// 消息通知
 	if claims.UserID != a.UserID { // 只可能是 admin
@@ -65,6 +65,7 @@ func (ArticleApi) ArticleRemoveView(c *gin.Context) {
 // ArticleBatchRemoveView 批量删除，只能管理员
 func (ArticleApi) ArticleBatchRemoveView(c *gin.Context) {
 	req := c.MustGet("bindReq").(models.IDListRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
 
 	if len(req.IDList) == 0 {
 		res.FailWithMsg("没有指定删除对象", c)
@@ -87,12 +88,12 @@ func (ArticleApi) ArticleBatchRemoveView(c *gin.Context) {
 			log.SetItemWarn(fmt.Sprintf("删除失败 id:%d", a.ID), "文章不存在: "+err.Error())
 			continue
 		}
-		mps, err := transaction.RemoveArticleAndRelated(&a)
+		err = transaction.SoftRemoveArticleTx(&a, claims.UserID)
 		if err != nil {
 			log.SetItemWarn(fmt.Sprintf("删除失败 id:%d", a.ID), "删除事务失败: "+err.Error())
 			continue
 		}
-		log.SetItem(fmt.Sprintf("删除文章 %d", a.ID), mps)
+		log.SetItem(fmt.Sprintf("删除文章 %d", a.ID), "已放入回收站")
 		succ = append(succ, a.ID)
 		count++
 
@@ -4,25 +4,43 @@ package article_api
 
 import (
 	"blogX_server/common/res"
+	"blogX_server/common/transaction"
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
 	"blogX_server/service/message_service"
 	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
 	"fmt"
 	"github.com/gin-gonic/gin"
 )
 
 type ArticleReviewReq struct {
 	ArticleID uint               `json:"articleID" binding:"required"`
-	Status    enum.ArticleStatus `json:"status" binding:"oneof=3 4"`
-	Msg       string             `json:"msg"`
+	Status    enum.ArticleStatus `json:"status" binding:"oneof=3 4 6"` // 3 通过 4 拒绝 6 退回修改
+	Msg       string             `json:"msg" binding:"max=1024"`       // 审核意见，拒绝和退回修改时必填
 }
 
-// ArticleReviewView 作为管理员提交审核结果
+// ArticleReviewView 审核员提交本轮审核结果，审核意见会记录在审核历史中
+// 审核员需要先认领，管理员可以直接审核
 func (ArticleApi) ArticleReviewView(c *gin.Context) {
 	req := c.MustGet("bindReq").(ArticleReviewReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	status := enum.ReviewStatusApproved
+	switch req.Status {
+	case enum.AritcleStatusFail:
+		status = enum.ReviewStatusRejected
+	case enum.ArticleStatusRevise:
+		status = enum.ReviewStatusChanges
+	}
+	if status != enum.ReviewStatusApproved && req.Msg == "" {
+		res.FailWithMsg("请填写审核意见", c)
+		return
+	}
 
 	var a models.ArticleModel
 	err := global.DB.Take(&a, req.ArticleID).Error
@@ -31,39 +49,79 @@ func (ArticleApi) ArticleReviewView(c *gin.Context) {
 		return
 	}
 
-	if a.Status != 2 {
+	if a.Status != enum.ArticleStatusReview {
 		res.FailWithMsg("该文章不处于待审核状态", c)
 		return
 	}
+	if a.UserID == claims.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("不能审核自己的文章", c)
+		return
+	}
 
-	err = global.DB.Model(&a).Update("Status", req.Status).Error
+	var r models.ArticleReviewModel
+	err = global.DB.Take(&r, "article_id = ? and status = ?", a.ID, enum.ReviewStatusPending).Error
 	if err != nil {
-		res.Fail(err, "审核提交失败", c)
-		return
+		// 审核流程上线前提交的文章没有审核记录，补一轮
+		err = transaction.SyncArticleReviewTx(a.ID, a.UserID, "")
+		if err == nil {
+			err = global.DB.Take(&r, "article_id = ? and status = ?", a.ID, enum.ReviewStatusPending).Error
+		}
+		if err != nil {
+			res.Fail(err, "审核记录不存在", c)
+			return
+		}
+	}
+
+	// 审核员审核的是认领时的版本，管理员审核的是现在看到的版本
+	version := r.Version
+	expiry := global.Config.Site.Article.ClaimExpiry()
+	if claims.Role != enum.AdminRoleType {
+		if r.ReviewerID == nil || *r.ReviewerID != claims.UserID {
+			res.FailWithMsg("请先认领该文章", c)
+			return
+		}
+		if r.ClaimExpired(expiry) {
+			res.FailWithMsg("认领已超时，请重新认领", c)
+			return
+		}
+		version = r.ClaimedVersion
 	}
 
 	// 日志
 	log := log_service.GetActionLog(c)
 	log.ShowAll()
 	log.SetTitle("文章审核")
+	log.ShowClaim(claims)
+
+	err = transaction.ReviewArticleTx(&r, &a, version, status, req.Msg, claims.UserID)
+	if err != nil {
+		res.Fail(err, "审核提交失败", c)
+		return
+	}
+	log.SetItem(fmt.Sprintf("文章 %d 第 %d 轮审核", a.ID, r.Round), status.String())
 
-	if req.Status == enum.ArticleStatusPublish {
+	href := fmt.Sprintf("%s/aritcle/%d", global.Config.System.Addr(), a.ID)
+	switch status {
+	case enum.ReviewStatusApproved:
+		// 审核通过后才提醒正文中 @ 的人
+		mention_service.SyncArticleByID(a.ID)
 		fMsg := fmt.Sprintf("您提交审核的文章 [ID:%d]%s 已成功通过！\n", a.ID, a.Title)
-		href := fmt.Sprintf("%s/aritcle/%d", global.Config.System.Addr(), a.ID)
 		err = message_service.SendSystemNotify(a.UserID, "文章审核通过", fMsg+req.Msg, a.Title, href)
-		if err != nil {
-			res.Fail(err, "发送消息失败", c)
-			return
-		}
-	} else if req.Status == enum.AritcleStatusFail {
+	case enum.ReviewStatusRejected:
 		fMsg := fmt.Sprintf("您提交审核的文章 [ID:%d]%s 没有通过！\n", a.ID, a.Title)
-		href := fmt.Sprintf("%s/aritcle/%d", global.Config.System.Addr(), a.ID)
 		err = message_service.SendSystemNotify(a.UserID, "文章审核未通过", fMsg+req.Msg, a.Title, href)
-		if err != nil {
-			res.Fail(err, "发送消息失败", c)
-			return
-		}
+	case enum.ReviewStatusChanges:
+		fMsg := fmt.Sprintf("您提交审核的文章 [ID:%d]%s 需要修改，修改后可以重新提交审核\n", a.ID, a.Title)
+		err = message_service.SendSystemNotify(a.UserID, "文章需要修改", fMsg+req.Msg, a.Title, href)
+	}
+	if err != nil {
+		res.Fail(err, "发送消息失败", c)
+		return
 	}
 	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	series_service.SyncArticleSeries(a.ID)
+	if status == enum.ReviewStatusApproved {
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	}
 	res.SuccessWithMsg("审核提交成功", c)
 }
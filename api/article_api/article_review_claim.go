@@ -0,0 +1,83 @@
+// Path: ./api/article_api/article_review_claim.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// ArticleReviewClaimView 认领一轮审核，认领期间其他审核员不能审核
+// 审核员不能认领自己的文章
+func (ArticleApi) ArticleReviewClaimView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var r models.ArticleReviewModel
+	err := global.DB.Preload("ArticleModel").Take(&r, "id = ? and status = ?", req.ID, enum.ReviewStatusPending).Error
+	if err != nil {
+		res.Fail(err, "该轮审核不存在或已结束", c)
+		return
+	}
+	if r.ArticleModel.UserID == claims.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("不能审核自己的文章", c)
+		return
+	}
+
+	expiry := global.Config.Site.Article.ClaimExpiry()
+	ok, err := transaction.ClaimArticleReview(r.ID, claims.UserID, expiry)
+	if err != nil {
+		res.Fail(err, "认领失败", c)
+		return
+	}
+	if !ok {
+		res.FailWithMsg("该文章已被其他审核员认领", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("认领审核")
+	log.SetItem(fmt.Sprintf("文章 %d 第 %d 轮审核", r.ArticleID, r.Round), claims.UserID)
+
+	res.SuccessWithMsg(fmt.Sprintf("认领成功，请在 %s 前完成审核", time.Now().Add(expiry).Format("2006-01-02 15:04:05")), c)
+}
+
+// ArticleReviewReleaseView 放弃认领，管理员可以释放任何人的认领
+func (ArticleApi) ArticleReviewReleaseView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var r models.ArticleReviewModel
+	err := global.DB.Take(&r, "id = ? and status = ?", req.ID, enum.ReviewStatusPending).Error
+	if err != nil {
+		res.Fail(err, "该轮审核不存在或已结束", c)
+		return
+	}
+	if r.ReviewerID == nil {
+		res.FailWithMsg("该文章未被认领", c)
+		return
+	}
+	if *r.ReviewerID != claims.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能放弃自己的认领", c)
+		return
+	}
+
+	err = global.DB.Model(&r).Updates(map[string]any{
+		"reviewer_id": nil,
+		"claimed_at":  nil,
+	}).Error
+	if err != nil {
+		res.Fail(err, "放弃认领失败", c)
+		return
+	}
+	res.SuccessWithMsg("已放弃认领", c)
+}
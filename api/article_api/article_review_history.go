@@ -0,0 +1,48 @@
+// Path: ./api/article_api/article_review_history.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleReviewHistoryView 文章的完整审核历史，按轮次先后排列
+// 作者可以看自己文章的审核意见，审核员和管理员可以看所有
+func (ArticleApi) ArticleReviewHistoryView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var a models.ArticleModel
+	err := global.DB.Take(&a, req.ID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if claims.UserID != a.UserID && claims.Role != enum.AdminRoleType && claims.Role != enum.ReviewerRoleType {
+		res.FailWithMsg("只能查看自己文章的审核历史", c)
+		return
+	}
+
+	var _list []models.ArticleReviewModel
+	err = global.DB.Preload("SubmitterModel").Preload("ReviewerModel").
+		Where("article_id = ?", a.ID).Order("round asc").Find(&_list).Error
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	expiry := global.Config.Site.Article.ClaimExpiry()
+	list := make([]ArticleReviewResp, 0, len(_list))
+	for _, r := range _list {
+		r.ArticleModel = a
+		data := newArticleReviewResp(r, expiry)
+		data.ArticleAbstract = ""
+		list = append(list, data)
+	}
+	res.SuccessWithList(list, len(list), c)
+}
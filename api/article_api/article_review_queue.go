@@ -0,0 +1,86 @@
+// Path: ./api/article_api/article_review_queue.go
+
+package article_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type ArticleReviewQueueReq struct {
+	common.PageInfo
+	Type int8 `form:"type" binding:"oneof=0 1 2"` // 0 全部待审核 1 可认领（未认领或认领已超时） 2 我认领的
+}
+
+type ArticleReviewResp struct {
+	models.ArticleReviewModel
+	ArticleTitle      string `json:"articleTitle"`
+	ArticleAbstract   string `json:"articleAbstract,omitempty"`
+	SubmitterNickname string `json:"submitterNickname"`
+	ReviewerNickname  string `json:"reviewerNickname,omitempty"`
+	ClaimExpired      bool   `json:"claimExpired"` // 待审核时，认领是否已超时（或未认领）
+}
+
+func newArticleReviewResp(r models.ArticleReviewModel, expiry time.Duration) ArticleReviewResp {
+	data := ArticleReviewResp{
+		ArticleReviewModel: r,
+		ArticleTitle:       r.ArticleModel.Title,
+		ArticleAbstract:    r.ArticleModel.Abstract,
+		SubmitterNickname:  r.SubmitterModel.Nickname,
+	}
+	if r.ReviewerModel != nil {
+		data.ReviewerNickname = r.ReviewerModel.Nickname
+	}
+	if r.Status == enum.ReviewStatusPending {
+		data.ClaimExpired = r.ClaimExpired(expiry)
+	}
+	return data
+}
+
+// ArticleReviewQueueView 审核队列，先提交的排在前面
+// 只列出仍在待审核状态的文章，作者撤回或文章进了回收站的不会出现
+func (ArticleApi) ArticleReviewQueueView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleReviewQueueReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	req.PageInfo.Normalize()
+	expiry := global.Config.Site.Article.ClaimExpiry()
+
+	// 审核员看不到自己的文章，管理员可以审核自己的文章
+	articleQuery := global.DB.Model(&models.ArticleModel{}).Select("id").Where("status = ?", enum.ArticleStatusReview)
+	if claims.Role != enum.AdminRoleType {
+		articleQuery = articleQuery.Where("user_id <> ?", claims.UserID)
+	}
+	query := global.DB.Where("article_id in (?)", articleQuery)
+	switch req.Type {
+	case 1:
+		query = query.Where("reviewer_id is null or claimed_at < ?", time.Now().Add(-expiry))
+	case 2:
+		query = query.Where("reviewer_id = ? and claimed_at >= ?", claims.UserID, time.Now().Add(-expiry))
+	}
+
+	_list, count, err := common.ListQuery(
+		models.ArticleReviewModel{Status: enum.ReviewStatusPending},
+		common.Options{
+			PageInfo:     req.PageInfo,
+			Preloads:     []string{"ArticleModel", "SubmitterModel", "ReviewerModel"},
+			Where:        query,
+			DefaultOrder: "created_at asc",
+		})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ArticleReviewResp, 0, len(_list))
+	for _, r := range _list {
+		list = append(list, newArticleReviewResp(r, expiry))
+	}
+	res.SuccessWithList(list, count, c)
+}
@@ -0,0 +1,70 @@
+// Path: ./api/article_api/article_review_resubmit.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleReviewResubmitReq struct {
+	ArticleID uint   `json:"articleID" binding:"required"`
+	Note      string `json:"note" binding:"max=256"` // 修改说明，给审核员看
+}
+
+// ArticleReviewResubmitView 作者把退回修改或未通过的文章重新提交审核，开启新的一轮
+// 修改内容走正常的文章修改接口，这里只提交当前版本
+func (ArticleApi) ArticleReviewResubmitView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleReviewResubmitReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var a models.ArticleModel
+	err := global.DB.Take(&a, req.ArticleID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if a.UserID != claims.UserID {
+		res.FailWithMsg("只能提交自己的文章", c)
+		return
+	}
+	if a.Status != enum.ArticleStatusRevise && a.Status != enum.AritcleStatusFail {
+		res.FailWithMsg("只有退回修改或未通过的文章可以重新提交", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("重新提交审核")
+
+	// 免审核时直接发布
+	if global.Config.Site.Article.AutoApprove {
+		err = global.DB.Model(&a).Update("status", enum.ArticleStatusPublish).Error
+		if err != nil {
+			res.Fail(err, "提交失败", c)
+			return
+		}
+		redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+		series_service.SyncArticleSeries(a.ID)
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+		res.SuccessWithMsg("文章已发布", c)
+		return
+	}
+
+	err = transaction.ResubmitArticleTx(&a, claims.UserID, req.Note)
+	if err != nil {
+		res.Fail(err, "提交失败", c)
+		return
+	}
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	res.SuccessWithMsg("已重新提交审核", c)
+}
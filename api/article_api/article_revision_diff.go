@@ -0,0 +1,72 @@
+// Path: ./api/article_api/article_revision_diff.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/text_service"
+	"blogX_server/utils/diff"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleRevisionDiffReq struct {
+	OldID uint `form:"oldID" binding:"required"`
+	NewID uint `form:"newID" binding:"required"`
+}
+
+type ArticleRevisionDiffResp struct {
+	OldVersion int                  `json:"oldVersion"`
+	NewVersion int                  `json:"newVersion"`
+	Title      []diff.Line          `json:"title"`
+	Abstract   []diff.Line          `json:"abstract"`
+	Content    []diff.Line          `json:"content"`  // 正文行级差异
+	Sections   []diff.SectionChange `json:"sections"` // 按章节的差异
+}
+
+// ArticleRevisionDiffView 比较同一篇文章的两个修订版本
+func (ArticleApi) ArticleRevisionDiffView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleRevisionDiffReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var oldRev, newRev models.ArticleRevisionModel
+	err := global.DB.Preload("ArticleModel").Take(&oldRev, req.OldID).Error
+	if err != nil {
+		res.Fail(err, "修订版本不存在", c)
+		return
+	}
+	err = global.DB.Take(&newRev, req.NewID).Error
+	if err != nil {
+		res.Fail(err, "修订版本不存在", c)
+		return
+	}
+	if oldRev.ArticleID != newRev.ArticleID {
+		res.FailWithMsg("只能比较同一篇文章的修订版本", c)
+		return
+	}
+	if claims.UserID != oldRev.ArticleModel.UserID && claims.Role != enum.AdminRoleType && claims.Role != enum.ReviewerRoleType {
+		res.FailWithMsg("只能查看自己文章的修订历史", c)
+		return
+	}
+
+	resp := ArticleRevisionDiffResp{
+		OldVersion: oldRev.Version,
+		NewVersion: newRev.Version,
+		Title:      diff.Lines(oldRev.Title, newRev.Title),
+		Abstract:   diff.Lines(oldRev.Abstract, newRev.Abstract),
+		Content:    diff.Lines(oldRev.Content, newRev.Content),
+		Sections:   diff.Sections(revisionSections(&oldRev), revisionSections(&newRev)),
+	}
+	res.SuccessWithData(resp, c)
+}
+
+// revisionSections 用和 text_model 相同的规则把正文切成章节
+func revisionSections(rev *models.ArticleRevisionModel) (list []diff.Section) {
+	for _, t := range text_service.MDContentTransformation(rev.ArticleID, rev.Title, rev.Content) {
+		list = append(list, diff.Section{Head: t.Head, Body: t.Body})
+	}
+	return
+}
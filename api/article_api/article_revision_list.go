@@ -0,0 +1,99 @@
+// Path: ./api/article_api/article_revision_list.go
+
+package article_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type ArticleRevisionListReq struct {
+	common.PageInfo
+	ArticleID uint `form:"articleID" binding:"required"`
+}
+
+type ArticleRevisionListResp struct {
+	ID             uint                    `json:"id"`
+	CreatedAt      time.Time               `json:"createdAt"`
+	Version        int                     `json:"version"`
+	Source         enum.RevisionSourceType `json:"source"`
+	RestoreFrom    *int                    `json:"restoreFrom"`
+	Title          string                  `json:"title"`
+	Status         enum.ArticleStatus      `json:"status"`
+	EditorID       uint                    `json:"editorID"`
+	EditorNickname string                  `json:"editorNickname"`
+	EditorAvatar   string                  `json:"editorAvatar"`
+}
+
+// ArticleRevisionListView 文章的修订历史列表（不含正文）
+func (ArticleApi) ArticleRevisionListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleRevisionListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var a models.ArticleModel
+	err := global.DB.Take(&a, req.ArticleID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	// 审核员需要对比每一轮提交之间的修改
+	if claims.UserID != a.UserID && claims.Role != enum.AdminRoleType && claims.Role != enum.ReviewerRoleType {
+		res.FailWithMsg("只能查看自己文章的修订历史", c)
+		return
+	}
+
+	req.PageInfo.Normalize()
+
+	_list, count, err := common.ListQuery(
+		models.ArticleRevisionModel{ArticleID: req.ArticleID},
+		common.Options{
+			PageInfo:     req.PageInfo,
+			Preloads:     []string{"EditorModel"},
+			DefaultOrder: "version desc",
+		})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ArticleRevisionListResp, 0, len(_list))
+	for _, r := range _list {
+		list = append(list, ArticleRevisionListResp{
+			ID:             r.ID,
+			CreatedAt:      r.CreatedAt,
+			Version:        r.Version,
+			Source:         r.Source,
+			RestoreFrom:    r.RestoreFrom,
+			Title:          r.Title,
+			Status:         r.Status,
+			EditorID:       r.EditorID,
+			EditorNickname: r.EditorModel.Nickname,
+			EditorAvatar:   r.EditorModel.AvatarURL,
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
+
+// ArticleRevisionDetailView 某个修订版本的完整内容
+func (ArticleApi) ArticleRevisionDetailView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var rev models.ArticleRevisionModel
+	err := global.DB.Preload("ArticleModel").Take(&rev, req.ID).Error
+	if err != nil {
+		res.Fail(err, "修订版本不存在", c)
+		return
+	}
+	if claims.UserID != rev.ArticleModel.UserID && claims.Role != enum.AdminRoleType && claims.Role != enum.ReviewerRoleType {
+		res.FailWithMsg("只能查看自己文章的修订历史", c)
+		return
+	}
+	res.SuccessWithData(rev, c)
+}
@@ -0,0 +1,89 @@
+// Path: ./api/article_api/article_revision_restore.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"blogX_server/utils/xss"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleRevisionRestoreView 把文章回滚到某个修订版本
+// 回滚不会删除之后的版本，而是以目标版本的内容生成一个新版本
+func (ArticleApi) ArticleRevisionRestoreView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var target models.ArticleRevisionModel
+	err := global.DB.Take(&target, req.ID).Error
+	if err != nil {
+		res.Fail(err, "修订版本不存在", c)
+		return
+	}
+
+	var a models.ArticleModel
+	err = global.DB.Take(&a, target.ArticleID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if claims.UserID != a.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能回滚自己的文章", c)
+		return
+	}
+
+	// 分类可能在之后被删除了
+	if target.CategoryID != nil {
+		var cat models.CategoryModel
+		err = global.DB.Take(&cat, "id = ? and user_id = ?", *target.CategoryID, a.UserID).Error
+		if err != nil {
+			target.CategoryID = nil
+		}
+	}
+
+	// 历史版本可能是草稿时保存的或者审核没通过的，和修改文章一样过滤
+	target.Content = xss.Filter(target.Content)
+	target.Abstract = xss.Filter(target.Abstract)
+	review, err := sensitive_service.Filter(&target.Title, &target.Abstract, &target.Content)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if review && a.Status == enum.ArticleStatusSchedule {
+		res.FailWithMsg("文章内容需要人工审核，不能定时发布", c)
+		return
+	}
+
+	// log
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("回滚文章")
+	if claims.UserID != a.UserID {
+		log.ShowClaim(claims)
+		log.SetTitle("管理回滚文章")
+	}
+
+	rev, err := transaction.RestoreArticleRevisionTx(&a, &target, review, claims.UserID)
+	if err != nil {
+		res.Fail(err, "文章回滚失败", c)
+		return
+	}
+	log.SetItem(fmt.Sprintf("文章 %d 回滚", a.ID), fmt.Sprintf("版本 %d -> 新版本 %d", target.Version, rev.Version))
+
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	series_service.SyncArticleSeries(a.ID)
+	redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	res.SuccessWithMsg(fmt.Sprintf("已回滚到版本 %d", target.Version), c)
+	mention_service.SyncArticleByID(a.ID)
+}
@@ -0,0 +1,57 @@
+// Path: ./api/article_api/article_schedule_cancel.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleScheduleCancelView 取消定时发布，文章回到草稿状态
+func (ArticleApi) ArticleScheduleCancelView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var a models.ArticleModel
+	err := global.DB.Take(&a, req.ID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if claims.UserID != a.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能修改自己的文章", c)
+		return
+	}
+	if a.Status != enum.ArticleStatusSchedule {
+		res.FailWithMsg("该文章不处于定时发布状态", c)
+		return
+	}
+
+	// log
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("取消定时发布")
+	if claims.UserID != a.UserID {
+		log.ShowClaim(claims)
+	}
+
+	err = global.DB.Model(&a).Updates(map[string]any{
+		"status":     enum.ArticleStatusDraft,
+		"publish_at": nil,
+	}).Error
+	if err != nil {
+		res.Fail(err, "取消定时发布失败", c)
+		return
+	}
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	series_service.SyncArticleSeries(a.ID)
+	res.SuccessWithMsg("已取消定时发布，文章已转为草稿", c)
+}
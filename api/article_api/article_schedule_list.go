@@ -0,0 +1,61 @@
+// Path: ./api/article_api/article_schedule_list.go
+
+package article_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleScheduleListReq struct {
+	common.PageInfo
+	UserID uint `form:"userID"` // 仅管理员可用，不传就是所有人
+}
+
+// ArticleScheduleListView 定时发布的文章列表，按发布时间先后排序
+func (ArticleApi) ArticleScheduleListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleScheduleListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	// 非管理员只能查自己
+	if claims.Role != enum.AdminRoleType {
+		req.UserID = claims.UserID
+	}
+
+	req.PageInfo.Normalize()
+
+	_list, count, err := common.ListQuery(
+		models.ArticleModel{
+			UserID: req.UserID,
+			Status: enum.ArticleStatusSchedule,
+		},
+		common.Options{
+			PageInfo:     req.PageInfo,
+			Likes:        []string{"title"},
+			Preloads:     []string{"UserModel", "CategoryModel"},
+			DefaultOrder: "publish_at asc",
+		})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ArticleListResp, 0, len(_list))
+	for _, article := range _list {
+		article.Content = "" // 正文在 list 中不返回
+		data := ArticleListResp{
+			ArticleModel:  article,
+			UserNickname:  article.UserModel.Nickname,
+			UserAvatarURL: article.UserModel.AvatarURL,
+		}
+		if article.CategoryModel != nil {
+			data.CategoryName = &article.CategoryModel.Name
+		}
+		list = append(list, data)
+	}
+	res.SuccessWithList(list, count, c)
+}
@@ -0,0 +1,85 @@
+// Path: ./api/article_api/article_schedule_update.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type ArticleScheduleUpdateReq struct {
+	ID        uint   `json:"id" binding:"required"`
+	PublishAt string `json:"publishAt" binding:"required"` // format "2006-01-02 15:04:05"
+}
+
+// ArticleScheduleUpdateView 修改定时发布时间，草稿也可以通过这个接口设为定时发布
+func (ArticleApi) ArticleScheduleUpdateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleScheduleUpdateReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	publishAt, err := parsePublishAt(req.PublishAt)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	var a models.ArticleModel
+	err = global.DB.Take(&a, req.ID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if claims.UserID != a.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能修改自己的文章", c)
+		return
+	}
+	if a.Status != enum.ArticleStatusSchedule && a.Status != enum.ArticleStatusDraft {
+		res.FailWithMsg("只有草稿和定时发布的文章可以设置发布时间", c)
+		return
+	}
+
+	// log
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("修改定时发布")
+	if claims.UserID != a.UserID {
+		log.ShowClaim(claims)
+	}
+
+	err = global.DB.Model(&a).Updates(map[string]any{
+		"status":     enum.ArticleStatusSchedule,
+		"publish_at": publishAt,
+	}).Error
+	if err != nil {
+		res.Fail(err, "定时发布设置失败", c)
+		return
+	}
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	series_service.SyncArticleSeries(a.ID)
+	res.SuccessWithMsg(fmt.Sprintf("文章将于 %s 发布", publishAt.Format("2006-01-02 15:04:05")), c)
+}
+
+// parsePublishAt 解析定时发布时间，必须是将来的时间
+func parsePublishAt(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, errors.New("定时发布必须指定发布时间")
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("发布时间[%s]格式错误: %s", s, err.Error())
+	}
+	if !t.After(time.Now()) {
+		return nil, errors.New("发布时间必须晚于当前时间")
+	}
+	return &t, nil
+}
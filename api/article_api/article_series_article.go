@@ -0,0 +1,156 @@
+// Path: ./api/article_api/article_series_article.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleSeriesArticleReq struct {
+	SeriesID  uint `json:"seriesID" binding:"required"`
+	ArticleID uint `json:"articleID" binding:"required"`
+}
+
+// ArticleSeriesAddView 把文章加入系列（追加到末尾），只能加入自己的文章
+func (ArticleApi) ArticleSeriesAddView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleSeriesArticleReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var s models.SeriesModel
+	err := global.DB.Take(&s, req.SeriesID).Error
+	if err != nil {
+		res.Fail(err, "系列不存在", c)
+		return
+	}
+	if claims.UserID != s.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能修改自己的系列", c)
+		return
+	}
+
+	var a models.ArticleModel
+	err = global.DB.Take(&a, req.ArticleID).Error
+	if err != nil {
+		res.Fail(err, "文章不存在", c)
+		return
+	}
+	if a.UserID != s.UserID {
+		res.FailWithMsg("只能把系列作者自己的文章加入系列", c)
+		return
+	}
+
+	var sa models.SeriesArticleModel
+	err = global.DB.Take(&sa, "article_id = ?", a.ID).Error
+	if err == nil {
+		if sa.SeriesID == s.ID {
+			res.FailWithMsg("文章已在该系列中", c)
+			return
+		}
+		res.FailWithMsg("文章已属于其他系列，请先移出", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("文章加入系列")
+
+	err = transaction.AddArticleToSeriesTx(&s, a.ID)
+	if err != nil {
+		res.Fail(err, "加入系列失败", c)
+		return
+	}
+	series_service.SyncSeries(s.ID)
+	res.SuccessWithMsg("加入系列成功", c)
+}
+
+// ArticleSeriesRemoveArticleView 把文章移出系列
+func (ArticleApi) ArticleSeriesRemoveArticleView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleSeriesArticleReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var sa models.SeriesArticleModel
+	err := global.DB.Preload("SeriesModel").Take(&sa, "series_id = ? and article_id = ?", req.SeriesID, req.ArticleID).Error
+	if err != nil {
+		res.Fail(err, "文章不在该系列中", c)
+		return
+	}
+	if claims.UserID != sa.SeriesModel.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能修改自己的系列", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("文章移出系列")
+
+	// 移出前清缓存，包含被移出的文章本身
+	series_service.CloseSeriesCache(sa.SeriesID)
+	err = transaction.RemoveArticleFromSeriesTx(&sa)
+	if err != nil {
+		res.Fail(err, "移出系列失败", c)
+		return
+	}
+	series_service.SyncSeries(sa.SeriesID)
+	res.SuccessWithMsg("移出系列成功", c)
+}
+
+type ArticleSeriesSortReq struct {
+	SeriesID      uint   `json:"seriesID" binding:"required"`
+	ArticleIDList []uint `json:"articleIDList" binding:"required"` // 系列中全部文章的 id，按新的顺序排列
+}
+
+// ArticleSeriesSortView 调整系列中文章的顺序
+func (ArticleApi) ArticleSeriesSortView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleSeriesSortReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var s models.SeriesModel
+	err := global.DB.Take(&s, req.SeriesID).Error
+	if err != nil {
+		res.Fail(err, "系列不存在", c)
+		return
+	}
+	if claims.UserID != s.UserID && claims.Role != enum.AdminRoleType {
+		res.FailWithMsg("只能修改自己的系列", c)
+		return
+	}
+
+	// 传入的必须正好是系列中的全部文章
+	var idList []uint
+	global.DB.Model(&models.SeriesArticleModel{}).Where("series_id = ?", s.ID).Pluck("article_id", &idList)
+	set := make(map[uint]struct{}, len(idList))
+	for _, id := range idList {
+		set[id] = struct{}{}
+	}
+	for _, id := range req.ArticleIDList {
+		if _, ok := set[id]; !ok {
+			res.FailWithMsg(fmt.Sprintf("文章 %d 不在该系列中", id), c)
+			return
+		}
+		delete(set, id)
+	}
+	if len(set) != 0 || len(req.ArticleIDList) != len(idList) {
+		res.FailWithMsg("文章列表与系列不一致", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("调整系列顺序")
+
+	err = transaction.SortSeriesTx(s.ID, req.ArticleIDList)
+	if err != nil {
+		res.Fail(err, "调整顺序失败", c)
+		return
+	}
+	series_service.CloseSeriesCache(s.ID)
+	res.SuccessWithMsg("调整顺序成功", c)
+}
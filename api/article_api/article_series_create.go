@@ -0,0 +1,99 @@
+// Path: ./api/article_api/article_series_create.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type ArticleSeriesCreateReq struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title" binding:"required,max=64"`
+	Abstract string `json:"abstract" binding:"max=256"`
+	CoverURL string `json:"coverURL"`
+}
+
+// ArticleSeriesCreateView 创建或修改系列，和分类一样，有 id 就是更新，没有 id 就是创建
+func (ArticleApi) ArticleSeriesCreateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleSeriesCreateReq)
+	if err := sensitive_service.FilterNoReview(&req.Title, &req.Abstract); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	log := log_service.GetActionLog(c)
+	log.ShowRequest()
+	log.ShowResponse()
+	log.SetLevel(enum.LogTraceLevel)
+
+	// 新建逻辑
+	if req.ID == 0 {
+		var s models.SeriesModel
+		err := global.DB.Where("user_id = ? AND title = ?", claims.UserID, req.Title).Take(&s).Error
+		if err == nil {
+			res.FailWithMsg("系列已存在", c)
+			return
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			res.Fail(err, "查询数据库失败", c)
+			return
+		}
+
+		log.SetTitle("创建文章系列")
+
+		err = global.DB.Create(&models.SeriesModel{
+			UserID:   claims.UserID,
+			Title:    req.Title,
+			Abstract: req.Abstract,
+			CoverURL: req.CoverURL,
+		}).Error
+		if err != nil {
+			res.Fail(err, "系列创建失败", c)
+			return
+		}
+		res.SuccessWithMsg("系列创建成功", c)
+		return
+	}
+
+	// 更新逻辑
+	var s models.SeriesModel
+	err := global.DB.Take(&s, req.ID).Error
+	if err != nil {
+		res.Fail(err, "系列不存在", c)
+		return
+	}
+	if claims.Role != enum.AdminRoleType && claims.UserID != s.UserID {
+		res.FailWithMsg("只能修改自己的系列", c)
+		return
+	}
+
+	log.SetTitle("更新文章系列")
+
+	err = global.DB.Model(&s).Updates(map[string]any{
+		"title":     req.Title,
+		"abstract":  req.Abstract,
+		"cover_url": req.CoverURL,
+	}).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			res.Fail(err, "系列已存在", c)
+			return
+		}
+		res.Fail(err, "系列更新失败", c)
+		return
+	}
+	// 系列名称显示在文章详情中
+	series_service.CloseSeriesCache(s.ID)
+	res.SuccessWithMsg("系列更新成功", c)
+}
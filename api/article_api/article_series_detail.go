@@ -0,0 +1,74 @@
+// Path: ./api/article_api/article_series_detail.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type ArticleSeriesDetailResp struct {
+	models.SeriesModel
+	UserNickname  string                     `json:"userNickname"`
+	UserAvatarURL string                     `json:"userAvatarURL"`
+	ArticleList   []ArticleSeriesArticleResp `json:"articleList"`
+}
+
+type ArticleSeriesArticleResp struct {
+	ID        uint               `json:"id"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Title     string             `json:"title"`
+	Abstract  string             `json:"abstract"`
+	CoverURL  string             `json:"coverURL"`
+	Status    enum.ArticleStatus `json:"status"`
+}
+
+// ArticleSeriesDetailView 系列详情，文章按系列中的顺序返回
+// 作者本人和管理员可以看到系列中未发布的文章
+func (ArticleApi) ArticleSeriesDetailView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+
+	var s models.SeriesModel
+	err := global.DB.Preload("UserModel").Take(&s, req.ID).Error
+	if err != nil {
+		res.Fail(err, "系列不存在", c)
+		return
+	}
+
+	onlyPublished := true
+	claims, err := jwts.ParseTokenFromRequest(c)
+	if err == nil && claims != nil && (claims.UserID == s.UserID || claims.Role == enum.AdminRoleType) {
+		onlyPublished = false
+	}
+
+	_list, err := series_service.GetSeriesArticleList(s.ID, onlyPublished)
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ArticleSeriesArticleResp, 0, len(_list))
+	for _, a := range _list {
+		list = append(list, ArticleSeriesArticleResp{
+			ID:        a.ID,
+			CreatedAt: a.CreatedAt,
+			Title:     a.Title,
+			Abstract:  a.Abstract,
+			CoverURL:  a.CoverURL,
+			Status:    a.Status,
+		})
+	}
+
+	res.SuccessWithData(ArticleSeriesDetailResp{
+		SeriesModel:   s,
+		UserNickname:  s.UserModel.Nickname,
+		UserAvatarURL: s.UserModel.AvatarURL,
+		ArticleList:   list,
+	}, c)
+}
@@ -0,0 +1,44 @@
+// Path: ./api/article_api/article_series_list.go
+
+package article_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/models"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleSeriesListReq struct {
+	common.PageInfo
+	UserID uint `form:"userID"`
+}
+
+// ArticleSeriesListView 某个用户的系列列表，不指定用户时查自己
+func (ArticleApi) ArticleSeriesListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ArticleSeriesListReq)
+
+	if req.UserID == 0 {
+		claims, err := jwts.ParseTokenFromRequest(c)
+		if err != nil || claims == nil {
+			res.FailWithMsg("未指定查询 id", c)
+			return
+		}
+		req.UserID = claims.UserID
+	}
+
+	req.PageInfo.Normalize()
+
+	list, count, err := common.ListQuery(
+		models.SeriesModel{UserID: req.UserID},
+		common.Options{
+			PageInfo: req.PageInfo,
+			Likes:    []string{"title"},
+		})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+	res.SuccessWithList(list, count, c)
+}
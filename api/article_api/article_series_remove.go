@@ -0,0 +1,70 @@
+// Path: ./api/article_api/article_series_remove.go
+
+package article_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/series_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleSeriesRemoveView 批量删除系列，系列中的文章不会被删除
+func (ArticleApi) ArticleSeriesRemoveView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDListRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if len(req.IDList) == 0 {
+		res.FailWithMsg("没有指定删除对象", c)
+		return
+	}
+
+	var list []models.SeriesModel
+	err := global.DB.Where("id IN ?", req.IDList).Find(&list).Error
+	if err != nil {
+		res.Fail(err, "查询数据库失败", c)
+		return
+	}
+	if len(list) != len(req.IDList) {
+		res.FailWithMsg("部分记录不存在或无权限访问", c)
+		return
+	}
+
+	// 日志
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("批量删除文章系列失败")
+
+	var succ []uint
+	for _, s := range list {
+		if s.UserID != claims.UserID {
+			if claims.Role != enum.AdminRoleType {
+				log.SetItem("权限不足", fmt.Sprintf("文章系列[id: %d][title: %s][belongs to: %d]", s.ID, s.Title, s.UserID))
+				continue
+			}
+			log.ShowClaim(claims)
+		}
+		// 先清缓存，删除之后就查不到系列中的文章了
+		series_service.CloseSeriesCache(s.ID)
+		err = transaction.RemoveSeries(&s)
+		if err != nil {
+			log.SetItem("失败", fmt.Sprintf("文章系列[id: %d][title: %s]", s.ID, s.Title))
+			continue
+		}
+		log.SetItem("成功", fmt.Sprintf("文章系列[id: %d][title: %s]", s.ID, s.Title))
+		succ = append(succ, s.ID)
+	}
+
+	if len(succ) == 0 {
+		res.FailWithMsg("批量删除系列失败", c)
+		return
+	}
+	log.SetTitle("批量删除文章系列成功")
+	res.SuccessWithMsg(fmt.Sprintf("批量删除系列完成，共计 %d 条，删除 %d 条: %v", len(list), len(succ), succ), c)
+}
@@ -4,29 +4,37 @@ package article_api
 
 import (
 	"blogX_server/common/res"
+	"blogX_server/common/transaction"
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/models/ctype"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
 	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/service/series_service"
 	"blogX_server/utils/jwts"
 	"blogX_server/utils/markdown"
 	"blogX_server/utils/xss"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"time"
 )
 
 type ArticleUpdateReq struct {
-	ID             uint               `json:"id" binding:"required"`
-	Title          string             `json:"title" binding:"required"`
-	Abstract       string             `json:"abstract"`
-	CoverURL       string             `json:"coverURL"`
-	Content        string             `json:"content" binding:"required"`
-	CategoryID     *uint              `json:"categoryID"`
-	Tags           ctype.List         `json:"tags"`
-	OpenForComment bool               `json:"openForComment"`
-	Status         enum.ArticleStatus `json:"status" binding:"required,oneof=1 2"` // 点提交就是 2，点存为草稿就是 1
+	ID                  uint                   `json:"id" binding:"required"`
+	Title               string                 `json:"title" binding:"required"`
+	Abstract            string                 `json:"abstract"`
+	CoverURL            string                 `json:"coverURL"`
+	Content             string                 `json:"content" binding:"required"`
+	CategoryID          *uint                  `json:"categoryID"`
+	Tags                ctype.List             `json:"tags"`
+	OpenForComment      bool                   `json:"openForComment"`
+	OpenForGuestComment bool                   `json:"openForGuestComment"`                       // 博客模式下允许游客评论
+	CommentModeration   enum.CommentModeration `json:"commentModeration" binding:"oneof=0 1 2 3"` // 0 跟随站点设置 1 不审核 2 含链接或新账号需审核 3 全部审核
+	Status              enum.ArticleStatus     `json:"status" binding:"required,oneof=1 2 5"`     // 点提交就是 2，点存为草稿就是 1，定时发布是 5
+	PublishAt           string                 `json:"publishAt"`                                 // 定时发布时间 "2006-01-02 15:04:05"，status 为 5 时必填
 }
 
 func (ArticleApi) ArticleUpdateView(c *gin.Context) {
@@ -47,6 +55,16 @@ func (ArticleApi) ArticleUpdateView(c *gin.Context) {
 		return
 	}
 
+	// 定时发布
+	var publishAt *time.Time
+	if req.Status == enum.ArticleStatusSchedule {
+		publishAt, err = parsePublishAt(req.PublishAt)
+		if err != nil {
+			res.FailWithMsg(err.Error(), c)
+			return
+		}
+	}
+
 	// 取分类
 	var cat models.CategoryModel
 	if req.CategoryID != nil {
@@ -80,6 +98,17 @@ func (ArticleApi) ArticleUpdateView(c *gin.Context) {
 		req.Abstract = txt
 	}
 
+	// 敏感词：拦截词直接拒绝，打码词替换为 *，需要审核的词不走自动审核
+	review, err := sensitive_service.Filter(&req.Title, &req.Abstract, &req.Content)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if review && req.Status == enum.ArticleStatusSchedule {
+		res.FailWithMsg("文章内容需要人工审核，不能定时发布", c)
+		return
+	}
+
 	// 正文内容图片转存给前端去做。后端留了个接口 ImageCache
 
 	// log
@@ -92,27 +121,33 @@ func (ArticleApi) ArticleUpdateView(c *gin.Context) {
 	}
 
 	m := map[string]any{
-		"title":            req.Title,
-		"abstract":         req.Abstract,
-		"cover_url":        req.CoverURL,
-		"content":          req.Content,
-		"category_id":      req.CategoryID,
-		"Tags":             req.Tags,
-		"open_for_comment": req.OpenForComment,
-		"status":           req.Status,
+		"title":                  req.Title,
+		"abstract":               req.Abstract,
+		"cover_url":              req.CoverURL,
+		"content":                req.Content,
+		"category_id":            req.CategoryID,
+		"Tags":                   req.Tags,
+		"open_for_comment":       req.OpenForComment,
+		"open_for_guest_comment": req.OpenForGuestComment,
+		"comment_moderation":     req.CommentModeration,
+		"status":                 req.Status,
+		"publish_at":             publishAt, // 非定时发布时会清空
 	}
-	if req.Status == enum.ArticleStatusReview && global.Config.Site.Article.AutoApprove {
+	if req.Status == enum.ArticleStatusReview && global.Config.Site.Article.AutoApprove && !review {
 		m["status"] = enum.ArticleStatusPublish
 	} else {
 		// TODO 要把已收藏这篇文章的取消
 	}
 
-	// 入库
-	err = global.DB.Model(&a).Updates(m).Error
+	// 入库，同时记录修订版本
+	err = transaction.UpdateArticleWithRevisionTx(&a, m, claims.UserID)
 	if err != nil {
 		res.Fail(err, "文章修改失败", c)
 		return
 	}
 	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	series_service.SyncArticleSeries(a.ID)
+	redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
 	res.SuccessWithMsg("文章修改成功", c)
+	mention_service.SyncArticleByID(a.ID)
 }
@@ -9,9 +9,9 @@ import (
 	"blogX_server/models/enum"
 	"blogX_server/service/comment_service"
 	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
 	"blogX_server/service/message_service"
-	"blogX_server/service/redis_service/redis_article"
-	"blogX_server/service/redis_service/redis_comment"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"blogX_server/utils/xss"
 	"errors"
@@ -29,63 +29,33 @@ func (CommentApi) CommentCreateView(c *gin.Context) {
 	req := c.MustGet("bindReq").(CommentCreateReq)
 	claims := jwts.MustGetClaimsFromRequest(c)
 
-	if req.ArticleID == 0 && req.ParentID == nil {
-		res.FailWithMsg("请输入文章 id 或父评论 id", c)
+	t, err := resolveTarget(req.ArticleID, req.ParentID)
+	if err != nil {
+		res.FailWithError(err, c)
 		return
 	}
+	article := t.Article
 
-	var err error
-	if req.ArticleID != 0 {
-		_, err = verifyArticle(req.ArticleID)
-		if err != nil {
-			res.FailWithError(err, c)
-			return
-		}
-	}
-
-	// 确定深度以及根评论 ID
-	var depth = 0
-	var rootID *uint
-	var parent models.CommentModel
-	if req.ParentID != nil {
-		err := global.DB.Take(&parent, req.ParentID).Error
-		if err != nil {
-			res.Fail(err, "获取父评论失败", c)
-			return
-		}
-		// 文章 id
-		if req.ArticleID != 0 {
-			// 校验文章 id
-			if parent.ArticleID != req.ArticleID {
-				res.FailWithMsg("文章 id 或父评论 id 错误", c)
-				return
-			}
-		} else {
-			// 获取文章 id
-			req.ArticleID = parent.ArticleID
-		}
+	req.Content = xss.Filter(req.Content)
 
-		// 本次评论的 root 及 depth
-		depth = parent.Depth + 1
-		if parent.RootID == nil {
-			rootID = &parent.ID
-		} else {
-			rootID = parent.RootID
-		}
-		if depth >= global.Config.Site.Article.CommentDepth {
-			res.FailWithMsg("评论层级超过限制", c)
-			return
-		}
+	// 敏感词：拦截词直接拒绝，打码词替换为 *，需要审核的词进入审核队列
+	review, err := sensitive_service.Filter(&req.Content)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
 	}
 
-	// 校验文章
-	article, err := verifyArticle(req.ArticleID)
+	// 先审后发
+	var user models.UserModel
+	err = global.DB.Take(&user, claims.UserID).Error
 	if err != nil {
-		res.FailWithError(err, c)
+		res.Fail(err, "用户不存在", c)
 		return
 	}
-
-	req.Content = xss.Filter(req.Content)
+	status := enum.CommentStatusPublished
+	if review || comment_service.NeedModeration(article, user, claims.Role, req.Content) {
+		status = enum.CommentStatusPending
+	}
 
 	log := log_service.GetActionLog(c)
 	log.ShowAll()
@@ -95,10 +65,11 @@ func (CommentApi) CommentCreateView(c *gin.Context) {
 	var cmt = models.CommentModel{
 		UserID:    claims.UserID,
 		Content:   req.Content,
-		ArticleID: req.ArticleID,
+		ArticleID: article.ID,
 		ParentID:  req.ParentID,
-		RootID:    rootID,
-		Depth:     depth,
+		RootID:    t.RootID,
+		Depth:     t.Depth,
+		Status:    status,
 	}
 
 	// 入库
@@ -107,21 +78,26 @@ func (CommentApi) CommentCreateView(c *gin.Context) {
 		res.Fail(err, "无法评论该文章", c)
 		return
 	}
+	err = comment_service.AutoWatch(cmt)
+	if err != nil {
+		log.SetItemWarn("自动关注失败", err.Error())
+	}
 
-	// 更新祖先评论的回复量
-	if cmt.ParentID != nil {
-		ancestors, err := comment_service.GetAncestors(*cmt.ParentID)
-		if err != nil {
-			res.Fail(err, "获取父评论失败", c)
-			return
-		}
-		for _, ans := range ancestors {
-			redis_comment.AddCommentReplyCount(ans.ID)
-		}
+	// 待审核的评论不计入评论数，也不发提醒，审核通过时再处理
+	if status == enum.CommentStatusPending {
+		cmt.ArticleModel = article
+		mention_service.SyncComment(cmt)
+		log.SetTitle("创建评论成功，等待审核")
+		res.SuccessWithMsg("评论已提交，审核通过后展示", c)
+		return
 	}
 
-	// 更新文章回复量
-	redis_article.AddArticleComment(req.ArticleID)
+	// 更新祖先评论的回复量、文章回复量
+	err = comment_service.AddPublishedCount(cmt)
+	if err != nil {
+		res.Fail(err, "获取父评论失败", c)
+		return
+	}
 
 	log.SetTitle("创建评论成功")
 	res.SuccessWithMsg("创建评论成功", c)
@@ -131,12 +107,59 @@ func (CommentApi) CommentCreateView(c *gin.Context) {
 	// 进去内部函数在 preload  cmt的fk，总是会报错
 	// 怀疑是 mysql 没有那么快写入并可读取
 	// 所以在外部把相关字段填好再传入吧
-	cmt.ParentModel = &parent
+	cmt.ParentModel = &t.Parent
 	cmt.ArticleModel = article
 	err = message_service.SendCommentNotify(cmt)
 	if err != nil {
 		log.SetItemWarn("消息发送失败", err.Error())
 	}
+	mention_service.SyncComment(cmt)
+	err = message_service.SendWatchNotify(cmt)
+	if err != nil {
+		log.SetItemWarn("关注提醒发送失败", err.Error())
+	}
+}
+
+// commentTarget 新评论的位置
+type commentTarget struct {
+	Article models.ArticleModel
+	Parent  models.CommentModel // 回复时为父评论，否则为空
+	RootID  *uint
+	Depth   int
+}
+
+// resolveTarget 校验文章和父评论，确定新评论的根评论和深度
+// 只传父评论 id 时，文章 id 从父评论中获取
+func resolveTarget(articleID uint, parentID *uint) (t commentTarget, err error) {
+	if articleID == 0 && parentID == nil {
+		return t, errors.New("请输入文章 id 或父评论 id")
+	}
+
+	if parentID != nil {
+		err = global.DB.Take(&t.Parent, parentID).Error
+		// 待审核、未通过的评论不能回复
+		if err != nil || t.Parent.Status != enum.CommentStatusPublished {
+			return t, errors.New("父评论不存在")
+		}
+		if articleID != 0 && t.Parent.ArticleID != articleID {
+			return t, errors.New("文章 id 或父评论 id 错误")
+		}
+		articleID = t.Parent.ArticleID
+
+		// 本次评论的 root 及 depth
+		t.Depth = t.Parent.Depth + 1
+		if t.Parent.RootID == nil {
+			t.RootID = &t.Parent.ID
+		} else {
+			t.RootID = t.Parent.RootID
+		}
+		if t.Depth >= global.Config.Site.Article.CommentDepth {
+			return t, errors.New("评论层级超过限制")
+		}
+	}
+
+	t.Article, err = verifyArticle(articleID)
+	return
 }
 
 func verifyArticle(articleID uint) (article models.ArticleModel, err error) {
@@ -0,0 +1,161 @@
+// Path: ./api/comment_api/comment_guest.go
+
+package comment_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/service/email_service"
+	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/xss"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/url"
+	"strings"
+)
+
+type GuestCommentCreateReq struct {
+	Content   string `json:"content" binding:"required"`
+	ArticleID uint   `json:"articleID"`
+	ParentID  *uint  `json:"parentID"`
+	Nickname  string `json:"nickname" binding:"required,max=32"`
+	Email     string `json:"email" binding:"required,email,max=256"`
+}
+
+// verifyGuestTarget 只有博客模式下、文章开启了游客评论时游客才能评论
+func verifyGuestTarget(articleID uint, parentID *uint) (t commentTarget, err error) {
+	if global.Config.Site.SiteInfo.Mode != 2 {
+		return t, errors.New("当前站点不允许游客评论")
+	}
+	t, err = resolveTarget(articleID, parentID)
+	if err != nil {
+		return
+	}
+	if !t.Article.OpenForGuestComment {
+		return t, errors.New("该文章不允许游客评论，请登录后评论")
+	}
+	return
+}
+
+// GuestCommentCreateView 游客评论，先发确认邮件，点击邮件中的链接后评论才会进入审核队列
+func (CommentApi) GuestCommentCreateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(GuestCommentCreateReq)
+
+	t, err := verifyGuestTarget(req.ArticleID, req.ParentID)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	req.Email = comment_service.NormalizeEmail(req.Email)
+	if !email_service.IsValidWithDomain(req.Email) {
+		res.FailWithMsg("非法邮箱地址", c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.UserModel{}).Where("email = ?", req.Email).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("该邮箱已注册，请登录后评论", c)
+		return
+	}
+
+	req.Nickname = strings.TrimSpace(req.Nickname)
+	req.Content = xss.Filter(req.Content)
+	if err = sensitive_service.FilterNoReview(&req.Nickname); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	// 游客评论都要审核，需要审核的敏感词不用单独处理
+	if _, err = sensitive_service.Filter(&req.Content); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if req.Nickname == "" {
+		res.FailWithMsg("请输入昵称", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("游客评论")
+
+	token, err := comment_service.SaveGuestComment(comment_service.GuestComment{
+		ArticleID: t.Article.ID,
+		ParentID:  req.ParentID,
+		Content:   req.Content,
+		Nickname:  req.Nickname,
+		Email:     req.Email,
+		IP:        c.ClientIP(),
+	})
+	if err != nil {
+		res.Fail(err, "评论提交失败", c)
+		return
+	}
+
+	hours := int(comment_service.GuestVerifyExpiry.Hours())
+	link := fmt.Sprintf("%s/api/comment/guest/verify?token=%s", global.Config.Site.BaseURL(), url.QueryEscape(token))
+	err = email_service.SendGuestCommentVerify(req.Email, req.Nickname, t.Article.Title, link, hours)
+	if err != nil {
+		comment_service.RemoveGuestComment(token)
+		res.Fail(err, "确认邮件发送失败", c)
+		return
+	}
+	res.SuccessWithMsg(fmt.Sprintf("确认邮件已发送，请在 %d 小时内点击邮件中的链接", hours), c)
+}
+
+type GuestCommentVerifyReq struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// GuestCommentVerifyView 游客点击邮件中的链接确认评论，评论以占位用户的身份入库，等待审核
+func (CommentApi) GuestCommentVerifyView(c *gin.Context) {
+	req := c.MustGet("bindReq").(GuestCommentVerifyReq)
+
+	gc, err := comment_service.TakeGuestComment(req.Token)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	// 确认前文章可能关闭了评论，父评论可能被删除，需要重新校验
+	t, err := verifyGuestTarget(gc.ArticleID, gc.ParentID)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	user, err := comment_service.GuestUser(gc.Email, gc.Nickname)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("游客评论确认")
+	log.SetItem("ip", gc.IP)
+
+	cmt := models.CommentModel{
+		UserID:    user.ID,
+		Content:   gc.Content,
+		ArticleID: t.Article.ID,
+		ParentID:  gc.ParentID,
+		RootID:    t.RootID,
+		Depth:     t.Depth,
+		Status:    enum.CommentStatusPending,
+	}
+	err = global.DB.Create(&cmt).Error
+	if err != nil {
+		res.Fail(err, "评论确认失败", c)
+		return
+	}
+	cmt.ArticleModel = t.Article
+	mention_service.SyncComment(cmt)
+	res.SuccessWithMsg("评论已确认，审核通过后展示", c)
+}
@@ -30,6 +30,10 @@ func (CommentApi) CommentLikeView(c *gin.Context) {
 		res.Fail(err, "评论不存在", c)
 		return
 	}
+	if cmt.Status != enum.CommentStatusPublished {
+		res.FailWithMsg("评论未发布", c)
+		return
+	}
 
 	uid := jwts.MustGetClaimsFromRequest(c).UserID
 
@@ -20,9 +20,10 @@ import (
 
 type CommentListReq struct {
 	common.PageInfo
-	Type      uint `form:"type" binding:"oneof=1 2 3"` // 1-我的文章收到的评论 2-我发的评论 3-管理员
-	ArticleId uint `form:"articleID"`
-	UserId    uint `form:"userID"`
+	Type      uint               `form:"type" binding:"oneof=1 2 3"` // 1-我的文章收到的评论 2-我发的评论 3-管理员
+	ArticleId uint               `form:"articleID"`
+	UserId    uint               `form:"userID"`
+	Status    enum.CommentStatus `form:"status"` // 不传时：我发的评论查全部，其他只查已发布的
 }
 
 type CommentListResponse struct {
@@ -36,6 +37,10 @@ type CommentListResponse struct {
 	ArticleTitle    string                     `json:"articleTitle"`
 	ArticleCoverURL string                     `json:"articleCoverURL"`
 	LikeCount       int                        `json:"likeCount"`
+	Status          enum.CommentStatus         `json:"status"`
+	RejectReason    string                     `json:"rejectReason,omitempty"`
+	Edited          bool                       `json:"edited"`
+	EditedAt        *time.Time                 `json:"editedAt"`
 	Relation        relationship_enum.Relation `json:"relation,omitempty"`
 	IsMe            bool                       `json:"isMe"`
 }
@@ -87,6 +92,14 @@ func (CommentApi) CommentListView(c *gin.Context) {
 		}
 	}
 
+	// 待审核、未通过的评论，评论者自己可以看到，其他人需要指定状态查询
+	if req.Status == 0 && req.Type != 2 {
+		req.Status = enum.CommentStatusPublished
+	}
+	if req.Status != 0 {
+		query = query.Where("status = ?", req.Status)
+	}
+
 	// 解析时间戳并查询
 	var err error
 	if req.StartTime != "" || req.EndTime != "" {
@@ -134,6 +147,10 @@ func (CommentApi) CommentListView(c *gin.Context) {
 			ArticleTitle:    cmt.ArticleModel.Title,
 			ArticleCoverURL: cmt.ArticleModel.CoverURL,
 			LikeCount:       cmt.LikeCount + redis_comment.GetCommentLikeCount(cmt.ID),
+			Status:          cmt.Status,
+			RejectReason:    cmt.RejectReason,
+			Edited:          cmt.EditCount > 0,
+			EditedAt:        cmt.EditedAt,
 			Relation:        relationMap[cmt.UserID],
 			IsMe:            cmt.UserID == claims.UserID,
 		})
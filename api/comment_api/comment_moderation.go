@@ -0,0 +1,144 @@
+// Path: ./api/comment_api/comment_moderation.go
+
+package comment_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/message_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentModerationListReq struct {
+	common.PageInfo
+	ArticleID uint `form:"articleID"`
+}
+
+// CommentModerationListView 待审核的评论，先提交的排在前面
+// 管理员可以看到所有的，文章作者只能看到自己文章下的
+func (CommentApi) CommentModerationListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentModerationListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	query := global.DB.Where("status = ?", enum.CommentStatusPending)
+	if claims.Role != enum.AdminRoleType {
+		query = query.Where("article_id IN (?)", global.DB.Model(&models.ArticleModel{}).
+			Where("user_id = ?", claims.UserID).Select("id"))
+	}
+
+	req.PageInfo.Normalize()
+	_list, count, err := common.ListQuery(models.CommentModel{
+		ArticleID: req.ArticleID,
+	}, common.Options{
+		PageInfo:     req.PageInfo,
+		Likes:        []string{"content"},
+		Preloads:     []string{"UserModel", "ArticleModel"},
+		Where:        query,
+		DefaultOrder: "created_at asc",
+	})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]CommentListResponse, 0, len(_list))
+	for _, cmt := range _list {
+		list = append(list, CommentListResponse{
+			ID:              cmt.ID,
+			CreatedAt:       cmt.CreatedAt,
+			Content:         cmt.Content,
+			UserID:          cmt.UserID,
+			UserNickname:    cmt.UserModel.Nickname,
+			UserAvatarURL:   cmt.UserModel.AvatarURL,
+			ArticleID:       cmt.ArticleID,
+			ArticleTitle:    cmt.ArticleModel.Title,
+			ArticleCoverURL: cmt.ArticleModel.CoverURL,
+			Status:          cmt.Status,
+			IsMe:            cmt.UserID == claims.UserID,
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
+
+type CommentModerationReq struct {
+	IDList []uint             `json:"idList" binding:"required,min=1"`
+	Status enum.CommentStatus `json:"status" binding:"oneof=1 3"` // 1 通过 3 拒绝
+	Reason string             `json:"reason" binding:"max=256"`   // 拒绝的原因，会通知评论者
+}
+
+// CommentModerationView 批量审核评论，通过后才计入评论数并发送评论提醒
+// 管理员可以审核所有评论，文章作者可以审核自己文章下的评论
+func (CommentApi) CommentModerationView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentModerationReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var cmtList []models.CommentModel
+	global.DB.Preload("ArticleModel").Preload("ParentModel").
+		Where("id in ? and status = ?", req.IDList, enum.CommentStatusPending).Find(&cmtList)
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("审核评论")
+
+	var success int
+	for _, cmt := range cmtList {
+		if claims.Role != enum.AdminRoleType && claims.UserID != cmt.ArticleModel.UserID {
+			continue
+		}
+
+		// 带上状态条件，并发审核同一条评论时只有一次生效，评论数不会重复计算
+		result := global.DB.Model(&cmt).Where("status = ?", enum.CommentStatusPending).
+			Updates(map[string]any{"status": req.Status, "reject_reason": req.Reason})
+		if result.Error != nil {
+			log.SetItemWarn(fmt.Sprintf("评论 %d", cmt.ID), result.Error.Error())
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		success++
+
+		if req.Status == enum.CommentStatusRejected {
+			content := fmt.Sprintf("您在文章 %s 下的评论没有通过审核", cmt.ArticleModel.Title)
+			if req.Reason != "" {
+				content += "：" + req.Reason
+			}
+			err := message_service.SendSystemNotify(cmt.UserID, "评论未通过审核", content, "", "")
+			if err != nil {
+				log.SetItemWarn(fmt.Sprintf("评论 %d 消息发送失败", cmt.ID), err.Error())
+			}
+			continue
+		}
+
+		// 通过：计入评论数，提醒文章作者或被回复的人
+		err := comment_service.AddPublishedCount(cmt)
+		if err != nil {
+			log.SetItemWarn(fmt.Sprintf("评论 %d 评论数更新失败", cmt.ID), err.Error())
+		}
+		if cmt.ParentModel == nil {
+			cmt.ParentModel = &models.CommentModel{}
+		}
+		err = message_service.SendCommentNotify(cmt)
+		if err != nil {
+			log.SetItemWarn(fmt.Sprintf("评论 %d 消息发送失败", cmt.ID), err.Error())
+		}
+		cmt.Status = enum.CommentStatusPublished
+		mention_service.SyncComment(cmt)
+		err = message_service.SendWatchNotify(cmt)
+		if err != nil {
+			log.SetItemWarn(fmt.Sprintf("评论 %d 关注提醒发送失败", cmt.ID), err.Error())
+		}
+	}
+
+	msg := fmt.Sprintf("审核评论 %d 条，成功 %d 条", len(req.IDList), success)
+	log.SetItem("结果", msg)
+	res.SuccessWithMsg(msg, c)
+}
@@ -0,0 +1,125 @@
+// Path: ./api/comment_api/comment_pin.go
+
+package comment_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/message_service"
+	"blogX_server/utils/jwts"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type CommentPinReq struct {
+	CommentID uint `json:"commentID" binding:"required"`
+	Pin       bool `json:"pin"` // false 为取消置顶
+}
+
+// CommentPinView 文章作者或管理员置顶根评论，置顶的评论在评论树最前面，并提醒评论者
+func (CommentApi) CommentPinView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentPinReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	cmt, err := verifyCommentManager(req.CommentID, claims)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	if cmt.RootID != nil {
+		res.FailWithMsg("只能置顶根评论", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.ShowClaim(claims)
+
+	if !req.Pin {
+		log.SetTitle("取消置顶评论")
+		err = global.DB.Model(&cmt).Update("pinned_at", nil).Error
+		if err != nil {
+			res.Fail(err, "取消置顶失败", c)
+			return
+		}
+		res.SuccessWithMsg("取消置顶成功", c)
+		return
+	}
+
+	log.SetTitle("置顶评论")
+	if cmt.PinnedAt != nil {
+		res.SuccessWithMsg("评论已置顶", c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.CommentModel{}).
+		Where("article_id = ? AND status = ? AND pinned_at IS NOT NULL", cmt.ArticleID, enum.CommentStatusPublished).Count(&count)
+	if limit := global.Config.Site.Article.CommentPinLimit(); int(count) >= limit {
+		res.FailWithMsg(fmt.Sprintf("每篇文章最多置顶 %d 条评论", limit), c)
+		return
+	}
+
+	result := global.DB.Model(&cmt).Where("pinned_at IS NULL").Update("pinned_at", time.Now())
+	if result.Error != nil {
+		res.Fail(result.Error, "置顶失败", c)
+		return
+	}
+	res.SuccessWithMsg("置顶成功", c)
+	if result.RowsAffected == 0 {
+		return
+	}
+
+	var operator models.UserModel
+	err = global.DB.Take(&operator, claims.UserID).Error
+	if err == nil {
+		err = message_service.SendCommentPinNotify(cmt, operator)
+	}
+	if err != nil {
+		log.SetItemWarn("消息发送失败", err.Error())
+	}
+}
+
+type CommentHighlightReq struct {
+	CommentID uint `json:"commentID" binding:"required"`
+	Highlight bool `json:"highlight"` // false 为取消标记
+}
+
+// CommentHighlightView 文章作者或管理员把评论标记为精选回答，回复也可以标记
+func (CommentApi) CommentHighlightView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentHighlightReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	cmt, err := verifyCommentManager(req.CommentID, claims)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	err = global.DB.Model(&cmt).Update("highlighted", req.Highlight).Error
+	if err != nil {
+		res.Fail(err, "操作失败", c)
+		return
+	}
+	if req.Highlight {
+		res.SuccessWithMsg("已标记为精选", c)
+		return
+	}
+	res.SuccessWithMsg("已取消精选", c)
+}
+
+// verifyCommentManager 只有文章作者和管理员可以置顶、标记已发布的评论
+func verifyCommentManager(commentID uint, claims *jwts.MyClaims) (cmt models.CommentModel, err error) {
+	err = global.DB.Preload("ArticleModel").Take(&cmt, commentID).Error
+	if err != nil || cmt.Status != enum.CommentStatusPublished {
+		return cmt, errors.New("评论不存在")
+	}
+	if claims.Role != enum.AdminRoleType && cmt.ArticleModel.UserID != claims.UserID {
+		return cmt, errors.New("只有文章作者可以操作")
+	}
+	return cmt, nil
+}
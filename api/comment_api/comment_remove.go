@@ -43,9 +43,10 @@ func (CommentApi) CommentRemoveView(c *gin.Context) {
 	log.SetTitle(fmt.Sprintf("删除评论[%d]失败", cmt.ID))
 	log.SetItem("评论", fmt.Sprintf("%+v", cmt))
 
-	err = transaction.RemoveComment(&cmt)
+	err = transaction.RemoveComment(&cmt, claims.UserID)
 	if err != nil {
 		res.FailWithError(err, c)
+		return
 	}
 
 	log.SetTitle(fmt.Sprintf("删除评论[%d]成功", cmt.ID))
@@ -7,16 +7,46 @@ import (
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/models/enum"
-	"blogX_server/models/enum/relationship_enum"
 	"blogX_server/service/comment_service"
-	"blogX_server/service/focus_service"
-	"blogX_server/utils"
 	"blogX_server/utils/jwts"
 	"github.com/gin-gonic/gin"
 )
 
+// treeExpandDepth 评论树每次向下展开的层数，更深的回复由前端按需加载
+const treeExpandDepth = 2
+
+type CommentTreeReq struct {
+	Sort       string `form:"sort" binding:"omitempty,oneof=new old hot"` // 默认 new
+	Cursor     string `form:"cursor"`                                     // 上一次返回的 nextCursor，第一页不传
+	Limit      int    `form:"limit" binding:"max=50"`                     // 根评论数，默认 10
+	ReplyLimit int    `form:"replyLimit" binding:"max=10"`                // 每条评论带上的回复数，默认 3
+}
+
+type CommentTreeResponse struct {
+	List       []*comment_service.CommentResponse `json:"list"`
+	Count      int                                `json:"count"`      // 根评论或直接回复的总数
+	NextCursor string                             `json:"nextCursor"` // 为空表示没有下一页
+	HasMore    bool                               `json:"hasMore"`
+}
+
+// CommentTreeView 文章的评论树，根评论按游标分页，支持最新、最早、热门排序
+// 作者置顶的评论不论哪种排序都在第一页的最前面
 func (CommentApi) CommentTreeView(c *gin.Context) {
 	req := c.MustGet("bindReq").(models.IDRequest)
+	var cr CommentTreeReq
+	if err := c.ShouldBindQuery(&cr); err != nil {
+		res.Fail(err, "参数错误", c)
+		return
+	}
+	if cr.Sort == "" {
+		cr.Sort = comment_service.SortNew
+	}
+	if cr.Limit <= 0 {
+		cr.Limit = 10
+	}
+	if cr.ReplyLimit <= 0 {
+		cr.ReplyLimit = 3
+	}
 
 	var article models.ArticleModel
 	err := global.DB.Take(&article, req.ID).Error
@@ -25,55 +55,92 @@ func (CommentApi) CommentTreeView(c *gin.Context) {
 		return
 	}
 
-	var userRelationMap = map[uint]relationship_enum.Relation{}
-	var userCommentLikeMap = map[uint]struct{}{}
+	// 非管理员只能查看`已发布`的文章
 	claims, err := jwts.ParseTokenFromRequest(c)
-	if err == nil && claims != nil {
-		// 登录了
-		var commentList []models.CommentModel // 文章的评论id列表
-		global.DB.Find(&commentList, "article_id = ?", req.ID)
-
-		if len(commentList) > 0 {
-			// 查我点赞的评论id列表
-			var commentIDList []uint
-			var userIDList []uint
-			for _, model := range commentList {
-				commentIDList = append(commentIDList, model.ID)
-				userIDList = append(userIDList, model.UserID)
-			}
-			userIDList = utils.Unique(userIDList) // 对用户id去重
-			userRelationMap = focus_service.CalcUserPatchRelationship(claims.UserID, userIDList)
+	login := err == nil && claims != nil
+	if (!login || claims.Role != enum.AdminRoleType) && article.Status != enum.ArticleStatusPublish {
+		res.FailWithMsg("文章不存在", c)
+		return
+	}
 
-			var commentDiggList []models.CommentLikesModel
-			global.DB.Find(&commentDiggList, "user_id = ? and comment_id in ?", claims.UserID, commentIDList)
-			for _, model := range commentDiggList {
-				userCommentLikeMap[model.CommentID] = struct{}{}
-			}
+	rootCmts, next, err := comment_service.GetRootPage(article.ID, cr.Sort, cr.Cursor, cr.Limit)
+	if err != nil {
+		res.Fail(err, "评论查询失败", c)
+		return
+	}
+	if cr.Cursor == "" {
+		pinned, err := comment_service.GetPinnedRoots(article.ID)
+		if err != nil {
+			res.Fail(err, "评论查询失败", c)
+			return
 		}
+		rootCmts = append(pinned, rootCmts...)
 	}
+	var count int64
+	global.DB.Model(&models.CommentModel{}).
+		Where("article_id = ? AND root_id IS NULL AND status = ?", article.ID, enum.CommentStatusPublished).Count(&count)
 
-	// 非管理员只能查看`已发布`的文章
-	if (err != nil || claims == nil || claims.Role != enum.AdminRoleType) && article.Status != enum.ArticleStatusPublish {
-		res.FailWithMsg("文章不存在", c)
-		return
+	list := comment_service.BuildTree(rootCmts, comment_service.TreeOptions{
+		ReplyLimit: cr.ReplyLimit,
+		Depth:      treeExpandDepth,
+	})
+	if login {
+		comment_service.FillViewerState(list, claims.UserID)
 	}
+	res.SuccessWithData(CommentTreeResponse{
+		List:       list,
+		Count:      int(count),
+		NextCursor: next,
+		HasMore:    next != "",
+	}, c)
+}
 
-	// 找到文章所有的根评论
-	var rootCmts []models.CommentModel
-	err = global.DB.Where("article_id = ? AND root_id IS NULL", req.ID).Order("created_at DESC").Find(&rootCmts).Error
-	if err != nil {
-		res.Fail(err, "数据库查询失败", c)
+type CommentReplyReq struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"max=50"` // 默认 10
+}
+
+// CommentReplyView 加载评论的更多回复，按时间正序，每条回复同样带上最早的几条子回复
+func (CommentApi) CommentReplyView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	var cr CommentReplyReq
+	if err := c.ShouldBindQuery(&cr); err != nil {
+		res.Fail(err, "参数错误", c)
 		return
 	}
+	if cr.Limit <= 0 {
+		cr.Limit = 10
+	}
 
-	if len(rootCmts) == 0 {
-		res.SuccessWithMsg("该文章目前没有评论", c)
+	var cmt models.CommentModel
+	err := global.DB.Preload("ArticleModel").Take(&cmt, req.ID).Error
+	if err != nil || cmt.Status != enum.CommentStatusPublished {
+		res.FailWithMsg("评论不存在", c)
+		return
+	}
+	claims, err := jwts.ParseTokenFromRequest(c)
+	login := err == nil && claims != nil
+	if (!login || claims.Role != enum.AdminRoleType) && cmt.ArticleModel.Status != enum.ArticleStatusPublish {
+		res.FailWithMsg("评论不存在", c)
 		return
 	}
 
-	var list []comment_service.CommentResponse
-	for _, cmt := range rootCmts {
-		list = append(list, *comment_service.PreloadAllChildrenResponseFromModel(&cmt, userRelationMap, userCommentLikeMap))
+	children, next, err := comment_service.GetChildPage(cmt.ID, cr.Cursor, cr.Limit)
+	if err != nil {
+		res.Fail(err, "回复查询失败", c)
+		return
+	}
+	list := comment_service.BuildTree(children, comment_service.TreeOptions{
+		ReplyLimit: 3,
+		Depth:      treeExpandDepth - 1,
+	})
+	if login {
+		comment_service.FillViewerState(list, claims.UserID)
 	}
-	res.SuccessWithList(list, len(rootCmts), c)
+	res.SuccessWithData(CommentTreeResponse{
+		List:       list,
+		Count:      comment_service.CountChildren([]uint{cmt.ID})[cmt.ID],
+		NextCursor: next,
+		HasMore:    next != "",
+	}, c)
 }
@@ -0,0 +1,127 @@
+// Path: ./api/comment_api/comment_update.go
+
+package comment_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/jwts"
+	"blogX_server/utils/xss"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type CommentUpdateReq struct {
+	ID      uint   `json:"id" binding:"required"`
+	Content string `json:"content" binding:"required"`
+}
+
+// CommentUpdateView 修改评论，作者只能在发布后的一段时间内修改，管理员不受限制
+// 修改前的内容会保存为历史版本，管理员可以查看
+func (CommentApi) CommentUpdateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentUpdateReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var cmt models.CommentModel
+	err := global.DB.Preload("ArticleModel").Preload("UserModel").Take(&cmt, req.ID).Error
+	if err != nil {
+		res.Fail(err, "评论不存在", c)
+		return
+	}
+
+	isAdmin := claims.Role == enum.AdminRoleType
+	if !isAdmin {
+		if cmt.UserID != claims.UserID {
+			res.FailWithMsg("只能修改自己的评论", c)
+			return
+		}
+		if cmt.Status == enum.CommentStatusRejected {
+			res.FailWithMsg("未通过审核的评论不能修改", c)
+			return
+		}
+		window := global.Config.Site.Article.CommentEditWindow()
+		if time.Since(cmt.CreatedAt) > window {
+			res.FailWithMsg(fmt.Sprintf("评论发布超过 %d 分钟，不能再修改", int(window.Minutes())), c)
+			return
+		}
+	}
+
+	req.Content = xss.Filter(req.Content)
+	review, err := sensitive_service.Filter(&req.Content)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if req.Content == cmt.Content {
+		res.SuccessWithMsg("评论没有修改", c)
+		return
+	}
+
+	// 已发布的评论修改后不会重新进入审核队列，需要审核的内容不允许直接改上去
+	if cmt.Status == enum.CommentStatusPublished && !isAdmin &&
+		(review || comment_service.NeedEditModeration(cmt.ArticleModel, cmt.UserModel, claims.Role, cmt.Content, req.Content)) {
+		res.FailWithMsg("修改后的内容需要审核，不能直接修改已发布的评论", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("修改评论")
+	if cmt.UserID != claims.UserID {
+		log.ShowClaim(claims)
+		log.SetTitle("管理修改评论")
+	}
+
+	err = transaction.EditCommentTx(&cmt, req.Content, claims.UserID)
+	if err != nil {
+		res.Fail(err, "评论修改失败", c)
+		return
+	}
+	res.SuccessWithMsg("评论修改成功", c)
+
+	// 新加的 @ 发提醒
+	mention_service.SyncComment(cmt)
+}
+
+type CommentEditResponse struct {
+	Version        int       `json:"version"`
+	Content        string    `json:"content"`
+	EditorID       uint      `json:"editorID"`
+	EditorNickname string    `json:"editorNickname"`
+	EditedAt       time.Time `json:"editedAt"` // 这个版本被替换掉的时间
+}
+
+// CommentEditListView 评论的历史版本，新的在前，用于处理评论纠纷
+func (CommentApi) CommentEditListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+
+	var cmt models.CommentModel
+	err := global.DB.Take(&cmt, req.ID).Error
+	if err != nil {
+		res.Fail(err, "评论不存在", c)
+		return
+	}
+
+	var _list []models.CommentEditModel
+	global.DB.Preload("EditorModel").Where("comment_id = ?", cmt.ID).Order("version desc").Find(&_list)
+
+	list := make([]CommentEditResponse, 0, len(_list))
+	for _, e := range _list {
+		list = append(list, CommentEditResponse{
+			Version:        e.Version,
+			Content:        e.Content,
+			EditorID:       e.EditorID,
+			EditorNickname: e.EditorModel.Nickname,
+			EditedAt:       e.CreatedAt,
+		})
+	}
+	res.SuccessWithList(list, len(list), c)
+}
@@ -0,0 +1,126 @@
+// Path: ./api/comment_api/comment_watch.go
+
+package comment_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/utils"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentWatchReq struct {
+	ArticleID uint `json:"articleID" binding:"required"`
+	RootID    uint `json:"rootID"` // 根评论 id，不传表示整篇文章的评论区
+}
+
+// CommentWatchView 关注文章的评论区或某个讨论，有新评论时提醒
+func (CommentApi) CommentWatchView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentWatchReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var article models.ArticleModel
+	err := global.DB.Take(&article, "id = ? and status = ?", req.ArticleID, enum.ArticleStatusPublish).Error
+	if err != nil {
+		res.FailWithMsg("文章不存在", c)
+		return
+	}
+	if req.RootID != 0 {
+		var root models.CommentModel
+		err = global.DB.Take(&root, "id = ? and article_id = ? and root_id is null and status = ?",
+			req.RootID, req.ArticleID, enum.CommentStatusPublished).Error
+		if err != nil {
+			res.FailWithMsg("讨论不存在", c)
+			return
+		}
+	}
+
+	err = comment_service.Watch(claims.UserID, req.ArticleID, req.RootID)
+	if err != nil {
+		res.Fail(err, "关注失败", c)
+		return
+	}
+	res.SuccessWithMsg("关注成功", c)
+}
+
+// CommentUnwatchView 取消关注，包括评论后自动关注的讨论
+func (CommentApi) CommentUnwatchView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentWatchReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var article models.ArticleModel
+	err := global.DB.Take(&article, req.ArticleID).Error
+	if err != nil {
+		res.FailWithMsg("文章不存在", c)
+		return
+	}
+
+	err = comment_service.Unwatch(claims.UserID, req.ArticleID, req.RootID)
+	if err != nil {
+		res.Fail(err, "取消关注失败", c)
+		return
+	}
+	res.SuccessWithMsg("取消关注成功", c)
+}
+
+type CommentWatchListReq struct {
+	common.PageInfo
+	ArticleID uint `form:"articleID"`
+}
+
+type CommentWatchResponse struct {
+	models.CommentWatchModel
+	ArticleTitle string `json:"articleTitle"`
+	RootContent  string `json:"rootContent"` // 关注整篇文章时为空
+}
+
+// CommentWatchListView 我关注的评论区和讨论，传文章 id 时可用于展示关注状态
+func (CommentApi) CommentWatchListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(CommentWatchListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	req.PageInfo.Normalize()
+	_list, count, err := common.ListQuery(models.CommentWatchModel{
+		UserID:    claims.UserID,
+		ArticleID: req.ArticleID,
+	}, common.Options{
+		PageInfo:     req.PageInfo,
+		Preloads:     []string{"ArticleModel"},
+		Where:        global.DB.Where("muted = ?", false),
+		DefaultOrder: "created_at desc",
+	})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	var rootIDList []uint
+	for _, w := range _list {
+		if w.RootID != 0 {
+			rootIDList = append(rootIDList, w.RootID)
+		}
+	}
+	rootMap := map[uint]string{}
+	if len(rootIDList) > 0 {
+		var roots []models.CommentModel
+		global.DB.Select("id", "content").Where("id in ?", rootIDList).Find(&roots)
+		for _, r := range roots {
+			rootMap[r.ID] = utils.ExtractContent(r.Content, 30)
+		}
+	}
+
+	list := make([]CommentWatchResponse, 0, len(_list))
+	for _, w := range _list {
+		list = append(list, CommentWatchResponse{
+			CommentWatchModel: w,
+			ArticleTitle:      w.ArticleModel.Title,
+			RootContent:       rootMap[w.RootID],
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
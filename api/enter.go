@@ -3,19 +3,27 @@
 package api
 
 import (
+	"blogX_server/api/access_token_api"
 	"blogX_server/api/ai_api"
 	"blogX_server/api/article_api"
 	"blogX_server/api/banner_api"
 	"blogX_server/api/captcha_api"
 	"blogX_server/api/comment_api"
 	"blogX_server/api/data_api"
+	"blogX_server/api/feed_api"
 	"blogX_server/api/focus_api"
 	"blogX_server/api/global_notification_api"
 	"blogX_server/api/image_api"
 	"blogX_server/api/log_api"
 	"blogX_server/api/mytest_api"
 	"blogX_server/api/notify_api"
+	"blogX_server/api/oauth_api"
+	"blogX_server/api/reaction_api"
+	"blogX_server/api/recycle_api"
+	"blogX_server/api/report_api"
 	"blogX_server/api/search_api"
+	"blogX_server/api/sensitive_api"
+	"blogX_server/api/seo_api"
 	"blogX_server/api/site_api"
 	"blogX_server/api/user_api"
 )
@@ -35,6 +43,14 @@ type Api struct {
 	AiApi                 ai_api.AiApi
 	DataApi               data_api.DataApi
 	FocusApi              focus_api.FocusApi
+	RecycleApi            recycle_api.RecycleApi
+	FeedApi               feed_api.FeedApi
+	SeoApi                seo_api.SeoApi
+	SensitiveApi          sensitive_api.SensitiveApi
+	ReportApi             report_api.ReportApi
+	ReactionApi           reaction_api.ReactionApi
+	OAuthApi              oauth_api.OAuthApi
+	AccessTokenApi        access_token_api.AccessTokenApi
 
 	MyTestApi mytest_api.MyTestApi // 测试用
 }
@@ -0,0 +1,122 @@
+// Path: ./api/feed_api/enter.go
+
+package feed_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/feed_service"
+	"blogX_server/service/seo_service"
+	"blogX_server/utils/markdown"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type FeedApi struct{}
+
+// feedLimit 订阅源中最多的文章数
+const feedLimit = 20
+
+// FeedReq 全站、某个用户、某个用户的分类、某个标签，可以组合使用
+type FeedReq struct {
+	UserID     uint   `form:"userID"`
+	CategoryID uint   `form:"categoryID"`
+	Tag        string `form:"tag"`
+}
+
+// buildFeed 查询已发布的文章并组装为订阅源，format 用于生成订阅源自身的地址
+func buildFeed(req FeedReq, format string) (*feed_service.Feed, error) {
+	site := global.Config.Site
+	baseURL := site.BaseURL()
+
+	f := &feed_service.Feed{
+		Title:       site.SiteInfo.Title,
+		Link:        seo_service.HomeURL(),
+		Description: site.Seo.Description,
+		Language:    "zh-CN",
+	}
+	if f.Description == "" {
+		f.Description = site.SiteInfo.Slogan
+	}
+
+	// 只要已发布的文章，条件和文章列表、搜索保持一致
+	query := global.DB.Where("status = ?", enum.ArticleStatusPublish)
+
+	if req.CategoryID != 0 {
+		var cat models.CategoryModel
+		if err := global.DB.Preload("UserModel").Take(&cat, req.CategoryID).Error; err != nil {
+			return nil, errors.New("分类不存在")
+		}
+		// 分类是用户私有的，分类隐含了用户
+		req.UserID = cat.UserID
+		query = query.Where("category_id = ?", cat.ID)
+		f.Title = fmt.Sprintf("%s / %s - %s", cat.UserModel.Nickname, cat.Name, f.Title)
+		f.Link = seo_service.CategoryURL(cat.UserID, cat.ID)
+	}
+	if req.UserID != 0 {
+		var u models.UserModel
+		if err := global.DB.Take(&u, req.UserID).Error; err != nil {
+			return nil, errors.New("用户不存在")
+		}
+		query = query.Where("user_id = ?", u.ID)
+		if req.CategoryID == 0 {
+			f.Title = fmt.Sprintf("%s - %s", u.Nickname, f.Title)
+			f.Link = seo_service.UserURL(u.ID)
+			if u.Bio != "" {
+				f.Description = u.Bio
+			}
+		}
+	}
+	if req.Tag != "" {
+		query = common.TagQuery(query, req.Tag)
+		f.Title = fmt.Sprintf("#%s - %s", req.Tag, f.Title)
+		if req.UserID == 0 {
+			f.Link = seo_service.TagURL(req.Tag)
+		}
+	}
+
+	// 订阅源自身的地址
+	values := url.Values{}
+	if req.UserID != 0 && req.CategoryID == 0 {
+		values.Set("userID", fmt.Sprint(req.UserID))
+	}
+	if req.CategoryID != 0 {
+		values.Set("categoryID", fmt.Sprint(req.CategoryID))
+	}
+	if req.Tag != "" {
+		values.Set("tag", req.Tag)
+	}
+	f.FeedURL = fmt.Sprintf("%s/api/feed/%s", baseURL, format)
+	if len(values) > 0 {
+		f.FeedURL += "?" + values.Encode()
+	}
+
+	var list []models.ArticleModel
+	err := global.DB.Preload("UserModel").Where(query).
+		Order("created_at desc").Limit(feedLimit).Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	f.Updated = time.Unix(0, 0)
+	for _, a := range list {
+		link := seo_service.ArticleURL(a.ID)
+		f.Items = append(f.Items, feed_service.Item{
+			ID:          link,
+			Title:       a.Title,
+			Link:        link,
+			Summary:     a.Abstract,
+			ContentHTML: markdown.MdToHTML(a.Content),
+			Author:      a.UserModel.Nickname,
+			Image:       seo_service.AbsURL(a.CoverURL),
+			Tags:        a.Tags,
+			Published:   a.CreatedAt,
+			Updated:     a.UpdatedAt,
+		})
+	}
+	return f, nil
+}
@@ -0,0 +1,48 @@
+// Path: ./api/feed_api/feed.go
+
+package feed_api
+
+import (
+	"blogX_server/service/feed_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// FeedRssView RSS 2.0
+func (FeedApi) FeedRssView(c *gin.Context) {
+	writeFeed(c, "rss", "application/rss+xml; charset=utf-8", (*feed_service.Feed).RSS)
+}
+
+// FeedAtomView Atom 1.0
+func (FeedApi) FeedAtomView(c *gin.Context) {
+	writeFeed(c, "atom", "application/atom+xml; charset=utf-8", (*feed_service.Feed).Atom)
+}
+
+// FeedJsonView JSON Feed 1.1
+func (FeedApi) FeedJsonView(c *gin.Context) {
+	writeFeed(c, "json", "application/feed+json; charset=utf-8", (*feed_service.Feed).JSON)
+}
+
+// writeFeed 订阅源不是给前端的，出错时直接返回 http 状态码，而不是 res 的 json
+// 非 200 的响应不会被 CacheMiddleware 缓存
+func writeFeed(c *gin.Context, format, contentType string, render func(*feed_service.Feed) ([]byte, error)) {
+	req := c.MustGet("bindReq").(FeedReq)
+
+	f, err := buildFeed(req, format)
+	if err != nil {
+		c.String(http.StatusNotFound, err.Error())
+		return
+	}
+	byteData, err := render(f)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	lastModified := f.LastUpdated().UTC().Format(http.TimeFormat)
+	if redis_cache.NotModified(c, redis_cache.ETag(string(byteData)), lastModified) {
+		return
+	}
+	c.Data(http.StatusOK, contentType, byteData)
+}
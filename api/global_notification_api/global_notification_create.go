@@ -7,6 +7,7 @@ import (
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,6 +22,10 @@ type GNCreateReq struct {
 func (GlobalNotificationApi) GNCreateView(c *gin.Context) {
 	//只有 admin 才能进来
 	req := c.MustGet("bindReq").(GNCreateReq)
+	if err := sensitive_service.FilterNoReview(&req.Title, &req.Content); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
 
 	err := global.DB.Create(&models.GlobalNotificationModel{
 		Title:   req.Title,
@@ -32,7 +32,7 @@ func (GlobalNotificationApi) GNListView(c *gin.Context) {
 	isReadMap := map[uint]struct{}{}
 
 	// 用户侧查询: 用户已删除的不展示 用户是否已读也要展示出来
-	if claims.Role == enum.UserRoleType {
+	if claims.Role == enum.UserRoleType || claims.Role == enum.ReviewerRoleType {
 		// 首先把用户全局表的信息读取出来
 		var ugnList []models.UserGlobalNotificationModel
 		err := global.DB.Find(&ugnList, "user_id = ?", claims.UserID).Error
@@ -4,10 +4,8 @@ package image_api
 
 import (
 	"blogX_server/global"
-	"blogX_server/models"
-	"blogX_server/service/cloud_service/qny_cloud_service"
+	"blogX_server/service/image_service"
 	"blogX_server/service/log_service"
-	"blogX_server/utils/file"
 	"blogX_server/utils/hash"
 	"blogX_server/utils/jwts"
 	"errors"
@@ -16,8 +14,6 @@ import (
 	"github.com/sirupsen/logrus"
 	"io"
 	"mime/multipart"
-	"os"
-	"strings"
 )
 
 type respCode int8
@@ -110,108 +106,22 @@ func readMultiPartFile(fileHeader *multipart.FileHeader) (byteData []byte, err e
 	return
 }
 
+// uploadImage 上传单张，上传流程见 image_service.Upload
 func uploadImage(byteData []byte, filename, src string, uid uint) (code respCode, msg string) {
-	// 计算 hashString
-	hashString := hash.Md5(byteData)
-
-	// 合法格式
-	suffix, err := file.ImageSuffix(filename)
-	if err != nil {
-		return respCodeFail, err.Error()
-	}
-
-	// 入库
-	model := models.ImageModel{
-		Filename: filename,
-		Path:     fmt.Sprintf("uploads/%s/%s", global.Config.Upload.ImageDir, hashString+"."+suffix),
-		Size:     int64(len(byteData)),
-		Hash:     hashString,
-		Source:   src,
-	}
-
-	// 尝试入库，靠数据库 `hash` 字段 `unique` 去重
-	err = global.DB.Create(&model).Error
-	if err != nil {
-		if strings.Contains(err.Error(), "Duplicate entry") {
-			// 找出重复的那个
-			var _model models.ImageModel
-			global.DB.Take(&_model, "hash = ?", hashString)
-
-			// 首先判断是不是同一个用户上传的
-			// 如果不是，则加入关系表中
-			relation := models.UserUploadImage{
-				UserID:  uid,
-				ImageID: _model.ID,
-			}
-			// 关系表尝试入库
-			_err := global.DB.Create(&relation).Error
-			if _err != nil {
-				if strings.Contains(_err.Error(), "Duplicate entry") {
-					msg = fmt.Sprintf("相同用户%d && 相同图片%d", uid, _model.ID)
-					logrus.Infof(msg)
-					return respCodeFail, msg
-				} else {
-					return respCodeFail, _err.Error()
-				}
-			}
-
-			// 成功入库：相同图片，不同用户
-			msg := fmt.Sprintf("上传的%s 与已有%s 重复，hash: %s", filename, _model.Filename, hashString)
-			logrus.Info(msg)
-			return respCodeDupe, msg
-		}
-		return respCodeFail, err.Error()
-	}
-
-	// 存入多对多关系数据库
-	err = global.DB.Create(&models.UserUploadImage{
-		UserID:  uid,
-		ImageID: model.ID,
-	}).Error
+	model, status, err := image_service.Upload(byteData, filename, src, uid)
 	if err != nil {
 		return respCodeFail, err.Error()
 	}
-
-	// DB 部分结束，下面是云存储 or 本地存储 or both
-	// 判断是否开启云存储
-	if global.Config.Cloud.QNY.Enable {
-		url, err := qny_cloud_service.UploadBytes(byteData)
-		if err != nil {
-			return respCodeFail, err.Error()
-		}
-		model.Url = url
-		// 如果没开启本地存储
-		if !global.Config.Cloud.QNY.LocalSave {
-			// 更新 url
-			global.DB.Model(&model).Update("url", model.Url).Update("path", "")
-			msg := "filename: " + filename + " path: " + model.Path
-			fmt.Println(msg)
-			return respCodeSuccess, msg
-		}
-	}
-
-	// 把云存储路径更新到 db
-	if model.Url != "" {
-		global.DB.Model(&model).Update("url", model.Url)
-	}
-
-	// 创建文件 这个还是需要 fileHeader
-	//err = c.SaveUploadedFile(fileHeader, model.Path)
-	//if err != nil {
-	//	return respCodeFail, "创建文件失败: " + err.Error()
-	//}
-	//msg = "filename: " + fileHeader.Filename + " path: " + model.Path
-	//return respCodeSuccess, msg
-
-	// 本地存储
-	err = os.MkdirAll("uploads/images", 0777)
-	if err != nil {
-		return respCodeFail, err.Error()
-	}
-	err = os.WriteFile(model.Path, byteData, 0666)
-	if err != nil {
-		return respCodeFail, err.Error()
+	switch status {
+	case image_service.UploadStatusOwned:
+		msg = fmt.Sprintf("相同用户%d && 相同图片%d", uid, model.ID)
+		logrus.Info(msg)
+		return respCodeFail, msg
+	case image_service.UploadStatusDupe:
+		// 成功入库：相同图片，不同用户
+		msg = fmt.Sprintf("上传的%s 与已有%s 重复，hash: %s", filename, model.Filename, model.Hash)
+		logrus.Info(msg)
+		return respCodeDupe, msg
 	}
-	fmt.Println(filename)
 	return respCodeSuccess, fmt.Sprintf("成功上传图片 %s", filename)
 }
@@ -17,7 +17,7 @@ import (
 
 type NotifyListReq struct {
 	common.PageInfo `json:"pageInfo"`
-	NotifyType      int8 `form:"t" binding:"required,oneof=1 2 3"` // 1-评论与回复 2-赞和收藏 3-系统通知 `
+	NotifyType      int8 `form:"t" binding:"required,oneof=1 2 3 4"` // 1-评论与回复 2-赞和收藏 3-系统通知 4-@我的 `
 }
 
 type NotifyListResp struct {
@@ -49,11 +49,13 @@ func (NotifyApi) NotifyListView(c *gin.Context) {
 	// 判定返回种类
 	switch req.NotifyType {
 	case 1: // 评论与回复
-		query = query.Where("type = ? OR type = ?", notify_enum.ArticleCommentType, notify_enum.CommentReplyType)
+		query = query.Where("type IN ?", []notify_enum.Type{notify_enum.ArticleCommentType, notify_enum.CommentReplyType, notify_enum.WatchType, notify_enum.CommentPinType})
 	case 2: // 赞和收藏
-		query = query.Where("type = ? OR type = ? OR type = ?", notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType)
+		query = query.Where("type IN ?", []notify_enum.Type{notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType, notify_enum.ArticleReactionType, notify_enum.CommentReactionType})
 	case 3: // 系统通知
 		query = query.Where("type = ?", notify_enum.SystemType)
+	case 4: // @我的
+		query = query.Where("type = ?", notify_enum.MentionType)
 	}
 
 	// 解析时间戳并查询
@@ -16,7 +16,7 @@ import (
 
 type NotifyReadReq struct {
 	NotifyID   uint `json:"id"` // 读一篇（留空则代表是批量读取）
-	NotifyType int8 `json:"t"`  // 批量读取特定类型的消息：1-评论与回复 2-赞和收藏 3-系统通知
+	NotifyType int8 `json:"t"`  // 批量读取特定类型的消息：1-评论与回复 2-赞和收藏 3-系统通知 4-@我的
 }
 
 // NotifyReadView 将消息设为已读
@@ -34,13 +34,15 @@ func (NotifyApi) NotifyReadView(c *gin.Context) {
 		// 一键已读
 		switch req.NotifyType {
 		case 1: // 评论与回复
-			query = query.Where("type = ? OR type = ?", notify_enum.ArticleCommentType, notify_enum.CommentReplyType)
+			query = query.Where("type IN ?", []notify_enum.Type{notify_enum.ArticleCommentType, notify_enum.CommentReplyType, notify_enum.WatchType, notify_enum.CommentPinType})
 		case 2: // 赞和收藏
-			query = query.Where("type = ? OR type = ? OR type = ?", notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType)
+			query = query.Where("type IN ?", []notify_enum.Type{notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType, notify_enum.ArticleReactionType, notify_enum.CommentReactionType})
 		case 3: // 系统通知
 			query = query.Where("type = ?", notify_enum.SystemType)
+		case 4: // @我的
+			query = query.Where("type = ?", notify_enum.MentionType)
 		default:
-			res.FailWithMsg("type 必须是 1 or 2 or 3", c)
+			res.FailWithMsg("type 必须是 1 or 2 or 3 or 4", c)
 			return
 		}
 	}
@@ -18,6 +18,8 @@ type UserNotifyConfUpdateReq struct {
 	ReceiveCollectNotify   bool `json:"receiveCollectNotify"`
 	ReceivePrivateMessage  bool `json:"receivePrivateMessage"`
 	ReceiveStrangerMessage bool `json:"receiveStrangerMessage"`
+	ReceiveMentionNotify   bool `json:"receiveMentionNotify"`
+	AutoWatchComment       bool `json:"autoWatchComment"`
 }
 
 func (NotifyApi) UserNotifyConfUpdateView(c *gin.Context) {
@@ -37,6 +39,8 @@ func (NotifyApi) UserNotifyConfUpdateView(c *gin.Context) {
 		"receive_collect_notify":   req.ReceiveCollectNotify,
 		"receive_private_message":  req.ReceivePrivateMessage,
 		"receive_stranger_message": req.ReceiveStrangerMessage,
+		"receive_mention_notify":   req.ReceiveMentionNotify,
+		"auto_watch_comment":       req.AutoWatchComment,
 	}
 	err = global.DB.Model(&un).Updates(umap).Error
 	if err != nil {
@@ -16,6 +16,7 @@ type UserUnreadMessageResp struct {
 	LikeMsgCount    int `json:"diggMsgCount"`
 	PrivateMsgCount int `json:"privateMsgCount"`
 	SystemMsgCount  int `json:"systemMsgCount"`
+	MentionMsgCount int `json:"mentionMsgCount"`
 }
 
 // UserUnreadMessageView 查看用户未读的所有消息（站内信、系统通知、私信）数量
@@ -29,12 +30,14 @@ func (NotifyApi) UserUnreadMessageView(c *gin.Context) {
 	var resp UserUnreadMessageResp
 	for _, notify := range notifies {
 		switch notify.Type {
-		case notify_enum.ArticleCommentType, notify_enum.CommentReplyType:
+		case notify_enum.ArticleCommentType, notify_enum.CommentReplyType, notify_enum.WatchType, notify_enum.CommentPinType:
 			resp.CommentMsgCount++
-		case notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType:
+		case notify_enum.ArticleLikeType, notify_enum.ArticleCollectType, notify_enum.CommentLikeType, notify_enum.ArticleReactionType, notify_enum.CommentReactionType:
 			resp.LikeMsgCount++
 		case notify_enum.SystemType:
 			resp.SystemMsgCount++
+		case notify_enum.MentionType:
+			resp.MentionMsgCount++
 		}
 	}
 
@@ -0,0 +1,9 @@
+// Path: ./api/oauth_api/enter.go
+
+package oauth_api
+
+type OAuthApi struct{}
+
+type OAuthNameReq struct {
+	Name string `uri:"name" binding:"required"` // 配置中的服务名称
+}
@@ -0,0 +1,51 @@
+// Path: ./api/oauth_api/oauth_authorize.go
+
+package oauth_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/middleware"
+	"blogX_server/service/oauth_service"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthProviderListView 登录页展示的第三方登录
+func (OAuthApi) OAuthProviderListView(c *gin.Context) {
+	res.SuccessWithData(oauth_service.List(), c)
+}
+
+type OAuthAuthorizeReq struct {
+	Bind bool `form:"bind"` // 已登录的用户绑定第三方账号
+}
+
+// OAuthAuthorizeView 第三方授权页的地址，前端跳转过去，授权后回到配置的回调地址
+func (OAuthApi) OAuthAuthorizeView(c *gin.Context) {
+	req := c.MustGet("bindReq").(OAuthNameReq)
+	var ar OAuthAuthorizeReq
+	if err := c.ShouldBindQuery(&ar); err != nil {
+		res.Fail(err, "参数错误", c)
+		return
+	}
+
+	p, err := oauth_service.GetProvider(req.Name)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	st := oauth_service.State{Provider: p.Name}
+	if ar.Bind {
+		// 和 AuthMiddleware 一样校验 token，已退出或改过密码的 token 不能用来绑定
+		claims, ok := mdw.GetSessionClaims(c)
+		if !ok {
+			return
+		}
+		st.UserID = claims.UserID
+	}
+	state, err := oauth_service.NewState(c, st)
+	if err != nil {
+		res.Fail(err, "生成授权地址失败", c)
+		return
+	}
+	res.SuccessWithData(p.AuthorizeURL(state), c)
+}
@@ -0,0 +1,59 @@
+// Path: ./api/oauth_api/oauth_bind.go
+
+package oauth_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthIdentityListView 我绑定的第三方账号
+func (OAuthApi) OAuthIdentityListView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	list := []models.UserIdentityModel{}
+	global.DB.Where("user_id = ?", claims.UserID).Order("created_at asc").Find(&list)
+	res.SuccessWithList(list, len(list), c)
+}
+
+// OAuthUnbindView 解绑第三方账号，解绑后没有其他登录方式的不允许解绑
+func (OAuthApi) OAuthUnbindView(c *gin.Context) {
+	req := c.MustGet("bindReq").(OAuthNameReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var u models.UserModel
+	err := global.DB.Take(&u, claims.UserID).Error
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	var identity models.UserIdentityModel
+	err = global.DB.Take(&identity, "user_id = ? and provider = ?", u.ID, req.Name).Error
+	if err != nil {
+		res.FailWithMsg("没有绑定该账号", c)
+		return
+	}
+
+	var others int64
+	global.DB.Model(&models.UserIdentityModel{}).Where("user_id = ? and id <> ?", u.ID, identity.ID).Count(&others)
+	if u.Password == "" && u.OpenID == "" && others == 0 {
+		res.FailWithMsg("请先设置密码，否则解绑后无法登录", c)
+		return
+	}
+
+	err = global.DB.Delete(&identity).Error
+	if err != nil {
+		res.Fail(err, "解绑失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("解绑第三方账号")
+	log.SetItem("服务", req.Name)
+	res.SuccessWithMsg("解绑成功", c)
+}
@@ -0,0 +1,175 @@
+// Path: ./api/oauth_api/oauth_callback.go
+
+package oauth_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/oauth_service"
+	"blogX_server/service/user_service"
+	"blogX_server/utils/hash"
+	"blogX_server/utils/user"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OAuthCallbackReq struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
+
+// OAuthCallbackView 授权回调，授权时是登录的就登录或自动注册，是绑定的就绑定到当时的用户
+func (OAuthApi) OAuthCallbackView(c *gin.Context) {
+	req := c.MustGet("bindReq").(OAuthNameReq)
+	var cr OAuthCallbackReq
+	if err := c.ShouldBindQuery(&cr); err != nil {
+		res.Fail(err, "参数错误", c)
+		return
+	}
+
+	p, err := oauth_service.GetProvider(req.Name)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	st, err := oauth_service.TakeState(c, cr.State)
+	if err != nil || st.Provider != p.Name {
+		log_service.NewLoginFail(p.LoginType(), "state 无效", "", "", c)
+		res.FailWithMsg("授权已过期，请重新登录", c)
+		return
+	}
+
+	id, err := p.Login(cr.Code)
+	if err != nil {
+		log_service.NewLoginFail(p.LoginType(), err.Error(), "", "", c)
+		res.FailWithMsg(p.Label+"授权失败", c)
+		return
+	}
+
+	if st.UserID != 0 {
+		bindIdentity(c, p, id, st.UserID)
+		return
+	}
+
+	var identity models.UserIdentityModel
+	var u models.UserModel
+	err = global.DB.Take(&identity, "provider = ? and subject = ?", p.Name, id.Subject).Error
+	switch {
+	case err == nil:
+		err = global.DB.Take(&u, identity.UserID).Error
+		// 第三方的资料有变化时同步过来
+		global.DB.Model(&identity).Updates(map[string]any{
+			"username":   id.Username,
+			"email":      id.Email,
+			"avatar_url": id.AvatarURL,
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		u, err = transaction.CreateOAuthUserTx(newOAuthUser(p, id, c.ClientIP()), newIdentity(p, id))
+	}
+	if err != nil {
+		log_service.NewLoginFail(p.LoginType(), err.Error(), id.Subject, "", c)
+		res.FailWithMsg(p.Label+"登录失败", c)
+		return
+	}
+
+	if u.IsBanned() {
+		log_service.NewLoginFail(p.LoginType(), "账号封禁中", u.Username, "", c)
+		res.FailWithMsg(user_service.BanMsg(&u), c)
+		return
+	}
+
+	// 开启了两步验证的先返回凭证，第二步验证通过后再颁发 token
+	ticket, err := user_service.NewTwoFactorTicket(u, p.LoginType())
+	if err != nil {
+		res.Fail(err, "两步验证失败", c)
+		return
+	}
+	if ticket != nil {
+		res.Success(ticket, "请输入两步验证码", c)
+		return
+	}
+
+	token, err := user_service.Login(u, p.LoginType(), c)
+	if err != nil {
+		res.FailWithMsg("token失败: "+err.Error(), c)
+		return
+	}
+	res.Success(token, "登录成功", c)
+}
+
+// bindIdentity 把第三方账号绑定到已登录的用户，每个服务只能绑定一个账号
+func bindIdentity(c *gin.Context, p *oauth_service.Provider, id oauth_service.Identity, userID uint) {
+	var count int64
+	global.DB.Model(&models.UserIdentityModel{}).Where("provider = ? and subject = ?", p.Name, id.Subject).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("该"+p.Label+"账号已绑定其他用户", c)
+		return
+	}
+	global.DB.Model(&models.UserIdentityModel{}).Where("user_id = ? and provider = ?", userID, p.Name).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("已绑定"+p.Label+"账号，请先解绑", c)
+		return
+	}
+
+	identity := newIdentity(p, id)
+	identity.UserID = userID
+	err := global.DB.Create(&identity).Error
+	if err != nil {
+		res.Fail(err, "绑定失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("绑定" + p.Label)
+	log.SetItem("用户", userID)
+	res.SuccessWithMsg(p.Label+"绑定成功", c)
+}
+
+func newIdentity(p *oauth_service.Provider, id oauth_service.Identity) models.UserIdentityModel {
+	return models.UserIdentityModel{
+		Provider:  p.Name,
+		Subject:   id.Subject,
+		Username:  id.Username,
+		Email:     id.Email,
+		AvatarURL: id.AvatarURL,
+	}
+}
+
+// newOAuthUser 自动注册的用户，第三方的用户名可用就沿用，否则由账号标识生成
+// 第三方的邮箱不一定验证过，用占位邮箱，之后可以绑定真实邮箱
+func newOAuthUser(p *oauth_service.Provider, id oauth_service.Identity, ip string) models.UserModel {
+	username := id.Username
+	_, valid := user.IsValidUsername(username)
+	_, available := user.IsAvailableUsername(username)
+	if !valid || !available {
+		username = p.Name + "_" + hash.Md5([]byte(p.Name + ":" + id.Subject))[:12]
+		if len(username) > 32 {
+			username = username[len(username)-32:]
+		}
+	}
+	nickname := []rune(id.Nickname)
+	if len(nickname) == 0 {
+		nickname = []rune(id.Username)
+	}
+	if len(nickname) == 0 {
+		nickname = []rune(username)
+	}
+	if len(nickname) > 32 {
+		nickname = nickname[:32]
+	}
+	return models.UserModel{
+		Username:       username,
+		Nickname:       string(nickname),
+		Email:          username + "@oauth.invalid",
+		AvatarURL:      id.AvatarURL,
+		RegisterSource: p.RegisterSource(),
+		Role:           enum.UserRoleType,
+		LastLoginIP:    ip,
+	}
+}
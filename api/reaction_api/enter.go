@@ -0,0 +1,5 @@
+// Path: ./api/reaction_api/enter.go
+
+package reaction_api
+
+type ReactionApi struct{}
@@ -0,0 +1,89 @@
+// Path: ./api/reaction_api/reaction_list.go
+
+package reaction_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/reaction_service"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type ReactionCountReq struct {
+	TargetType enum.ReactionTargetType `form:"targetType" binding:"required,oneof=1 2"`
+	TargetID   uint                    `form:"targetID" binding:"required"`
+}
+
+// ReactionCountView 对象各表情的回应数，登录时带上自己是否回应过
+func (ReactionApi) ReactionCountView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReactionCountReq)
+
+	_, err := reaction_service.GetTarget(req.TargetType, req.TargetID)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	var userID uint
+	if claims, err := jwts.ParseTokenFromRequest(c); err == nil && claims != nil {
+		userID = claims.UserID
+	}
+	res.SuccessWithData(reaction_service.GetCounts(req.TargetType, req.TargetID, userID), c)
+}
+
+type ReactionUserListReq struct {
+	common.PageInfo
+	TargetType enum.ReactionTargetType `form:"targetType" binding:"required,oneof=1 2"`
+	TargetID   uint                    `form:"targetID" binding:"required"`
+	Emoji      string                  `form:"emoji"` // 不传为所有表情
+}
+
+type ReactionUserResponse struct {
+	UserID        uint      `json:"userID"`
+	UserNickname  string    `json:"userNickname"`
+	UserAvatarURL string    `json:"userAvatarURL"`
+	Emoji         string    `json:"emoji"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ReactionUserListView 谁回应了这个对象，最近回应的在前
+func (ReactionApi) ReactionUserListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReactionUserListReq)
+
+	_, err := reaction_service.GetTarget(req.TargetType, req.TargetID)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	req.PageInfo.Normalize()
+	_list, count, err := common.ListQuery(models.ReactionModel{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Emoji:      req.Emoji,
+	}, common.Options{
+		PageInfo:     req.PageInfo,
+		Preloads:     []string{"UserModel"},
+		DefaultOrder: "created_at desc",
+	})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ReactionUserResponse, 0, len(_list))
+	for _, r := range _list {
+		list = append(list, ReactionUserResponse{
+			UserID:        r.UserID,
+			UserNickname:  r.UserModel.Nickname,
+			UserAvatarURL: r.UserModel.AvatarURL,
+			Emoji:         r.Emoji,
+			CreatedAt:     r.CreatedAt,
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
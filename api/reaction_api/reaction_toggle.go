@@ -0,0 +1,63 @@
+// Path: ./api/reaction_api/reaction_toggle.go
+
+package reaction_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/message_service"
+	"blogX_server/service/reaction_service"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ReactionToggleReq struct {
+	TargetType enum.ReactionTargetType `json:"targetType" binding:"required,oneof=1 2"` // 1 文章 2 评论
+	TargetID   uint                    `json:"targetID" binding:"required"`
+	Emoji      string                  `json:"emoji" binding:"required"`
+}
+
+type ReactionToggleResponse struct {
+	Reacted bool                     `json:"reacted"` // 切换后是否回应
+	List    []reaction_service.Count `json:"list"`
+}
+
+// ReactionToggleView 回应或取消回应一个表情，返回最新的回应数
+func (ReactionApi) ReactionToggleView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReactionToggleReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if !global.Config.Site.Article.IsReaction(req.Emoji) {
+		res.FailWithMsg("不支持的表情", c)
+		return
+	}
+	target, err := reaction_service.GetTarget(req.TargetType, req.TargetID)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	reacted, created, err := reaction_service.Toggle(claims.UserID, req.TargetType, req.TargetID, req.Emoji)
+	if err != nil {
+		res.Fail(err, "操作失败", c)
+		return
+	}
+	res.SuccessWithData(ReactionToggleResponse{
+		Reacted: reacted,
+		List:    reaction_service.GetCounts(req.TargetType, req.TargetID, claims.UserID),
+	}, c)
+
+	if !created {
+		return
+	}
+	r := models.ReactionModel{UserID: claims.UserID, TargetType: req.TargetType, TargetID: req.TargetID, Emoji: req.Emoji}
+	if err = global.DB.Take(&r.UserModel, claims.UserID).Error; err != nil {
+		return
+	}
+	if err = message_service.SendReactionNotify(r, target.Article, target.Comment); err != nil {
+		logrus.Errorf("发送表情回应提醒失败: %v", err)
+	}
+}
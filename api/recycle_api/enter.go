@@ -0,0 +1,5 @@
+// Path: ./api/recycle_api/enter.go
+
+package recycle_api
+
+type RecycleApi struct{}
@@ -0,0 +1,83 @@
+// Path: ./api/recycle_api/recycle_list.go
+
+package recycle_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type RecycleListReq struct {
+	common.PageInfo
+	Type    enum.RecycleType `form:"type" binding:"omitempty,oneof=1 2"`
+	OwnerID uint             `form:"ownerID"` // 仅管理员可用，不传就是所有人
+}
+
+type RecycleListResp struct {
+	models.RecycleModel
+	OwnerNickname    string    `json:"ownerNickname"`
+	OperatorNickname string    `json:"operatorNickname"`
+	ExpireAt         time.Time `json:"expireAt"`   // 到期后彻底删除
+	Restorable       bool      `json:"restorable"` // 当前用户能否恢复
+}
+
+// RecycleListView 回收站列表，普通用户只能看到自己的内容，管理员可以看到所有人的
+func (RecycleApi) RecycleListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(RecycleListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if claims.Role != enum.AdminRoleType {
+		req.OwnerID = claims.UserID
+	}
+
+	query, err := common.TimeQuery(req.StartTime, req.EndTime)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	req.PageInfo.Normalize()
+
+	_list, count, err := common.ListQuery(
+		models.RecycleModel{
+			Type:    req.Type,
+			OwnerID: req.OwnerID,
+		},
+		common.Options{
+			PageInfo: req.PageInfo,
+			Likes:    []string{"title"},
+			Preloads: []string{"OwnerModel", "OperatorModel"},
+			Where:    query,
+		})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	days := global.Config.Site.Article.RecycleDays
+	list := make([]RecycleListResp, 0, len(_list))
+	for _, r := range _list {
+		list = append(list, RecycleListResp{
+			RecycleModel:     r,
+			OwnerNickname:    r.OwnerModel.Nickname,
+			OperatorNickname: r.OperatorModel.Nickname,
+			ExpireAt:         r.CreatedAt.AddDate(0, 0, days),
+			Restorable:       canRestore(claims, &r),
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
+
+// canRestore 管理员都可以恢复；普通用户只能恢复自己删除的内容，被管理员或文章作者删除的不行
+func canRestore(claims *jwts.MyClaims, r *models.RecycleModel) bool {
+	if claims.Role == enum.AdminRoleType {
+		return true
+	}
+	return r.OwnerID == claims.UserID && r.OperatorID == claims.UserID
+}
@@ -0,0 +1,66 @@
+// Path: ./api/recycle_api/recycle_remove.go
+
+package recycle_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// RecycleRemoveView 从回收站彻底删除，不等到期
+// 内容的发布者和管理员可以操作
+func (RecycleApi) RecycleRemoveView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDListRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if len(req.IDList) == 0 {
+		res.FailWithMsg("没有指定删除对象", c)
+		return
+	}
+
+	var list []models.RecycleModel
+	err := global.DB.Where("id IN ?", req.IDList).Find(&list).Error
+	if err != nil {
+		res.Fail(err, "查询数据库失败", c)
+		return
+	}
+	if len(list) != len(req.IDList) {
+		res.FailWithMsg("部分记录不存在或无权限访问", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetLevel(enum.LogWarnLevel)
+	log.SetTitle("彻底删除失败")
+
+	var succ []uint
+	for _, r := range list {
+		item := fmt.Sprintf("%s[id: %d][%s]", r.Type, r.TargetID, r.Title)
+		if r.OwnerID != claims.UserID && claims.Role != enum.AdminRoleType {
+			log.SetItem("权限不足", item)
+			continue
+		}
+		err = transaction.PurgeRecycle(&r)
+		if err != nil {
+			log.SetItemWarn("失败", fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+		log.SetItem("成功", item)
+		succ = append(succ, r.ID)
+	}
+
+	if len(succ) == 0 {
+		res.FailWithMsg("彻底删除失败", c)
+		return
+	}
+	log.SetTitle("彻底删除成功")
+	res.SuccessWithMsg(fmt.Sprintf("彻底删除完成，共计 %d 条，删除 %d 条", len(list), len(succ)), c)
+}
@@ -0,0 +1,73 @@
+// Path: ./api/recycle_api/recycle_restore.go
+
+package recycle_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// RecycleRestoreView 从回收站批量恢复
+func (RecycleApi) RecycleRestoreView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDListRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if len(req.IDList) == 0 {
+		res.FailWithMsg("没有指定恢复对象", c)
+		return
+	}
+
+	var list []models.RecycleModel
+	err := global.DB.Where("id IN ?", req.IDList).Find(&list).Error
+	if err != nil {
+		res.Fail(err, "查询数据库失败", c)
+		return
+	}
+	if len(list) != len(req.IDList) {
+		res.FailWithMsg("部分记录不存在或无权限访问", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("回收站恢复失败")
+
+	var succ []uint
+	for _, r := range list {
+		item := fmt.Sprintf("%s[id: %d][%s]", r.Type, r.TargetID, r.Title)
+		if !canRestore(claims, &r) {
+			log.SetItem("权限不足", item)
+			continue
+		}
+		switch r.Type {
+		case enum.RecycleArticleType:
+			err = transaction.RestoreArticleTx(&r)
+		case enum.RecycleCommentType:
+			err = transaction.RestoreCommentTx(&r)
+		}
+		if err != nil {
+			log.SetItemWarn("失败", fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+		log.SetItem("成功", item)
+		succ = append(succ, r.ID)
+	}
+
+	if len(succ) == 0 {
+		if err != nil {
+			res.FailWithMsg(fmt.Sprintf("恢复失败: %s", err), c)
+			return
+		}
+		res.FailWithMsg("恢复失败", c)
+		return
+	}
+	log.SetTitle("回收站恢复成功")
+	res.SuccessWithMsg(fmt.Sprintf("恢复完成，共计 %d 条，恢复 %d 条", len(list), len(succ)), c)
+}
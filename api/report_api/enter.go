@@ -0,0 +1,5 @@
+// Path: ./api/report_api/enter.go
+
+package report_api
+
+type ReportApi struct{}
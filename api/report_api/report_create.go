@@ -0,0 +1,66 @@
+// Path: ./api/report_api/report_create.go
+
+package report_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/report_service"
+	"blogX_server/utils/jwts"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+type ReportCreateReq struct {
+	TargetType enum.ReportTargetType `json:"targetType" binding:"required,oneof=1 2 3"` // 1 文章 2 评论 3 用户
+	TargetID   uint                  `json:"targetID" binding:"required"`
+	Reason     enum.ReportReason     `json:"reason" binding:"required,oneof=1 2 3 4 5 6"`
+	Content    string                `json:"content" binding:"max=512"` // 补充说明，原因为其他时必填
+}
+
+// ReportCreateView 举报文章、评论或用户，同一个对象在处理前只能举报一次
+func (ReportApi) ReportCreateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReportCreateReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	req.Content = strings.TrimSpace(req.Content)
+	if req.Reason == enum.ReportReasonOtherType && req.Content == "" {
+		res.FailWithMsg("请填写举报说明", c)
+		return
+	}
+
+	// 只能举报公开可见的内容
+	t := report_service.GetTarget(report_service.Key{Type: req.TargetType, ID: req.TargetID})
+	visible := t.Exists
+	switch {
+	case t.Article != nil:
+		visible = t.Article.Status == enum.ArticleStatusPublish
+	case t.Comment != nil:
+		visible = t.Comment.Status == enum.CommentStatusPublished
+	}
+	if !visible {
+		res.FailWithMsg("举报的内容不存在", c)
+		return
+	}
+	if t.OwnerID == claims.UserID {
+		res.FailWithMsg("不能举报自己", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("举报")
+
+	err := report_service.Submit(claims.UserID, report_service.Key{Type: req.TargetType, ID: req.TargetID}, req.Reason, req.Content)
+	if errors.Is(err, report_service.ErrReported) {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if err != nil {
+		res.Fail(err, "举报失败", c)
+		return
+	}
+	res.SuccessWithMsg("举报成功，我们会尽快处理", c)
+}
@@ -0,0 +1,95 @@
+// Path: ./api/report_api/report_handle.go
+
+package report_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/message_service"
+	"blogX_server/service/report_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandleReq struct {
+	TargetType enum.ReportTargetType `json:"targetType" binding:"required,oneof=1 2 3"`
+	TargetID   uint                  `json:"targetID" binding:"required"`
+	Action     enum.ReportAction     `json:"action" binding:"required,oneof=1 2 3 4 5"` // 1 驳回 2 隐藏 3 删除 4 警告 5 封禁
+	Note       string                `json:"note" binding:"max=256"`                    // 处理说明，会通知举报人和被处理的用户
+	BanDays    int                   `json:"banDays" binding:"max=3650"`                // 封禁天数，封禁时必填
+}
+
+// ReportHandleView 处理一个对象收到的所有待处理举报，处理结果通知每个举报人
+func (ReportApi) ReportHandleView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReportHandleReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if req.Action == enum.ReportActionBan && req.BanDays <= 0 {
+		res.FailWithMsg("请填写封禁天数", c)
+		return
+	}
+
+	k := report_service.Key{Type: req.TargetType, ID: req.TargetID}
+	reports := report_service.Pending(k)
+	if len(reports) == 0 {
+		res.FailWithMsg("没有待处理的举报", c)
+		return
+	}
+
+	t := report_service.GetTarget(k)
+	if !t.Exists && req.Action != enum.ReportActionDismiss {
+		res.FailWithMsg("被举报的内容已不存在，只能驳回", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.ShowClaim(claims)
+	log.SetTitle(fmt.Sprintf("处理举报: %s", req.Action))
+	log.SetItem("对象", fmt.Sprintf("%s %d %s", req.TargetType, req.TargetID, t.Title))
+
+	// 先记录处理结果，两个管理员同时处理时只有记录成功的一方执行处理，不会重复封禁或通知
+	reports, err := report_service.Resolve(reports, req.Action, req.Note, claims.UserID)
+	if err != nil {
+		res.Fail(err, "举报状态更新失败", c)
+		return
+	}
+	if len(reports) == 0 {
+		res.FailWithMsg("举报已被其他管理员处理", c)
+		return
+	}
+	log.SetItem("举报数", len(reports))
+
+	title, content, err := report_service.Apply(t, req.Action, req.Note, req.BanDays, claims.UserID)
+	if err != nil {
+		log.SetItemWarn("处理失败", err.Error())
+		if err := report_service.Unresolve(reports, claims.UserID); err != nil {
+			log.SetItemWarn("举报状态恢复失败", err.Error())
+		}
+		res.FailWithError(err, c)
+		return
+	}
+	if title != "" {
+		err = message_service.SendSystemNotify(t.OwnerID, title, content, "", "")
+		if err != nil {
+			log.SetItemWarn("发布者消息发送失败", err.Error())
+		}
+	}
+
+	content = fmt.Sprintf("您对%s「%s」的举报已处理，处理结果：%s", req.TargetType, t.Title, req.Action)
+	if req.Action == enum.ReportActionDismiss {
+		content = fmt.Sprintf("您对%s「%s」的举报经核实未发现违规", req.TargetType, t.Title)
+	}
+	if req.Note != "" {
+		content += "。说明：" + req.Note
+	}
+	for _, r := range reports {
+		err = message_service.SendSystemNotify(r.UserID, "举报处理结果", content, "", "")
+		if err != nil {
+			log.SetItemWarn(fmt.Sprintf("举报人 %d 消息发送失败", r.UserID), err.Error())
+		}
+	}
+	res.SuccessWithMsg(fmt.Sprintf("已处理举报 %d 条", len(reports)), c)
+}
@@ -0,0 +1,155 @@
+// Path: ./api/report_api/report_list.go
+
+package report_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/report_service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ReportTargetListReq struct {
+	common.PageInfo
+	TargetType enum.ReportTargetType `form:"targetType" binding:"omitempty,oneof=1 2 3"`
+	Status     enum.ReportStatus     `form:"status" binding:"omitempty,oneof=1 2 3"` // 默认待处理
+}
+
+type ReasonCount struct {
+	Reason enum.ReportReason `json:"reason"`
+	Label  string            `json:"label"`
+	Count  int               `json:"count"`
+}
+
+type ReportTargetResponse struct {
+	report_service.Target
+	Count   int           `json:"count"` // 举报人数
+	Reasons []ReasonCount `json:"reasons"`
+	FirstAt time.Time     `json:"firstAt"`
+	LastAt  time.Time     `json:"lastAt"`
+}
+
+// ReportTargetListView 管理员的举报处理中心，按被举报的对象分组，举报人数多的在前
+func (ReportApi) ReportTargetListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReportTargetListReq)
+	if req.Status == 0 {
+		req.Status = enum.ReportStatusPending
+	}
+	req.PageInfo.Normalize()
+
+	query := global.DB.Model(&models.ReportModel{}).Where("status = ?", req.Status)
+	if req.TargetType != 0 {
+		query = query.Where("target_type = ?", req.TargetType)
+	}
+
+	var count int64
+	global.DB.Table("(?) AS t", query.Session(&gorm.Session{}).Select("target_type, target_id").
+		Group("target_type, target_id")).Count(&count)
+
+	var rows []struct {
+		TargetType enum.ReportTargetType
+		TargetID   uint
+		Count      int
+		FirstAt    time.Time
+		LastAt     time.Time
+	}
+	err := query.Session(&gorm.Session{}).
+		Select("target_type, target_id, COUNT(*) AS count, MIN(created_at) AS first_at, MAX(created_at) AS last_at").
+		Group("target_type, target_id").Order("count DESC, last_at DESC").
+		Offset(req.PageInfo.GetOffset()).Limit(req.PageInfo.GetLimit()).Scan(&rows).Error
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+	list := make([]ReportTargetResponse, 0, len(rows))
+	if len(rows) == 0 {
+		res.SuccessWithList(list, int(count), c)
+		return
+	}
+
+	var keys []report_service.Key
+	var pairs [][]any
+	for _, r := range rows {
+		keys = append(keys, report_service.Key{Type: r.TargetType, ID: r.TargetID})
+		pairs = append(pairs, []any{r.TargetType, r.TargetID})
+	}
+	targets := report_service.GetTargets(keys)
+
+	// 这一页的对象各原因的举报人数
+	var reasonRows []struct {
+		TargetType enum.ReportTargetType
+		TargetID   uint
+		Reason     enum.ReportReason
+		Count      int
+	}
+	query.Session(&gorm.Session{}).Select("target_type, target_id, reason, COUNT(*) AS count").
+		Where("(target_type, target_id) IN ?", pairs).
+		Group("target_type, target_id, reason").Order("count DESC").Scan(&reasonRows)
+	reasonMap := map[report_service.Key][]ReasonCount{}
+	for _, r := range reasonRows {
+		k := report_service.Key{Type: r.TargetType, ID: r.TargetID}
+		reasonMap[k] = append(reasonMap[k], ReasonCount{Reason: r.Reason, Label: r.Reason.String(), Count: r.Count})
+	}
+
+	for i, r := range rows {
+		list = append(list, ReportTargetResponse{
+			Target:  targets[keys[i]],
+			Count:   r.Count,
+			Reasons: reasonMap[keys[i]],
+			FirstAt: r.FirstAt,
+			LastAt:  r.LastAt,
+		})
+	}
+	res.SuccessWithList(list, int(count), c)
+}
+
+type ReportListReq struct {
+	common.PageInfo
+	TargetType enum.ReportTargetType `form:"targetType" binding:"omitempty,oneof=1 2 3"`
+	TargetID   uint                  `form:"targetID"`
+	Status     enum.ReportStatus     `form:"status" binding:"omitempty,oneof=1 2 3"`
+}
+
+type ReportResponse struct {
+	models.ReportModel
+	UserNickname string `json:"userNickname"`
+	ReasonLabel  string `json:"reasonLabel"`
+	ActionLabel  string `json:"actionLabel"`
+}
+
+// ReportListView 某个对象收到的举报明细，不指定对象时为全部举报
+func (ReportApi) ReportListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(ReportListReq)
+
+	req.PageInfo.Normalize()
+	_list, count, err := common.ListQuery(models.ReportModel{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Status:     req.Status,
+	}, common.Options{
+		PageInfo:     req.PageInfo,
+		Likes:        []string{"content"},
+		Preloads:     []string{"UserModel"},
+		DefaultOrder: "created_at desc",
+	})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+
+	list := make([]ReportResponse, 0, len(_list))
+	for _, r := range _list {
+		list = append(list, ReportResponse{
+			ReportModel:  r,
+			UserNickname: r.UserModel.Nickname,
+			ReasonLabel:  r.Reason.String(),
+			ActionLabel:  r.Action.String(),
+		})
+	}
+	res.SuccessWithList(list, count, c)
+}
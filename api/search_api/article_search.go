@@ -92,7 +92,7 @@ func (SearchApi) ArticleSearchView(c *gin.Context) {
 
 		where := global.DB.Where("")
 		if req.Tag != "" {
-			where = where.Where("tags LIKE ?", "%"+req.Tag+"%")
+			where = common.TagQuery(where, req.Tag)
 		}
 
 		// 解析时间戳并查询
@@ -140,6 +140,8 @@ func (SearchApi) ArticleSearchView(c *gin.Context) {
 	// status = 3 表示已发布的文章
 	// NewTermQuery 用于精确匹配，不会对查询词进行分词
 	query.Must(elastic.NewTermQuery("status", 3))
+	// 回收站中的文章 deleted_at 有值（river 同步过来的），排除掉
+	query.MustNot(elastic.NewExistsQuery("deleted_at"))
 
 	// 2. 如果指定了标签，添加标签过滤条件
 	// 标签也使用 Must 确保强制匹配（AND）
@@ -0,0 +1,170 @@
+// Path: ./api/sensitive_api/enter.go
+
+package sensitive_api
+
+import (
+	"blogX_server/common"
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+type SensitiveApi struct{}
+
+// 敏感词的增删改都会重新加载内存中的词表，立即生效
+
+type SensitiveCreateReq struct {
+	Words    []string             `json:"words" binding:"required,min=1,max=1000"` // 批量添加，已存在的跳过
+	Category string               `json:"category" binding:"required,max=32"`
+	Action   enum.SensitiveAction `json:"action" binding:"required,oneof=1 2 3"` // 1 拦截 2 打码 3 审核
+}
+
+func (SensitiveApi) SensitiveCreateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(SensitiveCreateReq)
+
+	seen := map[string]bool{}
+	var words []string
+	for _, w := range req.Words {
+		w = strings.TrimSpace(w)
+		if w == "" || len([]rune(w)) > 64 || seen[w] {
+			continue
+		}
+		seen[w] = true
+		words = append(words, w)
+	}
+	if len(words) == 0 {
+		res.FailWithMsg("没有有效的敏感词", c)
+		return
+	}
+
+	var exists []string
+	global.DB.Model(&models.SensitiveWordModel{}).Where("word in ?", words).Pluck("word", &exists)
+	for _, w := range exists {
+		seen[w] = false
+	}
+	var list []models.SensitiveWordModel
+	for _, w := range words {
+		if seen[w] {
+			list = append(list, models.SensitiveWordModel{Word: w, Category: req.Category, Action: req.Action})
+		}
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("添加敏感词")
+
+	if len(list) > 0 {
+		err := global.DB.Create(&list).Error
+		if err != nil {
+			res.Fail(err, "添加敏感词失败", c)
+			return
+		}
+		if err = sensitive_service.Load(); err != nil {
+			res.Fail(err, "重新加载敏感词失败", c)
+			return
+		}
+	}
+	res.SuccessWithMsg(fmt.Sprintf("添加敏感词 %d 个，已存在 %d 个", len(list), len(words)-len(list)), c)
+}
+
+type SensitiveListReq struct {
+	common.PageInfo
+	Category string               `form:"category"`
+	Action   enum.SensitiveAction `form:"action"`
+}
+
+func (SensitiveApi) SensitiveListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(SensitiveListReq)
+
+	req.PageInfo.Normalize()
+	list, count, err := common.ListQuery(models.SensitiveWordModel{
+		Category: req.Category,
+		Action:   req.Action,
+	}, common.Options{
+		PageInfo:     req.PageInfo,
+		Likes:        []string{"word"},
+		DefaultOrder: "id desc",
+	})
+	if err != nil {
+		res.Fail(err, "查询失败", c)
+		return
+	}
+	res.SuccessWithList(list, count, c)
+}
+
+type SensitiveUpdateReq struct {
+	ID       uint                 `json:"id" binding:"required"`
+	Category string               `json:"category" binding:"required,max=32"`
+	Action   enum.SensitiveAction `json:"action" binding:"required,oneof=1 2 3"`
+}
+
+func (SensitiveApi) SensitiveUpdateView(c *gin.Context) {
+	req := c.MustGet("bindReq").(SensitiveUpdateReq)
+
+	var model models.SensitiveWordModel
+	err := global.DB.Take(&model, req.ID).Error
+	if err != nil {
+		res.Fail(err, "敏感词不存在", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("更新敏感词")
+
+	err = global.DB.Model(&model).Updates(map[string]any{
+		"category": req.Category,
+		"action":   req.Action,
+	}).Error
+	if err != nil {
+		res.Fail(err, "更新敏感词失败", c)
+		return
+	}
+	if err = sensitive_service.Load(); err != nil {
+		res.Fail(err, "重新加载敏感词失败", c)
+		return
+	}
+	res.SuccessWithMsg("更新敏感词成功", c)
+}
+
+func (SensitiveApi) SensitiveRemoveView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDListRequest)
+
+	var list []models.SensitiveWordModel
+	global.DB.Find(&list, "id in ?", req.IDList)
+	if len(list) == 0 {
+		res.FailWithMsg("无匹配敏感词", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("删除敏感词")
+
+	err := global.DB.Delete(&list).Error
+	if err != nil {
+		res.Fail(err, "删除敏感词失败", c)
+		return
+	}
+	if err = sensitive_service.Load(); err != nil {
+		res.Fail(err, "重新加载敏感词失败", c)
+		return
+	}
+	res.SuccessWithMsg(fmt.Sprintf("删除敏感词 %d 个", len(list)), c)
+}
+
+type SensitiveCheckReq struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// SensitiveCheckView 用当前词表检查一段文本，方便管理员调试
+func (SensitiveApi) SensitiveCheckView(c *gin.Context) {
+	req := c.MustGet("bindReq").(SensitiveCheckReq)
+	res.SuccessWithData(sensitive_service.Check(req.Text), c)
+}
@@ -0,0 +1,32 @@
+// Path: ./api/seo_api/article_seo.go
+
+package seo_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/seo_service"
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleSeoView 文章详情页的 SEO 信息：canonical 地址、Open Graph / Twitter 标签、JSON-LD
+// 只有已发布的文章会被收录，其他状态一律当作不存在
+func (SeoApi) ArticleSeoView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+
+	var a models.ArticleModel
+	err := global.DB.Preload("UserModel").Preload("CategoryModel").
+		Take(&a, "id = ? and status = ?", req.ID, enum.ArticleStatusPublish).Error
+	if err != nil {
+		res.FailWithMsg("文章不存在", c)
+		return
+	}
+
+	var categoryName string
+	if a.CategoryModel != nil {
+		categoryName = a.CategoryModel.Name
+	}
+	res.SuccessWithData(seo_service.ArticleMeta(a, a.UserModel, categoryName), c)
+}
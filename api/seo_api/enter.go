@@ -0,0 +1,5 @@
+// Path: ./api/seo_api/enter.go
+
+package seo_api
+
+type SeoApi struct{}
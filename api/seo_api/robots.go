@@ -0,0 +1,19 @@
+// Path: ./api/seo_api/robots.go
+
+package seo_api
+
+import (
+	"blogX_server/global"
+	"blogX_server/service/seo_service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// RobotsView robots.txt，规则来自站点配置，修改配置后立即生效，所以不走缓存
+func (SeoApi) RobotsView(c *gin.Context) {
+	var sitemap string
+	if baseURL := global.Config.Site.BaseURL(); baseURL != "" {
+		sitemap = baseURL + "/sitemap.xml"
+	}
+	c.String(http.StatusOK, seo_service.Robots(global.Config.Site.Seo.Robots, sitemap))
+}
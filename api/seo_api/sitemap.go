@@ -0,0 +1,87 @@
+// Path: ./api/seo_api/sitemap.go
+
+package seo_api
+
+import (
+	"blogX_server/global"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/seo_service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const sitemapContentType = "application/xml; charset=utf-8"
+
+// SitemapView sitemap.xml
+// 地址数不多时直接输出所有地址，超过 SitemapPageSize 时输出索引，指向 /sitemap/:name 的分页文件
+func (SeoApi) SitemapView(c *gin.Context) {
+	list, err := seo_service.CollectSitemap()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var total int
+	var all []seo_service.SitemapURL
+	for _, g := range list {
+		total += len(g.URLs)
+		all = append(all, g.URLs...)
+	}
+
+	if total <= seo_service.SitemapPageSize {
+		byteData, err := seo_service.RenderURLSet(all)
+		writeSitemap(c, byteData, err, seo_service.SitemapPage{URLs: all}.LastMod())
+		return
+	}
+
+	pages := seo_service.SplitSitemap(list, seo_service.SitemapPageSize)
+	var lastMod time.Time
+	for _, p := range pages {
+		if t := p.LastMod(); t.After(lastMod) {
+			lastMod = t
+		}
+	}
+	byteData, err := seo_service.RenderSitemapIndex(global.Config.Site.BaseURL()+"/sitemap/", pages)
+	writeSitemap(c, byteData, err, lastMod)
+}
+
+type SitemapPageReq struct {
+	Name string `uri:"name" binding:"required"` // 如 article-1.xml
+}
+
+// SitemapPageView sitemap 索引中的分页文件
+func (SeoApi) SitemapPageView(c *gin.Context) {
+	req := c.MustGet("bindReq").(SitemapPageReq)
+
+	list, err := seo_service.CollectSitemap()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, p := range seo_service.SplitSitemap(list, seo_service.SitemapPageSize) {
+		if p.Name != req.Name {
+			continue
+		}
+		byteData, err := seo_service.RenderURLSet(p.URLs)
+		writeSitemap(c, byteData, err, p.LastMod())
+		return
+	}
+	c.String(http.StatusNotFound, "sitemap 不存在")
+}
+
+// writeSitemap 和订阅源一样，sitemap 是给爬虫的，出错时直接返回 http 状态码
+func writeSitemap(c *gin.Context, byteData []byte, err error, lastMod time.Time) {
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	var lastModified string
+	if !lastMod.IsZero() {
+		lastModified = lastMod.UTC().Format(http.TimeFormat)
+	}
+	if redis_cache.NotModified(c, redis_cache.ETag(string(byteData)), lastModified) {
+		return
+	}
+	c.Data(http.StatusOK, sitemapContentType, byteData)
+}
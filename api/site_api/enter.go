@@ -10,6 +10,7 @@ import (
 	"blogX_server/middleware"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
+	"blogX_server/service/oauth_service"
 	"blogX_server/service/redis_service/redis_site"
 	"blogX_server/utils/jwts"
 	"fmt"
@@ -107,9 +108,14 @@ func (SiteApi) SiteInfoView(c *gin.Context) {
 	res.SuccessWithData(data, c)
 }
 
-// SiteInfoQQView qq登录
+// SiteInfoQQView qq登录，每次生成新的 state，登录和绑定时校验
 func (SiteApi) SiteInfoQQView(c *gin.Context) {
-	res.SuccessWithData(global.Config.QQ.Url(), c)
+	state, err := oauth_service.NewState(c, oauth_service.State{Provider: oauth_service.StateProviderQQ})
+	if err != nil {
+		res.Fail(err, "生成授权地址失败", c)
+		return
+	}
+	res.SuccessWithData(global.Config.QQ.Url(state), c)
 }
 
 type SiteUpdateRequest struct {
@@ -4,6 +4,7 @@ package user_api
 
 import (
 	"blogX_server/common/res"
+	"blogX_server/common/transaction"
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/service/log_service"
@@ -46,6 +47,14 @@ func (UserApi) BindEmailView(c *gin.Context) {
 	log.ShowAll()
 	log.SetTitle("邮箱绑定")
 
+	// 用这个邮箱游客评论过的，评论转到当前用户名下
+	claimed, err := transaction.ClaimGuestCommentsTx(emailAddr.(string), claims.UserID)
+	if err != nil {
+		log.SetItemWarn("认领游客评论失败", err.Error())
+	} else if claimed > 0 {
+		log.SetItem("认领游客评论", claimed)
+	}
+
 	// redis 删除记录
 	eid := c.MustGet("emailID").(string)
 	global.Redis.Del(eid)
@@ -6,10 +6,10 @@ import (
 	"blogX_server/common/res"
 	"blogX_server/global"
 	"blogX_server/service/log_service"
+	"blogX_server/service/user_service"
 	"blogX_server/utils/jwts"
 	"blogX_server/utils/pwd"
 	"blogX_server/utils/user"
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"time"
@@ -71,12 +71,11 @@ func (UserApi) ChangePasswordView(c *gin.Context) {
 		return
 	}
 
-	// redis 缓存一个更新时间，以便验证之前签发的 token 为失效
-	key := fmt.Sprintf("%dpassword_update", u.ID)
-	err = global.Redis.Set(key, time.Now().Unix(), time.Duration(global.Config.Jwt.Expire)*time.Hour).Err()
+	// 下线所有设备，之前签发的 token 和 refresh token 全部失效
+	err = user_service.LogoutAll(u.ID)
 	if err != nil {
-		logrus.Error("缓存密码更新时间失败:", err)
-		res.FailWithMsg("缓存密码更新时间失败: "+err.Error(), c)
+		logrus.Error("下线设备失败:", err)
+		res.FailWithMsg("下线设备失败: "+err.Error(), c)
 		return
 	}
 
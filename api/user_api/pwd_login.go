@@ -9,11 +9,10 @@ import (
 	"blogX_server/models/enum"
 	"blogX_server/service/email_service"
 	"blogX_server/service/log_service"
-	"blogX_server/utils/jwts"
+	"blogX_server/service/user_service"
 	"blogX_server/utils/pwd"
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"time"
 )
 
 type PwdLoginReq struct {
@@ -45,25 +44,29 @@ func (UserApi) PwdLoginView(c *gin.Context) {
 		return
 	}
 
-	// 颁发 token
-	token, err := jwts.GenerateToken(jwts.Claims{
-		UserID:   user.ID,
-		Username: user.Username,
-		Role:     user.Role,
-	})
-	if err != nil {
-		res.FailWithMsg("token失败: "+err.Error(), c)
+	if user.IsBanned() {
+		log_service.NewLoginFail(loginType, "账号封禁中", req.Username, "", c)
+		res.FailWithMsg(user_service.BanMsg(&user), c)
 		return
 	}
 
-	// 登录成功
-	global.DB.Model(&user).Updates(map[string]interface{}{
-		"last_login_ip":   c.ClientIP(),
-		"last_login_time": time.Now(),
-	})
+	// 开启了两步验证的先返回凭证，第二步验证通过后再颁发 token
+	ticket, err := user_service.NewTwoFactorTicket(user, loginType)
+	if err != nil {
+		res.Fail(err, "两步验证失败", c)
+		return
+	}
+	if ticket != nil {
+		res.Success(ticket, "请输入两步验证码", c)
+		return
+	}
 
-	// 登录日志
-	log_service.NewLoginSuccess(user, loginType, c)
+	// 颁发 token，记录登录
+	token, err := user_service.Login(user, loginType, c)
+	if err != nil {
+		res.FailWithMsg("token失败: "+err.Error(), c)
+		return
+	}
 
 	// 返回 token 与成功信息
 	res.Success(token, "登录成功", c)
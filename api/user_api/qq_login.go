@@ -0,0 +1,181 @@
+// Path: ./api/user_api/qq_login.go
+
+package user_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/oauth_service"
+	"blogX_server/service/qq_service"
+	"blogX_server/service/user_service"
+	"blogX_server/utils/hash"
+	"blogX_server/utils/jwts"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type QQLoginReq struct {
+	Code  string `json:"code" binding:"required"`  // QQ 授权后回调地址上的 code
+	State string `json:"state" binding:"required"` // 回调地址上的 state，和获取授权地址时写入的 cookie 比对
+}
+
+// QQLoginView QQ 登录回调，openid 没有绑定过账号时自动注册
+func (UserApi) QQLoginView(c *gin.Context) {
+	req := c.MustGet("bindReq").(QQLoginReq)
+	if st, err := oauth_service.TakeState(c, req.State); err != nil || st.Provider != oauth_service.StateProviderQQ {
+		log_service.NewLoginFail(enum.QQLoginType, "state 无效", "", "", c)
+		res.FailWithMsg("授权已过期，请重新登录", c)
+		return
+	}
+
+	info, err := qq_service.NewClient(global.Config.QQ).Login(req.Code)
+	if err != nil {
+		log_service.NewLoginFail(enum.QQLoginType, err.Error(), "", "", c)
+		res.FailWithMsg("QQ登录失败", c)
+		return
+	}
+
+	var user models.UserModel
+	err = global.DB.Take(&user, "open_id = ?", info.OpenID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		user, err = registerQQUser(info, c.ClientIP())
+	}
+	if err != nil {
+		log_service.NewLoginFail(enum.QQLoginType, err.Error(), info.OpenID, "", c)
+		res.FailWithMsg("QQ登录失败", c)
+		return
+	}
+
+	if user.IsBanned() {
+		log_service.NewLoginFail(enum.QQLoginType, "账号封禁中", user.Username, "", c)
+		res.FailWithMsg(user_service.BanMsg(&user), c)
+		return
+	}
+
+	// 开启了两步验证的先返回凭证，第二步验证通过后再颁发 token
+	ticket, err := user_service.NewTwoFactorTicket(user, enum.QQLoginType)
+	if err != nil {
+		res.Fail(err, "两步验证失败", c)
+		return
+	}
+	if ticket != nil {
+		res.Success(ticket, "请输入两步验证码", c)
+		return
+	}
+
+	token, err := user_service.Login(user, enum.QQLoginType, c)
+	if err != nil {
+		res.FailWithMsg("token失败: "+err.Error(), c)
+		return
+	}
+	res.Success(token, "登录成功", c)
+}
+
+// registerQQUser 用 QQ 资料创建用户，用户名由 openid 生成，邮箱为占位地址，之后可以绑定真实邮箱
+func registerQQUser(info qq_service.UserInfo, ip string) (user models.UserModel, err error) {
+	username := "qq_" + hash.Md5([]byte(info.OpenID))[:16]
+	nickname := []rune(info.Nickname)
+	if len(nickname) > 32 {
+		nickname = nickname[:32]
+	}
+	if len(nickname) == 0 {
+		nickname = []rune(username)
+	}
+	err = transaction.CreateUserAndUserConfigTx(models.UserModel{
+		Username:       username,
+		Nickname:       string(nickname),
+		Email:          username + "@qq.invalid",
+		AvatarURL:      info.AvatarURL,
+		OpenID:         info.OpenID,
+		RegisterSource: enum.RegisterSourceQQType,
+		Role:           enum.UserRoleType,
+		LastLoginIP:    ip,
+	})
+	if err != nil {
+		return
+	}
+	err = global.DBMaster.Take(&user, "username = ?", username).Error
+	return
+}
+
+// QQBindView 已登录的用户绑定 QQ，之后可以用 QQ 登录
+func (UserApi) QQBindView(c *gin.Context) {
+	req := c.MustGet("bindReq").(QQLoginReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	if st, err := oauth_service.TakeState(c, req.State); err != nil || st.Provider != oauth_service.StateProviderQQ {
+		res.FailWithMsg("授权已过期，请重新授权", c)
+		return
+	}
+
+	var user models.UserModel
+	err := global.DB.Take(&user, claims.UserID).Error
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if user.OpenID != "" {
+		res.FailWithMsg("已绑定QQ，请先解绑", c)
+		return
+	}
+
+	info, err := qq_service.NewClient(global.Config.QQ).Login(req.Code)
+	if err != nil {
+		res.Fail(err, "QQ授权失败", c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.UserModel{}).Where("open_id = ?", info.OpenID).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("该QQ已绑定其他账号", c)
+		return
+	}
+
+	err = global.DB.Model(&user).Update("open_id", info.OpenID).Error
+	if err != nil {
+		res.Fail(err, "绑定失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("绑定QQ")
+	res.SuccessWithMsg("QQ绑定成功", c)
+}
+
+// QQUnbindView 解绑 QQ，解绑后没有其他登录方式的不允许解绑
+func (UserApi) QQUnbindView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var user models.UserModel
+	err := global.DB.Take(&user, claims.UserID).Error
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if user.OpenID == "" {
+		res.FailWithMsg("没有绑定QQ", c)
+		return
+	}
+	var identities int64
+	global.DB.Model(&models.UserIdentityModel{}).Where("user_id = ?", user.ID).Count(&identities)
+	if user.Password == "" && identities == 0 {
+		res.FailWithMsg("请先设置密码，否则解绑后无法登录", c)
+		return
+	}
+
+	err = global.DB.Model(&user).Update("open_id", "").Error
+	if err != nil {
+		res.Fail(err, "解绑失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("解绑QQ")
+	res.SuccessWithMsg("QQ解绑成功", c)
+}
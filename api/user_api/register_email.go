@@ -9,7 +9,7 @@ import (
 	"blogX_server/models"
 	"blogX_server/models/enum"
 	"blogX_server/service/log_service"
-	"blogX_server/utils/jwts"
+	"blogX_server/service/user_service"
 	"blogX_server/utils/pwd"
 	"github.com/gin-gonic/gin"
 	"time"
@@ -103,13 +103,23 @@ func (UserApi) RegisterEmailView(c *gin.Context) {
 		res.FailWithError(err, c)
 		return
 	}
+	// 取回新用户的 id，用于认领游客评论和颁发 token
+	err = global.DBMaster.Take(&user, "username = ?", user.Username).Error
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+
+	// 用这个邮箱游客评论过的，评论转到新用户名下
+	claimed, err := transaction.ClaimGuestCommentsTx(user.Email, user.ID)
+	if err != nil {
+		log.SetItemWarn("认领游客评论失败", err.Error())
+	} else if claimed > 0 {
+		log.SetItem("认领游客评论", claimed)
+	}
 
-	// 颁发 token
-	token, err := jwts.GenerateToken(jwts.Claims{
-		UserID:   user.ID,
-		Username: user.Username,
-		Role:     user.Role,
-	})
+	// 创建登录设备，颁发 token
+	token, err := user_service.NewSession(user, enum.EmailPasswordLoginType, c)
 	if err != nil {
 		res.FailWithMsg("邮箱登录失败: "+err.Error(), c)
 		return
@@ -0,0 +1,210 @@
+// Path: ./api/user_api/two_factor.go
+
+package user_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/log_service"
+	"blogX_server/service/user_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type TwoFactorLoginReq struct {
+	Ticket string `json:"ticket" binding:"required"` // 第一步登录返回的凭证
+	Code   string `json:"code" binding:"required"`   // 验证器上的 6 位验证码或者恢复码
+}
+
+type TwoFactorLoginResponse struct {
+	user_service.LoginToken
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"` // 登录时完成绑定的，只显示这一次
+}
+
+// TwoFactorLoginView 登录的第二步，校验两步验证码后颁发 token
+func (UserApi) TwoFactorLoginView(c *gin.Context) {
+	req := c.MustGet("bindReq").(TwoFactorLoginReq)
+
+	r, err := user_service.VerifyTwoFactorTicket(req.Ticket, req.Code)
+	if err != nil {
+		if r.User.ID != 0 {
+			log_service.NewLoginFail(r.LoginType, "两步验证失败: "+err.Error(), r.User.Username, "", c)
+		}
+		res.FailWithError(err, c)
+		return
+	}
+	if r.User.IsBanned() {
+		log_service.NewLoginFail(r.LoginType, "账号封禁中", r.User.Username, "", c)
+		res.FailWithMsg(user_service.BanMsg(&r.User), c)
+		return
+	}
+	if r.RecoveryCodes != nil {
+		log_service.NewSecurityLog(r.User, "开启两步验证", "站点要求管理员开启，登录时完成绑定", true, c)
+	}
+	if r.UsedRecovery {
+		log_service.NewSecurityLog(r.User, "使用恢复码登录",
+			fmt.Sprintf("剩余恢复码 %d 个", user_service.RecoveryCodesLeft(r.User.ID)), true, c)
+	}
+
+	token, err := user_service.Login(r.User, r.LoginType, c)
+	if err != nil {
+		res.FailWithMsg("token失败: "+err.Error(), c)
+		return
+	}
+	res.Success(TwoFactorLoginResponse{LoginToken: token, RecoveryCodes: r.RecoveryCodes}, "登录成功", c)
+}
+
+type TwoFactorInfoResponse struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabledAt"`
+	Forced            bool       `json:"forced"`            // 站点要求必须开启，不能关闭
+	RecoveryCodesLeft int64      `json:"recoveryCodesLeft"` // 剩余可用的恢复码
+}
+
+// TwoFactorInfoView 自己的两步验证状态
+func (UserApi) TwoFactorInfoView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	data := TwoFactorInfoResponse{Forced: user_service.TwoFactorForced(user)}
+	if tf := user_service.GetTwoFactor(user.ID); tf != nil && tf.Enabled {
+		data.Enabled = true
+		data.EnabledAt = tf.EnabledAt
+		data.RecoveryCodesLeft = user_service.RecoveryCodesLeft(user.ID)
+	}
+	res.SuccessWithData(data, c)
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret string `json:"secret"` // 不能扫码时手动输入
+	URI    string `json:"uri"`    // otpauth 链接，前端生成二维码
+}
+
+// TwoFactorEnrollView 开始绑定两步验证，用验证器扫码后再确认第一个验证码
+func (UserApi) TwoFactorEnrollView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	secret, uri, err := user_service.EnrollTwoFactor(*user)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	res.SuccessWithData(TwoFactorEnrollResponse{Secret: secret, URI: uri}, c)
+}
+
+type TwoFactorCodeReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"` // 只显示这一次，提醒用户保存
+}
+
+// TwoFactorConfirmView 确认验证器上的第一个验证码，开启两步验证并返回恢复码
+func (UserApi) TwoFactorConfirmView(c *gin.Context) {
+	req := c.MustGet("bindReq").(TwoFactorCodeReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	codes, err := user_service.ConfirmTwoFactor(user.ID, req.Code)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	log_service.NewSecurityLog(*user, "开启两步验证", "", true, c)
+	res.Success(TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, "两步验证已开启", c)
+}
+
+// TwoFactorRecoveryCodesView 重新生成恢复码，之前的全部作废
+func (UserApi) TwoFactorRecoveryCodesView(c *gin.Context) {
+	req := c.MustGet("bindReq").(TwoFactorCodeReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	if _, err = user_service.VerifyTwoFactor(user.ID, req.Code); err != nil {
+		log_service.NewSecurityLog(*user, "重新生成恢复码失败", err.Error(), false, c)
+		res.FailWithError(err, c)
+		return
+	}
+	codes, err := user_service.RegenerateRecoveryCodes(user.ID)
+	if err != nil {
+		res.Fail(err, "生成恢复码失败", c)
+		return
+	}
+	log_service.NewSecurityLog(*user, "重新生成恢复码", "", true, c)
+	res.Success(TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, "恢复码已重新生成", c)
+}
+
+// TwoFactorDisableView 关闭两步验证，需要验证码确认；站点要求开启的不能关闭
+func (UserApi) TwoFactorDisableView(c *gin.Context) {
+	req := c.MustGet("bindReq").(TwoFactorCodeReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+	user, err := claims.GetUserFromClaims()
+	if err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if user_service.TwoFactorForced(user) {
+		res.FailWithMsg("站点要求管理员开启两步验证，不能关闭", c)
+		return
+	}
+
+	if _, err = user_service.VerifyTwoFactor(user.ID, req.Code); err != nil {
+		log_service.NewSecurityLog(*user, "关闭两步验证失败", err.Error(), false, c)
+		res.FailWithError(err, c)
+		return
+	}
+	if err = user_service.DisableTwoFactor(user.ID); err != nil {
+		res.Fail(err, "关闭失败", c)
+		return
+	}
+	log_service.NewSecurityLog(*user, "关闭两步验证", "", true, c)
+	res.SuccessWithMsg("两步验证已关闭", c)
+}
+
+// TwoFactorResetView 管理员重置用户的两步验证，用户丢了验证器和恢复码时使用
+func (UserApi) TwoFactorResetView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var user models.UserModel
+	if err := global.DB.Take(&user, req.ID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if !user_service.TwoFactorEnabled(user.ID) {
+		res.FailWithMsg("该用户没有开启两步验证", c)
+		return
+	}
+	if err := user_service.DisableTwoFactor(user.ID); err != nil {
+		res.Fail(err, "重置失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("重置两步验证")
+	log.SetItem("用户", fmt.Sprintf("%d %s", user.ID, user.Username))
+	log_service.NewSecurityLog(user, "管理员重置两步验证", "操作人 "+claims.Username, true, c)
+	res.SuccessWithMsg("两步验证已重置", c)
+}
@@ -7,6 +7,7 @@ import (
 	"blogX_server/global"
 	"blogX_server/models"
 	"blogX_server/service/log_service"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/jwts"
 	"blogX_server/utils/mps"
 	"github.com/gin-gonic/gin"
@@ -37,10 +38,16 @@ type UserInfoUpdateReq struct {
 	ReceiveCollectNotify   *bool `json:"receiveCollectNotify" s-m-c:"receive_collect_notify"`
 	ReceivePrivateMessage  *bool `json:"receivePrivateMessage" s-m-c:"receive_private_message"`
 	ReceiveStrangerMessage *bool `json:"receiveStrangerMessage" s-m-c:"receive_stranger_message"`
+	ReceiveMentionNotify   *bool `json:"receiveMentionNotify" s-m-c:"receive_mention_notify"`
+	AutoWatchComment       *bool `json:"autoWatchComment" s-m-c:"auto_watch_comment"`
 }
 
 func (UserApi) UserInfoUpdateView(c *gin.Context) {
 	req := c.MustGet("bindReq").(UserInfoUpdateReq)
+	if err := sensitive_service.FilterNoReview(req.Nickname, req.Bio); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
 
 	claims, ok := jwts.GetClaimsFromRequest(c)
 	if !ok {
@@ -5,6 +5,7 @@ package user_api
 import (
 	"blogX_server/common/res"
 	"blogX_server/service/redis_service/redis_jwt"
+	"blogX_server/service/user_service"
 	"blogX_server/utils/jwts"
 	"github.com/gin-gonic/gin"
 )
@@ -17,5 +18,10 @@ func (UserApi) UserLogoutView(c *gin.Context) {
 	}
 
 	redis_jwt.BlockJWTToken(token, redis_jwt.UserBlockType)
+	// 登录设备一起下线，refresh token 不能再用
+	claims := jwts.MustGetClaimsFromRequest(c)
+	if claims.SessionID != 0 {
+		_ = user_service.RevokeSession(claims.UserID, claims.SessionID)
+	}
 	res.SuccessWithMsg("注销成功", c)
 }
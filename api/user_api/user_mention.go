@@ -0,0 +1,27 @@
+// Path: ./api/user_api/user_mention.go
+
+package user_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/service/mention_service"
+	"blogX_server/utils/jwts"
+	"github.com/gin-gonic/gin"
+)
+
+type UserMentionReq struct {
+	Key   string `form:"key" binding:"required,max=32"`
+	Limit int    `form:"limit" binding:"max=20"`
+}
+
+// UserMentionView 输入 @ 时联想用户，自己关注的人排在前面
+func (UserApi) UserMentionView(c *gin.Context) {
+	req := c.MustGet("bindReq").(UserMentionReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	list := mention_service.Suggest(claims.UserID, req.Key, req.Limit)
+	res.SuccessWithList(list, len(list), c)
+}
@@ -0,0 +1,129 @@
+// Path: ./api/user_api/user_session.go
+
+package user_api
+
+import (
+	"blogX_server/common/res"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/user_service"
+	"blogX_server/utils/jwts"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// RefreshTokenView 用 refresh token 换新的 token，返回了 refresh token 时替换掉旧的
+func (UserApi) RefreshTokenView(c *gin.Context) {
+	req := c.MustGet("bindReq").(RefreshTokenReq)
+
+	token, err := user_service.Refresh(req.RefreshToken, c)
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	res.SuccessWithData(token, c)
+}
+
+type UserSessionListReq struct {
+	UserID uint `form:"userID"` // 管理员查看其他用户
+}
+
+type UserSessionResponse struct {
+	ID           uint           `json:"id"`
+	CreatedAt    time.Time      `json:"createdAt"` // 登录时间
+	LastActiveAt *time.Time     `json:"lastActiveAt"`
+	ExpiresAt    *time.Time     `json:"expiresAt"`
+	IP           string         `json:"ip"`
+	IPLocation   string         `json:"ipLocation"`
+	UA           string         `json:"ua"`
+	LoginType    enum.LoginType `json:"loginType"`
+	Current      bool           `json:"current"` // 是否当前设备
+}
+
+// UserSessionListView 还在登录中的设备
+func (UserApi) UserSessionListView(c *gin.Context) {
+	req := c.MustGet("bindReq").(UserSessionListReq)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	// 非管理员只能看自己的
+	if claims.Role != enum.AdminRoleType || req.UserID == 0 {
+		req.UserID = claims.UserID
+	}
+
+	_list := user_service.ListSessions(req.UserID)
+	list := make([]UserSessionResponse, 0, len(_list))
+	for _, s := range _list {
+		list = append(list, UserSessionResponse{
+			ID:           s.ID,
+			CreatedAt:    s.CreatedAt,
+			LastActiveAt: s.LastActiveAt,
+			ExpiresAt:    s.ExpiresAt,
+			IP:           s.IP,
+			IPLocation:   s.IPLocation,
+			UA:           s.UA,
+			LoginType:    s.LoginType,
+			Current:      s.ID == claims.SessionID,
+		})
+	}
+	res.SuccessWithList(list, len(list), c)
+}
+
+// UserSessionRemoveView 下线自己的一个设备
+func (UserApi) UserSessionRemoveView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	if err := user_service.RevokeSession(claims.UserID, req.ID); err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	if user, err := claims.GetUserFromClaims(); err == nil {
+		log_service.NewSecurityLog(*user, "下线设备", fmt.Sprintf("设备 %d", req.ID), true, c)
+	}
+	res.SuccessWithMsg("设备已下线", c)
+}
+
+// UserSessionRemoveOthersView 下线除了当前设备之外的所有设备
+func (UserApi) UserSessionRemoveOthersView(c *gin.Context) {
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	count, err := user_service.RevokeOtherSessions(claims.UserID, claims.SessionID)
+	if err != nil {
+		res.Fail(err, "下线失败", c)
+		return
+	}
+	if user, err := claims.GetUserFromClaims(); err == nil && count > 0 {
+		log_service.NewSecurityLog(*user, "下线其他设备", fmt.Sprintf("共 %d 个", count), true, c)
+	}
+	res.SuccessWithMsg(fmt.Sprintf("已下线 %d 个设备", count), c)
+}
+
+// UserSessionRemoveAllView 管理员下线用户的所有设备，用户要重新登录
+func (UserApi) UserSessionRemoveAllView(c *gin.Context) {
+	req := c.MustGet("bindReq").(models.IDRequest)
+	claims := jwts.MustGetClaimsFromRequest(c)
+
+	var user models.UserModel
+	if err := global.DB.Take(&user, req.ID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if err := user_service.LogoutAll(user.ID); err != nil {
+		res.Fail(err, "下线失败", c)
+		return
+	}
+
+	log := log_service.GetActionLog(c)
+	log.ShowAll()
+	log.SetTitle("下线用户所有设备")
+	log.SetItem("用户", fmt.Sprintf("%d %s", user.ID, user.Username))
+	log_service.NewSecurityLog(user, "管理员下线所有设备", "操作人 "+claims.Username, true, c)
+	res.SuccessWithMsg("已下线该用户的所有设备", c)
+}
@@ -0,0 +1,18 @@
+// Path: ./common/tag_query.go
+
+package common
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// tagLikeEscaper 转义 LIKE 中的通配符，转义字符用 !，mysql 和 sqlite 都不需要额外处理
+var tagLikeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// TagQuery 按标签筛选文章，标签在库中是逗号拼接的，只匹配完整的一项
+func TagQuery(query *gorm.DB, tag string) *gorm.DB {
+	t := tagLikeEscaper.Replace(tag)
+	return query.Where("(tags = ? or tags LIKE ? ESCAPE '!' or tags LIKE ? ESCAPE '!' or tags LIKE ? ESCAPE '!')",
+		tag, t+",%", "%,"+t, "%,"+t+",%")
+}
@@ -0,0 +1,47 @@
+// Path: ./common/tag_query_test.go
+
+package common
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/ctype"
+	"blogX_server/utils/testutil"
+	"fmt"
+	"testing"
+)
+
+func TestTagQuery(t *testing.T) {
+	testutil.Setup(t, &models.ArticleModel{}, &models.TextModel{})
+	for i, tags := range []ctype.List{
+		{"go"},
+		{"go", "mysql"},
+		{"docker", "go", "k8s"},
+		{"golang"},
+		{"django"},
+		{"100%"},
+		{"a_b"},
+		{"axb"},
+	} {
+		global.DB.Create(&models.ArticleModel{Title: fmt.Sprint(i + 1), Content: "x", Tags: tags})
+	}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"go", "[1 2 3]"},
+		{"mysql", "[2]"},
+		{"k8s", "[3]"},
+		{"%", "[]"},
+		{"100%", "[6]"},
+		{"a_b", "[7]"},
+	}
+	for _, tt := range tests {
+		var idList []uint
+		TagQuery(global.DB.Model(&models.ArticleModel{}), tt.tag).Order("id").Pluck("id", &idList)
+		if got := fmt.Sprint(idList); got != tt.want {
+			t.Errorf("TagQuery(%q) = %s, want %s", tt.tag, got, tt.want)
+		}
+	}
+}
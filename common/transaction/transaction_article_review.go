@@ -0,0 +1,130 @@
+// Path: ./common/transaction/transaction_article_review.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ReviewArticleTx 提交一轮审核的结果，同时修改文章状态，version 为审核员看过的版本号
+// 用条件更新保证同一轮只会被审核一次，审核期间作者又修改了的不能提交，以免发布没有审核过的内容
+func ReviewArticleTx(r *models.ArticleReviewModel, a *models.ArticleModel, version int, status enum.ReviewStatus, reason string, reviewerID uint) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.ArticleReviewModel{}).
+			Where("id = ? and status = ? and version = ?", r.ID, enum.ReviewStatusPending, version).
+			Updates(map[string]any{
+				"status":      status,
+				"reason":      reason,
+				"reviewer_id": reviewerID,
+				"reviewed_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var cur models.ArticleReviewModel
+			if err := tx.Take(&cur, r.ID).Error; err == nil && cur.Status == enum.ReviewStatusPending {
+				return errors.New("审核期间文章已被修改，请重新认领后审核")
+			}
+			return errors.New("该轮审核已结束")
+		}
+		return tx.Model(a).Update("status", status.ArticleStatus()).Error
+	})
+}
+
+// ClaimArticleReview 认领一轮审核，未认领、认领已超时或者自己认领的（刷新认领时间）都可以认领
+// 用条件更新保证并发时只有一人成功，返回 false 表示已被其他审核员认领
+// 同时记下当前的版本号，审核时只能提交这个版本
+func ClaimArticleReview(reviewID, reviewerID uint, expiry time.Duration) (ok bool, err error) {
+	now := time.Now()
+	result := global.DB.Model(&models.ArticleReviewModel{}).
+		Where("id = ? and status = ?", reviewID, enum.ReviewStatusPending).
+		Where("reviewer_id is null or reviewer_id = ? or claimed_at < ?", reviewerID, now.Add(-expiry)).
+		Updates(map[string]any{
+			"reviewer_id":     reviewerID,
+			"claimed_at":      now,
+			"claimed_version": gorm.Expr("version"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ResubmitArticleTx 作者把被退回或拒绝的文章重新提交审核，开启新的一轮
+func ResubmitArticleTx(a *models.ArticleModel, submitterID uint, note string) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(a).Update("status", enum.ArticleStatusReview).Error; err != nil {
+			return err
+		}
+		return syncReviewRound(tx, a.ID, submitterID, note)
+	})
+}
+
+// SyncArticleReviewTx 不经过修订事务修改了文章状态时（如定时发布）单独同步审核轮次
+func SyncArticleReviewTx(articleID, submitterID uint, note string) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		return syncReviewRound(tx, articleID, submitterID, note)
+	})
+}
+
+// syncReviewRound 文章保存后，在事务中同步审核轮次
+// 文章待审核时：没有进行中的一轮就开启新的一轮，有的话更新为最新的版本号（审核期间作者又修改了）
+// 文章不再待审核时（如转为草稿）：进行中的一轮记为撤回
+func syncReviewRound(tx *gorm.DB, articleID, submitterID uint, note string) error {
+	var a models.ArticleModel
+	if err := tx.Select("id", "status").Take(&a, articleID).Error; err != nil {
+		return err
+	}
+
+	var open models.ArticleReviewModel
+	err := tx.Take(&open, "article_id = ? and status = ?", articleID, enum.ReviewStatusPending).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	hasOpen := err == nil
+
+	if a.Status != enum.ArticleStatusReview {
+		if !hasOpen {
+			return nil
+		}
+		return tx.Model(&open).Update("status", enum.ReviewStatusWithdraw).Error
+	}
+
+	var version int
+	err = tx.Model(&models.ArticleRevisionModel{}).
+		Where("article_id = ?", articleID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error
+	if err != nil {
+		return err
+	}
+
+	if hasOpen {
+		m := map[string]any{"version": version}
+		if note != "" {
+			m["submit_note"] = note
+		}
+		return tx.Model(&open).Updates(m).Error
+	}
+
+	var maxRound int
+	err = tx.Model(&models.ArticleReviewModel{}).
+		Where("article_id = ?", articleID).
+		Select("COALESCE(MAX(round), 0)").
+		Scan(&maxRound).Error
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.ArticleReviewModel{
+		ArticleID:   articleID,
+		Round:       maxRound + 1,
+		SubmitterID: submitterID,
+		SubmitNote:  note,
+		Version:     version,
+		Status:      enum.ReviewStatusPending,
+	}).Error
+}
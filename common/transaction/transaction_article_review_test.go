@@ -0,0 +1,207 @@
+// Path: ./common/transaction/transaction_article_review_test.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testClaimExpiry = 30 * time.Minute
+
+// setupReview 一篇待审核的文章，并开启第一轮审核
+func setupReview(t *testing.T) (models.ArticleModel, models.ArticleReviewModel) {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.ArticleRevisionModel{},
+		&models.ArticleReviewModel{},
+	)
+	global.DB.Create(&models.UserModel{Username: "author"})
+	a := models.ArticleModel{Title: "a", Content: "x", UserID: 1, Status: enum.ArticleStatusReview}
+	global.DB.Create(&a)
+	global.DB.Create(&models.ArticleRevisionModel{ArticleID: a.ID, Version: 1, Title: "a", Content: "x"})
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	return a, pendingRound(t, a.ID)
+}
+
+func pendingRound(t *testing.T, aid uint) (r models.ArticleReviewModel) {
+	t.Helper()
+	if err := global.DB.Take(&r, "article_id = ? and status = ?", aid, enum.ReviewStatusPending).Error; err != nil {
+		t.Fatalf("没有待审核的一轮: %v", err)
+	}
+	return
+}
+
+func TestClaimArticleReviewRace(t *testing.T) {
+	_, r := setupReview(t)
+
+	var wg sync.WaitGroup
+	var success int32
+	for i := uint(10); i < 20; i++ {
+		wg.Add(1)
+		go func(reviewerID uint) {
+			defer wg.Done()
+			ok, err := ClaimArticleReview(r.ID, reviewerID, testClaimExpiry)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				atomic.AddInt32(&success, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if success != 1 {
+		t.Errorf("同时认领成功 %d 次，want 1", success)
+	}
+}
+
+func TestClaimArticleReviewExpiry(t *testing.T) {
+	_, r := setupReview(t)
+
+	if ok, _ := ClaimArticleReview(r.ID, 10, testClaimExpiry); !ok {
+		t.Fatal("未认领的应该可以认领")
+	}
+	if ok, _ := ClaimArticleReview(r.ID, 11, testClaimExpiry); ok {
+		t.Fatal("认领期间其他人不能认领")
+	}
+	if ok, _ := ClaimArticleReview(r.ID, 10, testClaimExpiry); !ok {
+		t.Fatal("自己认领的可以刷新认领时间")
+	}
+
+	global.DB.Model(&r).Update("claimed_at", time.Now().Add(-testClaimExpiry-time.Minute))
+	if ok, _ := ClaimArticleReview(r.ID, 11, testClaimExpiry); !ok {
+		t.Fatal("认领超时后其他人可以认领")
+	}
+	r = pendingRound(t, r.ArticleID)
+	if r.ReviewerID == nil || *r.ReviewerID != 11 || r.ClaimExpired(testClaimExpiry) {
+		t.Errorf("reviewerID = %v, claimedAt = %v", r.ReviewerID, r.ClaimedAt)
+	}
+
+	global.DB.Model(&r).Update("status", enum.ReviewStatusApproved)
+	if ok, _ := ClaimArticleReview(r.ID, 11, testClaimExpiry); ok {
+		t.Error("已结束的一轮不能认领")
+	}
+}
+
+func TestResubmitRound(t *testing.T) {
+	a, r := setupReview(t)
+	if r.Round != 1 || r.Version != 1 {
+		t.Fatalf("第一轮 round = %d, version = %d", r.Round, r.Version)
+	}
+
+	// 审核期间作者又修改了，还是同一轮，版本号更新
+	global.DB.Create(&models.ArticleRevisionModel{ArticleID: a.ID, Version: 2, Title: "a", Content: "y"})
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	r = pendingRound(t, a.ID)
+	if r.Round != 1 || r.Version != 2 {
+		t.Errorf("审核期间修改后 round = %d, version = %d", r.Round, r.Version)
+	}
+
+	if err := ReviewArticleTx(&r, &a, r.Version, enum.ReviewStatusChanges, "再改改", 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReviewArticleTx(&r, &a, r.Version, enum.ReviewStatusApproved, "", 11); err == nil {
+		t.Error("同一轮不能审核两次")
+	}
+	global.DB.Take(&a, a.ID)
+	if a.Status != enum.ArticleStatusRevise {
+		t.Fatalf("退回修改后文章 status = %d", a.Status)
+	}
+
+	if err := ResubmitArticleTx(&a, a.UserID, "改好了"); err != nil {
+		t.Fatal(err)
+	}
+	r = pendingRound(t, a.ID)
+	if r.Round != 2 || r.SubmitNote != "改好了" {
+		t.Errorf("重新提交后 round = %d, note = %q", r.Round, r.SubmitNote)
+	}
+	var count int64
+	global.DB.Model(&models.ArticleReviewModel{}).Where("article_id = ?", a.ID).Count(&count)
+	if count != 2 {
+		t.Errorf("审核记录 %d 条，want 2", count)
+	}
+}
+
+func TestSyncReviewRoundWithdraw(t *testing.T) {
+	a, r := setupReview(t)
+
+	// 审核期间转为草稿
+	global.DB.Model(&a).Update("status", enum.ArticleStatusDraft)
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	global.DB.Take(&r, r.ID)
+	if r.Status != enum.ReviewStatusWithdraw {
+		t.Errorf("转为草稿后本轮 status = %d, want %d", r.Status, enum.ReviewStatusWithdraw)
+	}
+
+	// 没有进行中的一轮时什么也不做
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	global.DB.Model(&models.ArticleReviewModel{}).Where("article_id = ?", a.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("审核记录 %d 条，want 1", count)
+	}
+
+	// 再次提交开启新的一轮
+	global.DB.Model(&a).Update("status", enum.ArticleStatusReview)
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if r = pendingRound(t, a.ID); r.Round != 2 {
+		t.Errorf("撤回后再提交 round = %d, want 2", r.Round)
+	}
+}
+
+func TestReviewEditedAfterClaim(t *testing.T) {
+	a, r := setupReview(t)
+
+	if ok, _ := ClaimArticleReview(r.ID, 10, testClaimExpiry); !ok {
+		t.Fatal("认领失败")
+	}
+	r = pendingRound(t, a.ID)
+	if r.ClaimedVersion != 1 {
+		t.Fatalf("认领时的版本 = %d, want 1", r.ClaimedVersion)
+	}
+
+	// 认领之后作者又修改了
+	global.DB.Create(&models.ArticleRevisionModel{ArticleID: a.ID, Version: 2, Title: "a", Content: "没有审核过的内容"})
+	if err := SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReviewArticleTx(&r, &a, r.ClaimedVersion, enum.ReviewStatusApproved, "", 10); err == nil {
+		t.Fatal("认领后文章被修改，不能按认领时的版本通过")
+	}
+	global.DB.Take(&a, a.ID)
+	if a.Status != enum.ArticleStatusReview {
+		t.Fatalf("没有通过时文章 status = %d", a.Status)
+	}
+
+	// 重新认领后审核的是新的版本
+	if ok, _ := ClaimArticleReview(r.ID, 10, testClaimExpiry); !ok {
+		t.Fatal("重新认领失败")
+	}
+	r = pendingRound(t, a.ID)
+	if err := ReviewArticleTx(&r, &a, r.ClaimedVersion, enum.ReviewStatusApproved, "", 10); err != nil {
+		t.Fatalf("重新认领后审核 err = %v", err)
+	}
+	global.DB.Take(&a, a.ID)
+	if a.Status != enum.ArticleStatusPublish {
+		t.Errorf("通过后文章 status = %d", a.Status)
+	}
+}
@@ -0,0 +1,91 @@
+// Path: ./common/transaction/transaction_article_revision_test.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"testing"
+)
+
+// setupRestore 一篇已发布的文章，版本 1 是草稿时保存的内容，版本 2 是发布的内容
+func setupRestore(t *testing.T, status enum.ArticleStatus) (models.ArticleModel, models.ArticleRevisionModel) {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.ArticleRevisionModel{},
+		&models.ArticleReviewModel{},
+	)
+	global.DB.Create(&models.UserModel{Username: "author"})
+	a := models.ArticleModel{Title: "published", Content: "y", UserID: 1, Status: status}
+	global.DB.Create(&a)
+	draft := models.ArticleRevisionModel{ArticleID: a.ID, Version: 1, Title: "draft", Content: "x"}
+	global.DB.Create(&draft)
+	global.DB.Create(&models.ArticleRevisionModel{ArticleID: a.ID, Version: 2, Title: "published", Content: "y"})
+	return a, draft
+}
+
+func TestRestoreArticleRevisionReview(t *testing.T) {
+	a, draft := setupRestore(t, enum.ArticleStatusPublish)
+
+	rev, err := RestoreArticleRevisionTx(&a, &draft, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev.Version != 3 || rev.RestoreFrom == nil || *rev.RestoreFrom != 1 {
+		t.Errorf("回滚生成的版本 = %d, restoreFrom = %v", rev.Version, rev.RestoreFrom)
+	}
+	var got models.ArticleModel
+	global.DB.Take(&got, a.ID)
+	if got.Status != enum.ArticleStatusReview || got.Title != "draft" {
+		t.Fatalf("需要审核的站点回滚后 status = %d, title = %s", got.Status, got.Title)
+	}
+	if r := pendingRound(t, a.ID); r.Version != 3 {
+		t.Errorf("审核的版本 = %d, want 3", r.Version)
+	}
+}
+
+func TestRestoreArticleRevisionAutoApprove(t *testing.T) {
+	a, draft := setupRestore(t, enum.ArticleStatusPublish)
+	global.Config.Site.Article.AutoApprove = true
+
+	if _, err := RestoreArticleRevisionTx(&a, &draft, true, 1); err != nil {
+		t.Fatal(err)
+	}
+	var got models.ArticleModel
+	global.DB.Take(&got, a.ID)
+	if got.Status != enum.ArticleStatusReview {
+		t.Errorf("命中需要审核的词时 status = %d, want %d", got.Status, enum.ArticleStatusReview)
+	}
+
+	a = got
+	if _, err := RestoreArticleRevisionTx(&a, &draft, false, 1); err != nil {
+		t.Fatal(err)
+	}
+	got = models.ArticleModel{}
+	global.DB.Take(&got, a.ID)
+	if got.Status != enum.ArticleStatusPublish {
+		t.Errorf("免审核时 status = %d, want %d", got.Status, enum.ArticleStatusPublish)
+	}
+}
+
+func TestRestoreArticleRevisionDraft(t *testing.T) {
+	a, draft := setupRestore(t, enum.ArticleStatusDraft)
+
+	if _, err := RestoreArticleRevisionTx(&a, &draft, false, 1); err != nil {
+		t.Fatal(err)
+	}
+	var got models.ArticleModel
+	global.DB.Take(&got, a.ID)
+	if got.Status != enum.ArticleStatusDraft {
+		t.Errorf("草稿回滚后 status = %d, want %d", got.Status, enum.ArticleStatusDraft)
+	}
+	var count int64
+	global.DB.Model(&models.ArticleReviewModel{}).Count(&count)
+	if count != 0 {
+		t.Errorf("草稿回滚不应该开启审核, 审核记录 %d 条", count)
+	}
+}
@@ -0,0 +1,39 @@
+// Path: ./common/transaction/transaction_claim_guest.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"errors"
+	"gorm.io/gorm"
+)
+
+// ClaimGuestCommentsTx 用户注册或绑定邮箱后，把同一邮箱的游客评论转到该用户名下
+// 占位用户保留，但不再有评论；邮箱已被注册后也不能再用这个邮箱游客评论
+func ClaimGuestCommentsTx(email string, userID uint) (claimed int64, err error) {
+	var guest models.UserModel
+	err = global.DB.Take(&guest, "username = ? and register_source = ?",
+		comment_service.GuestUsername(email), enum.RegisterSourceGuestType).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CommentModel{}).Unscoped().Where("user_id = ?", guest.ID).Update("user_id", userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		claimed = result.RowsAffected
+		if err := tx.Model(&models.MentionModel{}).Where("action_user_id = ?", guest.ID).Update("action_user_id", userID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.NotifyModel{}).Where("action_user_id = ?", guest.ID).Update("action_user_id", userID).Error
+	})
+	return
+}
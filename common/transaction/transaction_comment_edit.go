@@ -0,0 +1,47 @@
+// Path: ./common/transaction/transaction_comment_edit.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// EditCommentTx 修改评论内容，修改前的内容存为一个历史版本
+// 以 edit_count 作为条件更新，同时提交的两次修改只有一次生效
+func EditCommentTx(cmt *models.CommentModel, content string, editorID uint) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.CommentModel{}).
+			Where("id = ? and edit_count = ?", cmt.ID, cmt.EditCount).
+			Updates(map[string]any{
+				"content":    content,
+				"edit_count": gorm.Expr("edit_count + 1"),
+				"edited_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("评论已被修改，请刷新后重试")
+		}
+
+		err := tx.Create(&models.CommentEditModel{
+			CommentID: cmt.ID,
+			Version:   cmt.EditCount + 1,
+			EditorID:  editorID,
+			Content:   cmt.Content,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		cmt.Content = content
+		cmt.EditCount++
+		cmt.EditedAt = &now
+		return nil
+	})
+}
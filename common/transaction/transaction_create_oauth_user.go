@@ -0,0 +1,22 @@
+// Path: ./common/transaction/transaction_create_oauth_user.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"gorm.io/gorm"
+)
+
+// CreateOAuthUserTx 第三方登录自动注册，用户、用户配置和绑定的第三方账号一起创建
+// 同一个第三方账号同时回调两次时，只有一次能创建成功
+func CreateOAuthUserTx(u models.UserModel, identity models.UserIdentityModel) (user models.UserModel, err error) {
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := createUserAndUserConfig(tx, &u); err != nil {
+			return err
+		}
+		identity.UserID = u.ID
+		return tx.Create(&identity).Error
+	})
+	return u, err
+}
@@ -13,35 +13,40 @@ import (
 func CreateUserAndUserConfigTx(u models.UserModel) (err error) {
 	// 注意这里是 DBMaster
 	return global.DBMaster.Transaction(func(tx *gorm.DB) (err error) {
-		// 创建 User
-		err = tx.Create(&u).Error
-		if err != nil {
-			return err
-		}
+		return createUserAndUserConfig(tx, &u)
+	})
+}
+
+// createUserAndUserConfig 在事务中创建用户及其配置，创建后 u 中有 id
+func createUserAndUserConfig(tx *gorm.DB, u *models.UserModel) (err error) {
+	// 创建 User
+	err = tx.Create(u).Error
+	if err != nil {
+		return err
+	}
 
-		// 配置 UserConfig
-		userConf := models.UserConfigModel{
-			UserID: u.ID,
-		}
-		// 创建 UserConfig
-		err = tx.Create(&userConf).Error
-		if err != nil {
-			return err
-		}
+	// 配置 UserConfig
+	userConf := models.UserConfigModel{
+		UserID: u.ID,
+	}
+	// 创建 UserConfig
+	err = tx.Create(&userConf).Error
+	if err != nil {
+		return err
+	}
 
-		// 配置 UserMsgConfig
-		msgConf := models.UserMessageConfModel{
-			UserID: u.ID,
-		}
-		// 创建 UserMsgConfig
-		err = tx.Create(&msgConf).Error
-		if err != nil {
-			return err
-		}
+	// 配置 UserMsgConfig
+	msgConf := models.UserMessageConfModel{
+		UserID: u.ID,
+	}
+	// 创建 UserMsgConfig
+	err = tx.Create(&msgConf).Error
+	if err != nil {
+		return err
+	}
 
-		// 成功创建
-		return nil
-	})
+	// 成功创建
+	return nil
 }
 
 // CreateUserAndUserConfig2 不走事务的存储，其实现在有专门的主库变量，现在也用不上了
@@ -0,0 +1,44 @@
+// Path: ./common/transaction/transaction_import_job.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"gorm.io/gorm"
+)
+
+// ImportArticleTx 导入一篇文章及其评论，并在同一个事务中保存导入任务的进度（调用前已更新好 job 的计数），任务中断后续跑不会重复导入
+// comments 中父评论必须排在子评论前面，parents[i] 为第 i 条评论的父评论下标，根评论为 -1
+// 评论的 Depth、ReplyCount 由调用方算好，这里只补上 ArticleID、ParentID、RootID
+func ImportArticleTx(a *models.ArticleModel, comments []models.CommentModel, parents []int, job *models.ImportJobModel) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		// 待审核、未通过的评论不计入评论数
+		a.CommentCount = 0
+		for _, cmt := range comments {
+			if cmt.Status == enum.CommentStatusPublished {
+				a.CommentCount++
+			}
+		}
+		if err := createArticleWithRevision(tx, a, a.UserID, enum.RevisionSourceImport); err != nil {
+			return err
+		}
+		for i := range comments {
+			cmt := &comments[i]
+			cmt.ArticleID = a.ID
+			if p := parents[i]; p >= 0 {
+				parent := &comments[p]
+				cmt.ParentID = &parent.ID
+				cmt.RootID = parent.RootID
+				if cmt.RootID == nil {
+					cmt.RootID = &parent.ID
+				}
+			}
+			if err := tx.Create(cmt).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(job).Select(models.ImportJobProgressColumns).Updates(job).Error
+	})
+}
@@ -0,0 +1,137 @@
+// Path: ./common/transaction/transaction_recycle.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/service/redis_service/redis_article"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/redis_service/redis_comment"
+	"blogX_server/service/series_service"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// SoftRemoveArticleTx 软删除文章，放入回收站
+// 点赞、收藏、评论等关联记录都保留，恢复时不需要重建；text_model 由 BeforeDelete 钩子删除
+func SoftRemoveArticleTx(a *models.ArticleModel, operatorID uint) error {
+	err := global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(a).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.RecycleModel{
+			Type:       enum.RecycleArticleType,
+			TargetID:   a.ID,
+			OwnerID:    a.UserID,
+			OperatorID: operatorID,
+			Title:      a.Title,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	series_service.SyncArticleSeries(a.ID)
+	return nil
+}
+
+// RestoreArticleTx 从回收站恢复文章
+// 清空 deleted_at 会触发 AfterUpdate 钩子重建 text_model，es 中的文档由 river 同步
+func RestoreArticleTx(r *models.RecycleModel) error {
+	var a models.ArticleModel
+	err := global.DB.Unscoped().Take(&a, r.TargetID).Error
+	if err != nil {
+		return errors.New("文章不存在")
+	}
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		a.DeletedAt = gorm.DeletedAt{}
+		if err := tx.Unscoped().Model(&a).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(r).Error
+	})
+	if err != nil {
+		return err
+	}
+	redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+	redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	series_service.SyncArticleSeries(a.ID)
+	return nil
+}
+
+// RestoreCommentTx 从回收站恢复评论，连同当时一起被删除的子评论，并把评论数加回去
+func RestoreCommentTx(r *models.RecycleModel) error {
+	var cmt models.CommentModel
+	err := global.DB.Unscoped().Take(&cmt, r.TargetID).Error
+	if err != nil {
+		return errors.New("评论不存在")
+	}
+	// 文章和父评论都必须还在
+	if err = global.DB.Take(&models.ArticleModel{}, cmt.ArticleID).Error; err != nil {
+		return errors.New("评论所在的文章已被删除，请先恢复文章")
+	}
+	if cmt.ParentID != nil {
+		if err = global.DB.Take(&models.CommentModel{}, *cmt.ParentID).Error; err != nil {
+			return errors.New("父评论已被删除，请先恢复父评论")
+		}
+	}
+
+	idList := append(recycleRelatedIDs(r), cmt.ID)
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.CommentModel{}).Where("id in ?", idList).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(r).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// 评论数加回去，待审核和未通过的评论本来就没有计数
+	var delta int64
+	global.DB.Model(&models.CommentModel{}).Where("id in ? and status = ?", idList, enum.CommentStatusPublished).Count(&delta)
+	if delta == 0 {
+		return nil
+	}
+	if cmt.ParentID != nil {
+		ancestors, err := comment_service.GetAncestors(*cmt.ParentID)
+		if err != nil {
+			return fmt.Errorf("获取父评论失败, Error: %v", err)
+		}
+		for _, ans := range ancestors {
+			redis_comment.UpdateCommentReplyCount(ans.ID, int(delta))
+		}
+	}
+	redis_article.UpdateArticleComment(cmt.ArticleID, int(delta))
+	return nil
+}
+
+// PurgeArticle 彻底删除回收站中的文章
+func PurgeArticle(r *models.RecycleModel) (logs map[string]any, err error) {
+	var a models.ArticleModel
+	err = global.DB.Unscoped().Take(&a, r.TargetID).Error
+	if err != nil {
+		// 文章已经不存在了，只删除回收站记录
+		return nil, global.DB.Delete(r).Error
+	}
+	return RemoveArticleAndRelated(&a)
+}
+
+// PurgeRecycle 按类型彻底删除回收站中的内容
+func PurgeRecycle(r *models.RecycleModel) (err error) {
+	switch r.Type {
+	case enum.RecycleArticleType:
+		_, err = PurgeArticle(r)
+	case enum.RecycleCommentType:
+		err = PurgeComment(r)
+	default:
+		err = global.DB.Delete(r).Error
+	}
+	return
+}
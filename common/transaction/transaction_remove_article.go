@@ -5,11 +5,15 @@ package transaction
 import (
 	"blogX_server/global"
 	"blogX_server/models"
+	"blogX_server/models/enum"
 	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
 	"fmt"
 	"gorm.io/gorm"
 )
 
+// RemoveArticleAndRelated 彻底删除文章及关联记录，回收站中的文章也可以删除
+// 日常删除走 SoftRemoveArticleTx，这里只在清空回收站时调用
 func RemoveArticleAndRelated(a *models.ArticleModel) (logs map[string]any, err error) {
 	var (
 		likes    []models.ArticleLikesModel
@@ -17,6 +21,9 @@ func RemoveArticleAndRelated(a *models.ArticleModel) (logs map[string]any, err e
 		pins     []models.UserPinnedArticleModel
 		history  []models.UserArticleHistoryModel
 		comments []models.CommentModel
+		revs     []models.ArticleRevisionModel
+		reviews  []models.ArticleReviewModel
+		series   []models.SeriesArticleModel
 	)
 
 	err = global.DBMaster.Transaction(func(tx *gorm.DB) (err error) {
@@ -37,17 +44,78 @@ func RemoveArticleAndRelated(a *models.ArticleModel) (logs map[string]any, err e
 			return err
 		}
 		// 评论
-		if err := tx.Where("article_id = ?", a.ID).Find(&comments).Delete(&models.CommentModel{}).Error; err != nil {
+		if err := tx.Unscoped().Where("article_id = ?", a.ID).Find(&comments).Delete(&models.CommentModel{}).Error; err != nil {
 			return err
 		}
+		// 评论的修改历史和表情回应，文章正文和评论中的提及
+		if len(comments) > 0 {
+			var cidList []uint
+			for _, cmt := range comments {
+				cidList = append(cidList, cmt.ID)
+			}
+			if err := tx.Where("comment_id in ?", cidList).Delete(&models.CommentEditModel{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("target_type = ? and target_id in ?", enum.ReactionTargetComment, cidList).Delete(&models.ReactionModel{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("target_type = ? and target_id in ?", enum.ReactionTargetComment, cidList).Delete(&models.ReactionCountModel{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("article_id = ?", a.ID).Delete(&models.MentionModel{}).Error; err != nil {
+			return err
+		}
+		// 文章和评论的表情回应
+		if err := tx.Where("target_type = ? and target_id = ?", enum.ReactionTargetArticle, a.ID).Delete(&models.ReactionModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? and target_id = ?", enum.ReactionTargetArticle, a.ID).Delete(&models.ReactionCountModel{}).Error; err != nil {
+			return err
+		}
+		// 评论区的关注
+		if err := tx.Where("article_id = ?", a.ID).Delete(&models.CommentWatchModel{}).Error; err != nil {
+			return err
+		}
+		// 修订历史
+		if err := tx.Where("article_id = ?", a.ID).Find(&revs).Delete(&models.ArticleRevisionModel{}).Error; err != nil {
+			return err
+		}
+		// 审核记录
+		if err := tx.Where("article_id = ?", a.ID).Find(&reviews).Delete(&models.ArticleReviewModel{}).Error; err != nil {
+			return err
+		}
+		// 系列
+		if err := tx.Where("article_id = ?", a.ID).Find(&series).Delete(&models.SeriesArticleModel{}).Error; err != nil {
+			return err
+		}
+		// 回收站记录（文章本身，以及文章下被删除的评论）
+		if err := tx.Where("type = ? and target_id = ?", enum.RecycleArticleType, a.ID).Delete(&models.RecycleModel{}).Error; err != nil {
+			return err
+		}
+		if len(comments) > 0 {
+			var cidList []uint
+			for _, cmt := range comments {
+				cidList = append(cidList, cmt.ID)
+			}
+			if err := tx.Where("type = ? and target_id in ?", enum.RecycleCommentType, cidList).Delete(&models.RecycleModel{}).Error; err != nil {
+				return err
+			}
+		}
 		// 文章本体
-		if err := tx.Delete(a).Error; err != nil {
+		if err := tx.Unscoped().Delete(a).Error; err != nil {
 			return err
 		}
 
 		// 缓存的文章详情
 		redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
 
+		// 修订里是整篇文章的内容，只记录 id
+		var revIDList []uint
+		for _, r := range revs {
+			revIDList = append(revIDList, r.ID)
+		}
+
 		logs = map[string]any{
 			fmt.Sprintf("删除文章 %d", a.ID):              a,
 			fmt.Sprintf("删除关联点赞 %d 条", len(likes)):    likes,
@@ -55,8 +123,18 @@ func RemoveArticleAndRelated(a *models.ArticleModel) (logs map[string]any, err e
 			fmt.Sprintf("删除关联置顶 %d 条", len(pins)):     pins,
 			fmt.Sprintf("删除关联阅读 %d 条", len(history)):  history,
 			fmt.Sprintf("删除关联评论 %d 条", len(comments)): comments,
+			fmt.Sprintf("删除关联修订 %d 条", len(revs)):     revIDList,
+			fmt.Sprintf("删除关联审核 %d 条", len(reviews)):  reviews,
+			fmt.Sprintf("移出系列 %d 个", len(series)):     series,
 		}
 		return nil
 	})
+	if err != nil {
+		return
+	}
+	// 事务提交后再统计系列的文章数，同系列文章的上一篇、下一篇也会变化
+	for _, sa := range series {
+		series_service.SyncSeries(sa.SeriesID)
+	}
 	return
 }
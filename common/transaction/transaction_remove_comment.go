@@ -5,24 +5,33 @@ package transaction
 import (
 	"blogX_server/global"
 	"blogX_server/models"
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
 	"blogX_server/service/comment_service"
 	"blogX_server/service/redis_service/redis_article"
 	"blogX_server/service/redis_service/redis_comment"
+	"blogX_server/utils"
 	"fmt"
 	"gorm.io/gorm"
+	"strconv"
 )
 
-func RemoveComment(cmt *models.CommentModel) error {
+// RemoveComment 软删除评论及其子评论，放入回收站
+func RemoveComment(cmt *models.CommentModel, operatorID uint) error {
 	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
 		// 删除子评论
 		offsprings, err := comment_service.GetOffsprings(&cmt.ID)
 		if err != nil {
 			return fmt.Errorf("查询子评论失败, Error: %v", err)
 		}
+		var related ctype.List
 		if len(offsprings) > 0 {
 			if err = tx.Delete(&offsprings).Error; err != nil {
 				return fmt.Errorf("删除子评论失败, Error: %v", err)
 			}
+			for _, o := range offsprings {
+				related = append(related, strconv.Itoa(int(o.ID)))
+			}
 		}
 
 		// 删除本体
@@ -31,23 +40,102 @@ func RemoveComment(cmt *models.CommentModel) error {
 			return fmt.Errorf("删除评论失败, Error: %v", err)
 		}
 
-		// 取当前缓存评论数
-		// 如果缓存中没有（已经备份到 db），则会返回 0，也没问题
-		currentReplyCount := redis_comment.GetCommentReplyCount(cmt.ID) + cmt.ReplyCount
+		// 回收站记录
+		err = tx.Create(&models.RecycleModel{
+			Type:       enum.RecycleCommentType,
+			TargetID:   cmt.ID,
+			OwnerID:    cmt.UserID,
+			OperatorID: operatorID,
+			Title:      utils.ExtractContent(cmt.Content, 64),
+			RelatedIDs: related,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("回收站记录失败, Error: %v", err)
+		}
 
-		// 更新祖先评论评论数
-		if cmt.ParentID != nil {
-			ancestors, err := comment_service.GetAncestors(*cmt.ParentID)
-			if err != nil {
-				return fmt.Errorf("获取父评论失败, Error: %v", err)
-			}
-			for _, ans := range ancestors {
-				redis_comment.UpdateCommentReplyCount(ans.ID, -currentReplyCount-1)
-			}
+		// 待审核和未通过的评论没有计入评论数，也不会有子评论
+		if cmt.Status != enum.CommentStatusPublished {
+			return nil
+		}
+
+		return subPublishedCount(cmt)
+	})
+}
+
+// HideCommentTx 隐藏已发布的评论：设为未通过，子评论随之不再展示，评论数扣除整棵子树
+func HideCommentTx(cmt *models.CommentModel, reason string) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(cmt).Where("status = ?", enum.CommentStatusPublished).Updates(map[string]any{
+			"status":        enum.CommentStatusRejected,
+			"reject_reason": reason,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		// 已经不是已发布状态的，评论数之前没有计入
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return subPublishedCount(cmt)
+	})
+}
+
+// subPublishedCount 已发布的评论被删除或隐藏后，祖先评论的回复数和文章的评论数扣除整棵子树
+func subPublishedCount(cmt *models.CommentModel) error {
+	// 取当前缓存评论数
+	// 如果缓存中没有（已经备份到 db），则会返回 0，也没问题
+	currentReplyCount := redis_comment.GetCommentReplyCount(cmt.ID) + cmt.ReplyCount
+
+	// 更新祖先评论评论数
+	if cmt.ParentID != nil {
+		ancestors, err := comment_service.GetAncestors(*cmt.ParentID)
+		if err != nil {
+			return fmt.Errorf("获取父评论失败, Error: %v", err)
 		}
+		for _, ans := range ancestors {
+			redis_comment.UpdateCommentReplyCount(ans.ID, -currentReplyCount-1)
+		}
+	}
+
+	// 更新文章评论数
+	redis_article.UpdateArticleComment(cmt.ArticleID, -currentReplyCount-1)
+	return nil
+}
 
-		// 更新文章评论数
-		redis_article.UpdateArticleComment(cmt.ArticleID, -currentReplyCount-1)
-		return nil
+// PurgeComment 彻底删除回收站中的评论，连同一起被删除的子评论、点赞记录、修改历史、提及记录和表情回应
+func PurgeComment(r *models.RecycleModel) error {
+	idList := append(recycleRelatedIDs(r), r.TargetID)
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id in ?", idList).Delete(&models.CommentLikesModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id in ?", idList).Delete(&models.CommentEditModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id in ?", idList).Delete(&models.MentionModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? and target_id in ?", enum.ReactionTargetComment, idList).Delete(&models.ReactionModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? and target_id in ?", enum.ReactionTargetComment, idList).Delete(&models.ReactionCountModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("id in ?", idList).Delete(&models.CommentModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(r).Error
 	})
 }
+
+// recycleRelatedIDs 把回收站记录中的 RelatedIDs 转换为 uint
+func recycleRelatedIDs(r *models.RecycleModel) (idList []uint) {
+	for _, s := range r.RelatedIDs {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			continue
+		}
+		idList = append(idList, uint(id))
+	}
+	return
+}
@@ -0,0 +1,59 @@
+// Path: ./common/transaction/transaction_series.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"gorm.io/gorm"
+)
+
+// AddArticleToSeriesTx 把文章加到系列的末尾
+// 系列的文章数只算已发布的，由调用方用 series_service.SyncSeries 统计
+func AddArticleToSeriesTx(s *models.SeriesModel, aid uint) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		var maxRank int
+		err := tx.Model(&models.SeriesArticleModel{}).
+			Where("series_id = ?", s.ID).
+			Select("COALESCE(MAX(`rank`), 0)").
+			Scan(&maxRank).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.SeriesArticleModel{
+			SeriesID:  s.ID,
+			ArticleID: aid,
+			Rank:      maxRank + 1,
+		}).Error
+	})
+}
+
+// RemoveArticleFromSeriesTx 把文章移出系列，其余文章的顺序不变
+func RemoveArticleFromSeriesTx(sa *models.SeriesArticleModel) error {
+	return global.DB.Delete(sa).Error
+}
+
+// SortSeriesTx 按传入的文章 id 顺序重排系列
+func SortSeriesTx(seriesID uint, articleIDList []uint) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		for i, aid := range articleIDList {
+			err := tx.Model(&models.SeriesArticleModel{}).
+				Where("series_id = ? and article_id = ?", seriesID, aid).
+				Update("rank", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveSeries 删除系列，文章本身不受影响
+func RemoveSeries(s *models.SeriesModel) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", s.ID).Delete(&models.SeriesArticleModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(s).Error
+	})
+}
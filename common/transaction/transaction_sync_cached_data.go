@@ -31,7 +31,8 @@ func SyncCommentTx(commentList []models.CommentModel, maps map[string]map[uint]i
 			}
 
 			// 写入数据库
-			err := tx.Model(&cmt).Updates(updateMap).Error
+			// 回收站中的评论也要同步，恢复后计数才正确
+			err := tx.Unscoped().Model(&cmt).Updates(updateMap).Error
 			if err != nil {
 				return fmt.Errorf("update comment[%d] error: %v", cmt.ID, err)
 			}
@@ -70,7 +71,8 @@ func SyncArticleTx(articleList []models.ArticleModel, maps map[string]map[uint]i
 			}
 
 			// 写入数据库
-			err := tx.Model(&article).Updates(updateMap).Error
+			// 回收站中的文章也要同步，恢复后计数才正确
+			err := tx.Unscoped().Model(&article).Updates(updateMap).Error
 			if err != nil {
 				return fmt.Errorf("update article[%d] error: %v", article.ID, err)
 			}
@@ -0,0 +1,35 @@
+// Path: ./common/transaction/transaction_sync_reaction.go
+
+package transaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/redis_service/redis_reaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SyncReactionTx 把 redis 中的表情回应增量累加到 ReactionCountModel，没有记录的新建
+func SyncReactionTx(deltas map[redis_reaction.Key]int) error {
+	list := make([]models.ReactionCountModel, 0, len(deltas))
+	for k, d := range deltas {
+		if d == 0 {
+			continue
+		}
+		list = append(list, models.ReactionCountModel{
+			TargetType: k.TargetType,
+			TargetID:   k.TargetID,
+			Emoji:      k.Emoji,
+			Count:      d,
+		})
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{"count": gorm.Expr("GREATEST(count + VALUES(count), 0)")}),
+		}).CreateInBatches(&list, 200).Error
+	})
+}
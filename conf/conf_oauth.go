@@ -0,0 +1,36 @@
+// Path: ./conf/conf_oauth.go
+
+package conf
+
+// OAuth QQ 之外的第三方登录，每个服务一项
+type OAuth struct {
+	Providers []OAuthProvider `yaml:"providers" json:"providers"`
+}
+
+// OAuthProvider 一个 OAuth2 / OIDC 登录服务
+// Kind 为 github、gitee 时端点、scope 和字段映射有默认值，只需填 client id 和 secret
+// Kind 为 oidc 时配置 Issuer 即可从 /.well-known/openid-configuration 发现端点，也可以手动填写
+type OAuthProvider struct {
+	Name         string            `yaml:"name" json:"name"`   // 路由中的标识，小写字母和数字，如 github、company
+	Label        string            `yaml:"label" json:"label"` // 登录按钮上显示的名称
+	Kind         string            `yaml:"kind" json:"kind"`   // github gitee oidc
+	Enable       bool              `yaml:"enable" json:"enable"`
+	ClientID     string            `yaml:"clientID" json:"clientID"`
+	ClientSecret string            `yaml:"clientSecret" json:"clientSecret"`
+	Issuer       string            `yaml:"issuer" json:"issuer"` // oidc 的 issuer
+	AuthURL      string            `yaml:"authURL" json:"authURL"`
+	TokenURL     string            `yaml:"tokenURL" json:"tokenURL"`
+	UserInfoURL  string            `yaml:"userInfoURL" json:"userInfoURL"`
+	Scopes       []string          `yaml:"scopes" json:"scopes"`
+	Redirect     string            `yaml:"redirect" json:"redirect"` // 回调地址，前端页面拿到 code 和 state 后调用回调接口
+	Claims       OAuthClaimMapping `yaml:"claims" json:"claims"`
+}
+
+// OAuthClaimMapping 用户信息中各字段的名称，不填用 Kind 的默认值
+type OAuthClaimMapping struct {
+	ID       string `yaml:"id" json:"id"` // 唯一标识，oidc 为 sub，github 为 id
+	Username string `yaml:"username" json:"username"`
+	Nickname string `yaml:"nickname" json:"nickname"`
+	Email    string `yaml:"email" json:"email"`
+	Avatar   string `yaml:"avatar" json:"avatar"`
+}
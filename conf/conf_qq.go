@@ -10,6 +10,7 @@ type QQ struct {
 	Redirect string `yaml:"redirect" json:"redirect"` // 回调地址
 }
 
-func (q QQ) Url() string {
-	return fmt.Sprintf("https://graph.qq.com/oauth2.0/show?which=Login&display=pc&response_type=code&client_id=%s&redirect_uri=%s", q.AppID, q.Redirect)
+// Url 跳转 QQ 授权的地址，state 会原样带回回调地址
+func (q QQ) Url(state string) string {
+	return fmt.Sprintf("https://graph.qq.com/oauth2.0/show?which=Login&display=pc&response_type=code&client_id=%s&redirect_uri=%s&state=%s", q.AppID, q.Redirect, state)
 }
@@ -11,4 +11,31 @@ type Redis struct {
 	CommentSyncTime  string `yaml:"commentSyncTime"`  // 同步时间 eg. "0 0 2 * * *" 秒 分 小时 日 月 周
 	SiteDataSyncTime string `yaml:"siteDataSyncTime"` // 同步时间 eg. "0 0 2 * * *" 秒 分 小时 日 月 周
 	UserDataSyncTime string `yaml:"userDataSyncTime"` // 同步时间 eg. "0 0 2 * * *" 秒 分 小时 日 月 周
+	ArticlePubTime   string `yaml:"articlePubTime"`   // 定时发布检查时间 eg. "0 * * * * *" 每分钟
+	RecyclePurgeTime string `yaml:"recyclePurgeTime"` // 回收站清理时间 eg. "0 0 2 * * *" 秒 分 小时 日 月 周
+	ReactionSyncTime string `yaml:"reactionSyncTime"` // 表情回应数同步时间 eg. "0 */10 * * * *" 每 10 分钟
+}
+
+// ArticlePubSpec 定时发布检查时间，没有配置时每分钟检查一次
+func (r Redis) ArticlePubSpec() string {
+	if r.ArticlePubTime == "" {
+		return "0 * * * * *"
+	}
+	return r.ArticlePubTime
+}
+
+// RecyclePurgeSpec 回收站清理时间，没有配置时每天 2 点清理
+func (r Redis) RecyclePurgeSpec() string {
+	if r.RecyclePurgeTime == "" {
+		return "0 0 2 * * *"
+	}
+	return r.RecyclePurgeTime
+}
+
+// ReactionSyncSpec 表情回应数同步时间，没有配置时每 10 分钟同步一次
+func (r Redis) ReactionSyncSpec() string {
+	if r.ReactionSyncTime == "" {
+		return "0 */10 * * * *"
+	}
+	return r.ReactionSyncTime
 }
@@ -2,7 +2,10 @@
 
 package conf
 
-import "blogX_server/conf/site"
+import (
+	"blogX_server/conf/site"
+	"strings"
+)
 
 // Site 站点设置
 type Site struct {
@@ -15,3 +18,15 @@ type Site struct {
 	Article    site.Article    `yaml:"article" json:"article"`
 	AutoGen    site.AutoGen    `yaml:"autoGen" json:"autoGen"`
 }
+
+// BaseURL 站点对外的访问地址（不带结尾的 /）
+// 优先用 Seo.SiteURL，没有配置时如果 Project.WebPath 是网址也可以用
+func (s Site) BaseURL() string {
+	if s.Seo.SiteURL != "" {
+		return strings.TrimSuffix(s.Seo.SiteURL, "/")
+	}
+	if strings.HasPrefix(s.Project.WebPath, "http") {
+		return strings.TrimSuffix(s.Project.WebPath, "/")
+	}
+	return ""
+}
@@ -6,4 +6,5 @@ type Upload struct {
 	ImageSizeLimit     int      `yaml:"imageSizeLimit"`
 	ValidImageSuffixes []string `yaml:"validImageSuffixes"`
 	ImageDir           string   `yaml:"imageDir"`
+	ArchiveSizeLimit   int      `yaml:"archiveSizeLimit"` // 导入文章的文件（压缩包、WordPress 的 WXR）大小限制，单位 MB
 }
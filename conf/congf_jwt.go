@@ -2,8 +2,27 @@
 
 package conf
 
+import "time"
+
 type Jwt struct {
-	Expire int    `yaml:"expire"` // 单位：小时
-	Secret string `yaml:"secret"`
-	Issuer string `yaml:"issuer"`
+	Expire        int    `yaml:"expire"`        // 登录有效期，单位：小时，期间用 refresh token 换新的 token，不用重新登录
+	AccessMinutes int    `yaml:"accessMinutes"` // token 有效期，单位：分钟，默认 30
+	Secret        string `yaml:"secret"`
+	Issuer        string `yaml:"issuer"`
+}
+
+// AccessExpiry token 的有效期，过期后用 refresh token 换新的
+func (j Jwt) AccessExpiry() time.Duration {
+	if j.AccessMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(j.AccessMinutes) * time.Minute
+}
+
+// SessionExpiry 登录设备的有效期，超过这个时间没有刷新过 token 的要重新登录
+func (j Jwt) SessionExpiry() time.Duration {
+	if j.Expire <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(j.Expire) * time.Hour
 }
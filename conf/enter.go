@@ -20,6 +20,7 @@ type Config struct {
 	Ai     Ai     `yaml:"ai"`
 	Cloud  Cloud  `yaml:"cloud"`
 	QQ     QQ     `yaml:"qq"`
+	OAuth  OAuth  `yaml:"oauth"` // QQ 之外的第三方登录
 	Email  Email  `yaml:"email"`
 	Upload Upload `yaml:"upload"`
 }
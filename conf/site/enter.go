@@ -2,6 +2,11 @@
 
 package site
 
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
 // SiteInfo 网站设置
 type SiteInfo struct {
 	Title        string `yaml:"title" json:"title"`                   // 标题
@@ -24,6 +29,15 @@ type Project struct {
 type Seo struct {
 	Keywords    string `yaml:"keywords" json:"keywords"`
 	Description string `yaml:"description" json:"description"`
+	SiteURL     string `yaml:"siteURL" json:"siteURL"` // 站点对外的访问地址，如 https://blog.example.com，订阅源等需要绝对链接
+	Robots      Robots `yaml:"robots" json:"robots"`
+}
+
+// Robots robots.txt 的生成规则
+type Robots struct {
+	DisallowAll bool     `yaml:"disallowAll" json:"disallowAll"` // 禁止所有爬虫，如测试站
+	Disallow    []string `yaml:"disallow" json:"disallow"`       // 禁止爬取的路径，如 /admin
+	CrawlDelay  int      `yaml:"crawlDelay" json:"crawlDelay"`   // 爬取间隔，单位秒，0 为不限制
 }
 
 // About 关于我们
@@ -43,6 +57,7 @@ type Login struct {
 	UsernamePwdLogin bool `yaml:"usernamePwdLogin" json:"usernamePwdLogin"` // 用户名密码登录
 	EmailRegister    bool `yaml:"emailRegister" json:"emailRegister"`       // 邮箱登录
 	Captcha          bool `yaml:"captcha" json:"captcha"`                   // 图片验证码
+	ForceAdmin2FA    bool `yaml:"forceAdmin2FA" json:"forceAdmin2FA"`       // 管理员必须开启两步验证，没开启的登录时先绑定
 }
 
 // IndexRight 右边栏设置
@@ -61,6 +76,59 @@ type Article struct {
 	AutoApprove  bool `yaml:"autoApprove" json:"autoApprove"`   // 免审核
 	MaxPin       int  `yaml:"maxPin" json:"maxPin"`             // 用户最高置顶数
 	CommentDepth int  `yaml:"commentDepth" json:"commentDepth"` // 评论的层级
+	RecycleDays  int  `yaml:"recycleDays" json:"recycleDays"`   // 回收站保留天数，到期彻底删除
+	ClaimMinutes int  `yaml:"claimMinutes" json:"claimMinutes"` // 审核员认领文章后的有效时间，超时未审核其他审核员可以重新认领
+	EditMinutes  int  `yaml:"editMinutes" json:"editMinutes"`   // 评论发布后多久内允许作者修改，管理员不受限制
+
+	CommentModeration enum.CommentModeration `yaml:"commentModeration" json:"commentModeration" binding:"oneof=0 1 2 3"` // 评论先审后发：1 关闭 2 新用户或含链接的评论 3 全部，文章可以单独设置
+	NewAccountDays    int                    `yaml:"newAccountDays" json:"newAccountDays"`                               // 注册不满多少天的算新用户，默认 3 天
+	MaxCommentPin     int                    `yaml:"maxCommentPin" json:"maxCommentPin"`                                 // 每篇文章最多置顶的评论数，默认 3 条
+	Reactions         []string               `yaml:"reactions" json:"reactions"`                                         // 文章和评论可用的表情回应
+}
+
+// ClaimExpiry 审核认领的有效时间，没有配置时为 1 小时
+func (a Article) ClaimExpiry() time.Duration {
+	if a.ClaimMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(a.ClaimMinutes) * time.Minute
+}
+
+// CommentEditWindow 评论可以修改的时间，没有配置时为 30 分钟
+func (a Article) CommentEditWindow() time.Duration {
+	if a.EditMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(a.EditMinutes) * time.Minute
+}
+
+// CommentPinLimit 每篇文章最多置顶的评论数，没有配置时为 3 条
+func (a Article) CommentPinLimit() int {
+	if a.MaxCommentPin <= 0 {
+		return 3
+	}
+	return a.MaxCommentPin
+}
+
+// defaultReactions 没有配置表情回应时使用
+var defaultReactions = []string{"👍", "❤️", "😄", "🎉", "😕", "👀"}
+
+// ReactionList 可用的表情回应，没有配置时使用默认的一组
+func (a Article) ReactionList() []string {
+	if len(a.Reactions) == 0 {
+		return defaultReactions
+	}
+	return a.Reactions
+}
+
+// IsReaction 是否为可用的表情回应
+func (a Article) IsReaction(emoji string) bool {
+	for _, r := range a.ReactionList() {
+		if r == emoji {
+			return true
+		}
+	}
+	return false
 }
 
 type AutoGen struct {
@@ -44,6 +44,22 @@ type Options struct {
 	// Sub 针对哪个子类进行操作
 	// 可以通过 -s 参数控制
 	Sub string
+
+	// UserID 操作哪个用户，如导入导出文章
+	// 可以通过 -u 参数控制
+	UserID uint
+
+	// Path 导入导出的文件路径
+	// 可以通过 -p 参数控制
+	Path string
+
+	// Format 导出文章的格式，hexo 或 hugo
+	// 可以通过 -format 参数控制，默认为 hexo
+	Format string
+
+	// Incremental 导出静态网站时，只重新生成上次导出之后修改过的文章
+	// 可以通过 -incr 参数控制
+	Incremental bool
 }
 
 // FlagOptions 是一个全局变量，用于存储解析后的命令行参数值
@@ -63,6 +79,9 @@ var FlagOptions = new(Options)
 // 查看版本：./program -v
 // 组合使用：./program -f custom-config.yaml -db
 // 命令行创建用户：./program -t user -s create （可用于远程部署后创建一个管理员）
+// 导入文章：./program -t article -s import -u 1 -p blog.zip
+// 导出文章：./program -t article -s export -u 1 -format hugo -p backup.zip
+// 导出静态网站：./program -t static -s export -p ./public （增量导出加 -incr）
 func Parse() {
 	// 定义 -f 参数，用于指定配置文件路径
 	// 当用户未指定时，默认使用 "settings.yaml" 作为配置文件
@@ -84,6 +103,10 @@ func Parse() {
 
 	flag.StringVar(&FlagOptions.Type, "t", "", "type")
 	flag.StringVar(&FlagOptions.Sub, "s", "", "subtype")
+	flag.UintVar(&FlagOptions.UserID, "u", 0, "user id")
+	flag.StringVar(&FlagOptions.Path, "p", "", "file path")
+	flag.StringVar(&FlagOptions.Format, "format", "hexo", "article export format: hexo / hugo")
+	flag.BoolVar(&FlagOptions.Incremental, "incr", false, "incremental static site export")
 
 	// 解析命令行参数
 	// 这个调用会处理所有通过命令行传入的参数
@@ -116,5 +139,22 @@ func Run() {
 			u.Create()
 			os.Exit(0)
 		}
+	case "article":
+		a := FlagArticle{}
+		switch FlagOptions.Sub {
+		case "import":
+			a.Import()
+			os.Exit(0)
+		case "export":
+			a.Export()
+			os.Exit(0)
+		}
+	case "static":
+		st := FlagStatic{}
+		switch FlagOptions.Sub {
+		case "export":
+			st.Export()
+			os.Exit(0)
+		}
 	}
 }
@@ -0,0 +1,94 @@
+// Path: ./flags/flag_article.go
+
+package flags
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/archive_service"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"time"
+)
+
+type FlagArticle struct{}
+
+// Import 从 Hexo / Hugo 的文章压缩包导入，和接口导入的流程一致
+// 使用示例：./program -t article -s import -u 1 -p blog.zip
+func (FlagArticle) Import() {
+	u, ok := flagArticleUser()
+	if !ok {
+		return
+	}
+	if FlagOptions.Path == "" {
+		fmt.Println("请用 -p 指定压缩包路径")
+		return
+	}
+	byteData, err := os.ReadFile(FlagOptions.Path)
+	if err != nil {
+		fmt.Println("读取文件失败:", err)
+		return
+	}
+
+	list, err := archive_service.Import(byteData, u.ID)
+	if err != nil {
+		fmt.Println("导入失败:", err)
+		return
+	}
+	count := map[archive_service.ImportStatus]int{}
+	for _, r := range list {
+		count[r.Status]++
+		line := fmt.Sprintf("[%s] %s -> %s", r.Status, r.File, r.Title)
+		if r.ArticleID != 0 {
+			line += fmt.Sprintf(" (ID:%d, 图片 %d)", r.ArticleID, r.Images)
+		}
+		if r.Msg != "" {
+			line += " " + r.Msg
+		}
+		fmt.Println(line)
+	}
+	logrus.Infof("用户 %s 导入文章完成: 成功 %d, 跳过 %d, 失败 %d", u.Username,
+		count[archive_service.ImportStatusCreated], count[archive_service.ImportStatusSkipped], count[archive_service.ImportStatusFailed])
+}
+
+// Export 导出用户的文章，-format 为 hexo 或 hugo，不指定 -p 时输出到当前目录
+// 使用示例：./program -t article -s export -u 1 -format hugo -p backup.zip
+func (FlagArticle) Export() {
+	u, ok := flagArticleUser()
+	if !ok {
+		return
+	}
+	format := archive_service.Format(FlagOptions.Format)
+	if format != archive_service.FormatHexo && format != archive_service.FormatHugo {
+		fmt.Println("-format 只能是 hexo 或 hugo")
+		return
+	}
+
+	byteData, err := archive_service.Export(u.ID, format)
+	if err != nil {
+		fmt.Println("导出失败:", err)
+		return
+	}
+	path := FlagOptions.Path
+	if path == "" {
+		path = fmt.Sprintf("blogx_%d_%s_%s.zip", u.ID, format, time.Now().Format("20060102150405"))
+	}
+	if err = os.WriteFile(path, byteData, 0644); err != nil {
+		fmt.Println("写入文件失败:", err)
+		return
+	}
+	logrus.Infof("用户 %s 的文章已导出到 %s", u.Username, path)
+}
+
+func flagArticleUser() (u models.UserModel, ok bool) {
+	if FlagOptions.UserID == 0 {
+		fmt.Println("请用 -u 指定用户 id")
+		return
+	}
+	if err := global.DB.Take(&u, FlagOptions.UserID).Error; err != nil {
+		fmt.Println("用户不存在")
+		return
+	}
+	return u, true
+}
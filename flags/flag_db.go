@@ -40,6 +40,23 @@ func FlagDB() {
 		&models.TextModel{},
 		&models.DataModel{},
 		&models.UserFocusModel{},
+		&models.ArticleRevisionModel{},
+		&models.ArticleReviewModel{},
+		&models.SeriesModel{},
+		&models.SeriesArticleModel{},
+		&models.RecycleModel{},
+		&models.ImportJobModel{},
+		&models.SensitiveWordModel{},
+		&models.CommentEditModel{},
+		&models.MentionModel{},
+		&models.ReportModel{},
+		&models.CommentWatchModel{},
+		&models.ReactionModel{},
+		&models.ReactionCountModel{},
+		&models.UserIdentityModel{},
+		&models.UserTwoFactorModel{},
+		&models.UserRecoveryCodeModel{},
+		&models.AccessTokenModel{},
 	)
 	if err != nil {
 		logrus.Errorf("failed to migrate DB: %s\n", err)
@@ -0,0 +1,27 @@
+// Path: ./flags/flag_static.go
+
+package flags
+
+import (
+	"blogX_server/service/static_service"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type FlagStatic struct{}
+
+// Export 把公开的内容导出为静态网站，-incr 时只重新生成上次导出之后修改过的文章
+// 使用示例：./program -t static -s export -p ./public -incr
+func (FlagStatic) Export() {
+	dir := FlagOptions.Path
+	if dir == "" {
+		dir = "static_site"
+	}
+	r, err := static_service.Export(dir, FlagOptions.Incremental)
+	if err != nil {
+		fmt.Println("导出失败:", err)
+		return
+	}
+	logrus.Infof("静态网站已导出到 %s: 文章 %d 篇（未变化 %d 篇，删除 %d 篇），页面 %d 个，图片 %d 张",
+		dir, r.Articles, r.Unchanged, r.Removed, r.Pages, r.Images)
+}
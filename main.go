@@ -8,6 +8,8 @@ import (
 	"blogX_server/global"
 	"blogX_server/router"
 	"blogX_server/service/cron_service"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/service/wordpress_service"
 )
 
 func main() {
@@ -20,6 +22,8 @@ func main() {
 	global.ESClient = core.InitES()            // 连接 es
 	flags.Run()                                // 命令行操作: 数据库迁移 ES建索引等
 	core.InitMysqlES()                         // es 开启同步（协程）
+	sensitive_service.Init()                   // 加载敏感词
 	cron_service.Cron()                        // 定时任务（协程）
+	wordpress_service.ResumeJobs()             // 继续执行中断的导入任务（协程）
 	router.Run()                               // 启动 web 服务
 }
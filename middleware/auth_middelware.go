@@ -6,6 +6,7 @@ import (
 	"blogX_server/common/res"
 	"blogX_server/global"
 	"blogX_server/models/enum"
+	"blogX_server/service/access_token_service"
 	"blogX_server/service/redis_service/redis_jwt"
 	"blogX_server/utils/jwts"
 	"fmt"
@@ -21,8 +22,9 @@ func AuthMiddleware(c *gin.Context) {
 	c.Set("claims", claims)
 }
 
+// AdminMiddleware 管理员，不接受访问令牌
 func AdminMiddleware(c *gin.Context) {
-	claims, ok := getValidClaims(c)
+	claims, ok := GetSessionClaims(c)
 	if !ok {
 		c.Abort()
 		return
@@ -35,8 +37,27 @@ func AdminMiddleware(c *gin.Context) {
 	c.Set("claims", claims)
 }
 
+// ReviewerMiddleware 审核员或管理员，不接受访问令牌
+func ReviewerMiddleware(c *gin.Context) {
+	claims, ok := GetSessionClaims(c)
+	if !ok {
+		c.Abort()
+		return
+	}
+	if claims.Role != enum.AdminRoleType && claims.Role != enum.ReviewerRoleType {
+		res.FailWithMsg("权限不足 (Not reviewer)", c)
+		c.Abort()
+		return
+	}
+	c.Set("claims", claims)
+}
+
 // getValidClaims extracts and validates JWT claims from the request, returning them if valid or responding with failure on error.
 func getValidClaims(c *gin.Context) (claims *jwts.MyClaims, ok bool) {
+	if token, _ := jwts.GetTokenFromRequest(c); access_token_service.IsAccessToken(token) {
+		return getAccessTokenClaims(c, token)
+	}
+
 	claims, err := jwts.ParseTokenFromRequest(c)
 	if err != nil {
 		res.FailWithError(err, c)
@@ -54,6 +75,37 @@ func getValidClaims(c *gin.Context) (claims *jwts.MyClaims, ok bool) {
 	return claims, true
 }
 
+// GetSessionClaims 和 AuthMiddleware 的校验相同，但不接受访问令牌
+// 用于只在部分情况下需要登录的接口，校验失败时已经返回了错误
+func GetSessionClaims(c *gin.Context) (claims *jwts.MyClaims, ok bool) {
+	if token, _ := jwts.GetTokenFromRequest(c); access_token_service.IsAccessToken(token) {
+		res.FailWithMsg("访问令牌不能用于这个接口", c)
+		return
+	}
+	return getValidClaims(c)
+}
+
+// getAccessTokenClaims 用个人访问令牌登录，只能访问令牌权限范围内的接口
+// 令牌只有普通用户的权限，管理员和审核员的令牌也不能访问管理的接口
+func getAccessTokenClaims(c *gin.Context, token string) (claims *jwts.MyClaims, ok bool) {
+	t, user, err := access_token_service.Verify(token, c.ClientIP())
+	if err != nil {
+		res.FailWithError(err, c)
+		return
+	}
+	required, _ := c.Value("scope").(enum.AccessScope)
+	if !access_token_service.Allow(t.Scopes, c.Request.Method, required) {
+		res.FailWithMsg("访问令牌没有这个接口的权限", c)
+		return
+	}
+	claims = &jwts.MyClaims{Claims: jwts.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     enum.UserRoleType,
+	}}
+	return claims, true
+}
+
 // isExpiredToken checks if a token is expired by comparing its issued time with the password update timestamp in Redis.
 func isExpiredToken(claims *jwts.MyClaims) bool {
 	key := fmt.Sprintf("%dpassword_update", claims.UserID)
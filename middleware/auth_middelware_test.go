@@ -0,0 +1,65 @@
+// Path: ./middleware/auth_middelware_test.go
+
+package mdw
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/user_service"
+	"blogX_server/utils/testutil"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newContext(header, token string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/user/detail", nil)
+	// 内网地址不用查 ip 库
+	c.Request.RemoteAddr = "127.0.0.1:1234"
+	if token != "" {
+		c.Request.Header.Set(header, token)
+	}
+	return c
+}
+
+func TestAuthMiddlewareRevokedSession(t *testing.T) {
+	testutil.Setup(t, &models.UserModel{}, &models.UserLoginModel{})
+	global.Config.Jwt.Secret = "test-secret"
+	user := models.UserModel{Username: "user", Email: "user@example.com", Role: enum.UserRoleType}
+	global.DB.Create(&user)
+	login, err := user_service.NewSession(user, enum.UsernamePasswordLoginType, newContext("", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := []struct {
+		header string
+		token  string
+	}{
+		{"token", login.Token},
+		{"Authorization", "Bearer " + login.Token},
+	}
+	for _, r := range requests {
+		c := newContext(r.header, r.token)
+		AuthMiddleware(c)
+		if c.IsAborted() {
+			t.Fatalf("%s 头带的有效 token 应该通过", r.header)
+		}
+	}
+
+	var s models.UserLoginModel
+	global.DB.Take(&s)
+	if err = user_service.RevokeSession(user.ID, s.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range requests {
+		c := newContext(r.header, r.token)
+		AuthMiddleware(c)
+		if !c.IsAborted() {
+			t.Errorf("%s 头带的已下线设备的 token 应该被拒绝", r.header)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"blogX_server/service/redis_service/redis_cache"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"net/http"
 	"net/url"
 )
 
@@ -56,8 +57,20 @@ func CacheMiddleware(option redis_cache.CacheOption) gin.HandlerFunc {
 
 			//fmt.Println("!!!!!!!!!!!!!!!!!!!!!走缓存了")
 
-			// 设置响应头为 JSON
-			c.Header("Content-Type", "application/json; charset=utf-8")
+			// 设置响应头，默认为 JSON
+			contentType := "application/json; charset=utf-8"
+			if option.ContentType != "" {
+				contentType = option.ContentType
+			}
+			c.Header("Content-Type", contentType)
+
+			// 条件请求，客户端缓存有效就直接 304
+			if option.ETag {
+				lastModified, _ := global.Redis.Get(key + redis_cache.CacheLastModifiedSuffix).Result()
+				if redis_cache.NotModified(c, redis_cache.ETag(val), lastModified) {
+					return
+				}
+			}
 
 			// 直接返回缓存内容
 			c.Writer.Write([]byte(val))
@@ -77,7 +90,17 @@ func CacheMiddleware(option redis_cache.CacheOption) gin.HandlerFunc {
 		// 拿到 handler 的响应体（已被拦截保存在 w.Body 中）
 		body := string(w.Body)
 
+		// 304 或者出错的响应不缓存
+		if w.Status() != http.StatusOK || body == "" {
+			return
+		}
+
 		// 将响应内容写入 Redis 缓存，供后续相同请求使用
 		redis_cache.CacheOpen(key, body, option.Expiry)
+		if option.ETag {
+			if lastModified := w.Header().Get("Last-Modified"); lastModified != "" {
+				redis_cache.CacheOpen(key+redis_cache.CacheLastModifiedSuffix, lastModified, option.Expiry)
+			}
+		}
 	}
 }
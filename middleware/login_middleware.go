@@ -15,3 +15,11 @@ func UsernamePwdLoginMiddleware(c *gin.Context) {
 		return
 	}
 }
+
+func QQLoginMiddleware(c *gin.Context) {
+	if !global.Config.Site.Login.QQLogin {
+		res.FailWithMsg("站点未开启QQ登录", c)
+		c.Abort()
+		return
+	}
+}
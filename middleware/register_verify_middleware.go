@@ -4,6 +4,7 @@ package mdw
 
 import (
 	"blogX_server/common/res"
+	"blogX_server/service/sensitive_service"
 	"blogX_server/utils/user"
 	"bytes"
 	"github.com/gin-gonic/gin"
@@ -41,6 +42,13 @@ func RegisterVerifyMiddleware(c *gin.Context) {
 		return
 	}
 
+	// 用户名不能打码，命中任何敏感词都不允许注册
+	if err = sensitive_service.Validate(req.Username); err != nil {
+		res.FailWithMsg("用户名"+err.Error(), c)
+		c.Abort()
+		return
+	}
+
 	// 判断 username 是否重复
 	msg, ok = user.IsAvailableUsername(req.Username)
 	if !ok {
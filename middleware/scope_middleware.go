@@ -0,0 +1,15 @@
+// Path: ./middleware/scope_middleware.go
+
+package mdw
+
+import (
+	"blogX_server/models/enum"
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeMiddleware 声明接口需要的访问令牌权限，放在 AuthMiddleware 前面；用 jwt 登录的不受影响
+func ScopeMiddleware(scope enum.AccessScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("scope", scope)
+	}
+}
@@ -0,0 +1,25 @@
+// Path: ./models/access_token_model.go
+
+package models
+
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
+// AccessTokenModel 个人访问令牌，给脚本和 CI 使用，只保存哈希
+type AccessTokenModel struct {
+	Model
+	UserID     uint               `gorm:"not null;index" json:"userID"`
+	Name       string             `gorm:"size:64;not null" json:"name"`
+	Prefix     string             `gorm:"size:16" json:"prefix"` // 令牌的开头几位，列表里用来辨认
+	TokenHash  string             `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Scopes     []enum.AccessScope `gorm:"type:longtext; serializer:json" json:"scopes"`
+	ExpiresAt  *time.Time         `json:"expiresAt"` // nil 为永不过期
+	LastUsedAt *time.Time         `json:"lastUsedAt"`
+	LastUsedIP string             `gorm:"size:32" json:"lastUsedIP"`
+	RevokedAt  *time.Time         `json:"revokedAt"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
@@ -6,6 +6,7 @@ import (
 	"blogX_server/service/text_service"
 	_ "embed"
 	"gorm.io/gorm"
+	"time"
 )
 
 type ArticleModel struct {
@@ -17,14 +18,20 @@ type ArticleModel struct {
 	CategoryID     *uint              `gorm:"index" json:"categoryID"`      // 自定义分类
 	Tags           ctype.List         `gorm:"type:longtext" json:"tags"`    // 标签
 	UserID         uint               `gorm:"index;not null" json:"userID"` // 发布者
-	Status         enum.ArticleStatus `json:"status"`                       // 草稿 审核中 已发布
+	Status         enum.ArticleStatus `json:"status"`                       // 草稿 审核中 已发布 定时发布
+	PublishAt      *time.Time         `gorm:"index" json:"publishAt"`       // 定时发布的时间，只在定时发布状态下有值
 	ReadCount      int                `gorm:"not null; default:0" json:"readCount"`
 	LikeCount      int                `gorm:"not null; default:0" json:"likeCount"`
 	CommentCount   int                `gorm:"not null; default:0" json:"commentCount"`
 	CollectCount   int                `gorm:"not null; default:0" json:"collectCount"`
 	OpenForComment bool               `gorm:"not null; default:true" json:"openForComment"`
-	PinnedByUser   bool               `gorm:"not null; default:false" json:"pinnedByUser"`
-	PinnedByAdmin  bool               `gorm:"not null; default:false" json:"pinnedByAdmin"`
+	// OpenForGuestComment 博客模式下允许游客不登录评论，游客评论都需要审核
+	OpenForGuestComment bool `gorm:"not null; default:false" json:"openForGuestComment"`
+	// CommentModeration 评论先审后发，0 跟随站点设置
+	CommentModeration enum.CommentModeration `gorm:"not null; default:0" json:"commentModeration"`
+	PinnedByUser      bool                   `gorm:"not null; default:false" json:"pinnedByUser"`
+	PinnedByAdmin     bool                   `gorm:"not null; default:false" json:"pinnedByAdmin"`
+	DeletedAt         gorm.DeletedAt         `gorm:"index" json:"-"` // 软删除，在回收站中的文章
 
 	// FK
 	UserModel     UserModel      `gorm:"foreignKey:UserID;references:ID" json:"-"`
@@ -47,8 +54,8 @@ func (ArticleModel) GetIndex() string {
 
 // AfterCreate 在创建后把文章内容按照 md 格式拆分为小标题+文字的形式，并且入 text_model 库
 func (a *ArticleModel) AfterCreate(tx *gorm.DB) (err error) {
-	// 已发布的文章才进行
-	if a.Status != enum.ArticleStatusPublish {
+	// 已发布且不在回收站中的文章才进行
+	if a.Status != enum.ArticleStatusPublish || a.DeletedAt.Valid {
 		return
 	}
 
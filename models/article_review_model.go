@@ -0,0 +1,35 @@
+// Path: ./models/article_review_model.go
+
+package models
+
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
+// ArticleReviewModel 文章审核记录，每次提交审核为一轮
+// 同一篇文章同时最多只有一轮处于待审核状态，Round 从 1 开始递增
+type ArticleReviewModel struct {
+	Model
+	ArticleID      uint              `gorm:"not null;uniqueIndex:idx_uniq_article_review" json:"articleID"`
+	Round          int               `gorm:"not null;uniqueIndex:idx_uniq_article_review" json:"round"`
+	SubmitterID    uint              `gorm:"not null" json:"submitterID"`  // 提交人，一般是作者
+	SubmitNote     string            `gorm:"size:256" json:"submitNote"`   // 作者重新提交时的修改说明
+	Version        int               `gorm:"not null" json:"version"`      // 提交时文章的修订版本号，可以和上一轮对比
+	Status         enum.ReviewStatus `gorm:"index;not null" json:"status"` // 待审核 通过 拒绝 退回修改 撤回
+	ReviewerID     *uint             `gorm:"index" json:"reviewerID"`      // 认领人 / 审核人
+	ClaimedAt      *time.Time        `json:"claimedAt"`                    // 认领时间，超时后可被他人认领
+	ClaimedVersion int               `json:"claimedVersion"`               // 认领时的版本号，认领后作者又修改了要重新认领
+	Reason         string            `gorm:"size:1024" json:"reason"`      // 审核意见
+	ReviewedAt     *time.Time        `json:"reviewedAt"`
+
+	// FK
+	ArticleModel   ArticleModel `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
+	SubmitterModel UserModel    `gorm:"foreignKey:SubmitterID;references:ID" json:"-"`
+	ReviewerModel  *UserModel   `gorm:"foreignKey:ReviewerID;references:ID" json:"-"`
+}
+
+// ClaimExpired 认领是否已经超时
+func (r ArticleReviewModel) ClaimExpired(expiry time.Duration) bool {
+	return r.ClaimedAt == nil || time.Since(*r.ClaimedAt) > expiry
+}
@@ -0,0 +1,46 @@
+// Path: ./models/article_revision_model.go
+
+package models
+
+import (
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
+)
+
+// ArticleRevisionModel 文章修订历史，每次保存文章都会生成一条快照
+// 同一篇文章的 Version 从 1 开始递增
+type ArticleRevisionModel struct {
+	Model
+	ArticleID   uint                    `gorm:"not null;uniqueIndex:idx_uniq_article_revision" json:"articleID"`
+	Version     int                     `gorm:"not null;uniqueIndex:idx_uniq_article_revision" json:"version"`
+	EditorID    uint                    `gorm:"not null" json:"editorID"` // 本次保存的操作人（作者或管理员）
+	Source      enum.RevisionSourceType `gorm:"not null" json:"source"`   // 创建 修改 自动发布 回滚
+	RestoreFrom *int                    `json:"restoreFrom"`              // 回滚时，来源的版本号
+	Title       string                  `gorm:"size:128; not null" json:"title"`
+	Abstract    string                  `gorm:"size:256" json:"abstract"`
+	CoverURL    string                  `gorm:"size:256" json:"coverURL"`
+	Content     string                  `gorm:"not null" json:"content"`
+	CategoryID  *uint                   `json:"categoryID"`
+	Tags        ctype.List              `gorm:"type:longtext" json:"tags"`
+	Status      enum.ArticleStatus      `json:"status"`
+
+	// FK
+	ArticleModel ArticleModel `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
+	EditorModel  UserModel    `gorm:"foreignKey:EditorID;references:ID" json:"-"`
+}
+
+// NewArticleRevision 根据文章当前的内容生成一条快照（版本号在事务中确定）
+func NewArticleRevision(a *ArticleModel, editorID uint, source enum.RevisionSourceType) ArticleRevisionModel {
+	return ArticleRevisionModel{
+		ArticleID:  a.ID,
+		EditorID:   editorID,
+		Source:     source,
+		Title:      a.Title,
+		Abstract:   a.Abstract,
+		CoverURL:   a.CoverURL,
+		Content:    a.Content,
+		CategoryID: a.CategoryID,
+		Tags:       a.Tags,
+		Status:     a.Status,
+	}
+}
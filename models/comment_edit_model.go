@@ -0,0 +1,17 @@
+// Path: ./models/comment_edit_model.go
+
+package models
+
+// CommentEditModel 评论的修改历史，每次修改前把旧内容存一份
+// Version 从 1 开始，第 n 次修改前的内容就是第 n 个版本
+type CommentEditModel struct {
+	Model
+	CommentID uint   `gorm:"not null;uniqueIndex:idx_uniq_comment_edit" json:"commentID"`
+	Version   int    `gorm:"not null;uniqueIndex:idx_uniq_comment_edit" json:"version"`
+	EditorID  uint   `gorm:"not null" json:"editorID"` // 本次修改的操作人（作者或管理员）
+	Content   string `gorm:"not null" json:"content"`  // 修改前的内容
+
+	// FK
+	CommentModel CommentModel `gorm:"foreignKey:CommentID;references:ID" json:"-"`
+	EditorModel  UserModel    `gorm:"foreignKey:EditorID;references:ID" json:"-"`
+}
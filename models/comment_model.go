@@ -1,5 +1,11 @@
 package models
 
+import (
+	"blogX_server/models/enum"
+	"gorm.io/gorm"
+	"time"
+)
+
 type CommentModel struct {
 	Model
 	Content    string `gorm:"not null" json:"content"`
@@ -11,6 +17,17 @@ type CommentModel struct {
 	ReplyCount int    `gorm:"not null; default:0" json:"replyCount"` // 所有子孙回复数
 	LikeCount  int    `gorm:"not null; default:0" json:"likeCount"`  // 点赞数
 
+	Status       enum.CommentStatus `gorm:"not null; default:1; index" json:"status"` // 已发布 待审核 未通过
+	RejectReason string             `gorm:"size:256" json:"rejectReason"`             // 未通过审核的原因
+
+	EditCount int        `gorm:"not null; default:0" json:"editCount"` // 修改次数，历史版本在 CommentEditModel
+	EditedAt  *time.Time `json:"editedAt"`                             // 最后一次修改的时间，没有修改过为 nil
+
+	PinnedAt    *time.Time `gorm:"index" json:"pinnedAt"`                      // 文章作者置顶的时间，没有置顶为 nil，只有根评论可以置顶
+	Highlighted bool       `gorm:"not null; default:false" json:"highlighted"` // 文章作者标记的精选回答
+
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // 软删除，在回收站中的评论
+
 	// FK
 	UserModel      UserModel       `gorm:"foreignKey:UserID;references:ID" json:"-"`
 	ArticleModel   ArticleModel    `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
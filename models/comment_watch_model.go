@@ -0,0 +1,19 @@
+// Path: ./models/comment_watch_model.go
+
+package models
+
+// CommentWatchModel 关注文章的评论区或某个根评论下的讨论，有新评论时提醒
+// RootID 为 0 表示关注整篇文章的评论区
+// 取消关注不删除记录而是设为 Muted，之后再评论也不会被自动关注回来
+type CommentWatchModel struct {
+	Model
+	UserID    uint `gorm:"not null;uniqueIndex:idx_uniq_comment_watch" json:"userID"`
+	ArticleID uint `gorm:"not null;uniqueIndex:idx_uniq_comment_watch;index" json:"articleID"`
+	RootID    uint `gorm:"not null;uniqueIndex:idx_uniq_comment_watch" json:"rootID"`
+	Auto      bool `gorm:"not null; default:false" json:"auto"`  // 评论时自动关注的
+	Muted     bool `gorm:"not null; default:false" json:"muted"` // 已取消关注
+
+	// FK
+	UserModel    UserModel    `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	ArticleModel ArticleModel `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
+}
@@ -0,0 +1,30 @@
+// Path: ./models/enum/access_scope.go
+
+package enum
+
+// AccessScope 访问令牌的权限范围
+type AccessScope string
+
+const (
+	ScopeRead         AccessScope = "read"          // 只读，可以访问所有 GET 接口
+	ScopeArticleWrite AccessScope = "article:write" // 发布、修改、导入文章
+	ScopeImageUpload  AccessScope = "image:upload"  // 上传图片
+	ScopeCommentWrite AccessScope = "comment:write" // 发布、修改评论
+)
+
+// AccessScopeList 所有可选的权限，创建令牌时校验
+var AccessScopeList = []AccessScope{ScopeRead, ScopeArticleWrite, ScopeImageUpload, ScopeCommentWrite}
+
+func (s AccessScope) String() string {
+	switch s {
+	case ScopeRead:
+		return "只读"
+	case ScopeArticleWrite:
+		return "发布文章"
+	case ScopeImageUpload:
+		return "上传图片"
+	case ScopeCommentWrite:
+		return "发布评论"
+	}
+	return ""
+}
@@ -5,8 +5,10 @@ package enum
 type ArticleStatus uint8
 
 const (
-	ArticleStatusDraft   ArticleStatus = 1
-	ArticleStatusReview  ArticleStatus = 2
-	ArticleStatusPublish ArticleStatus = 3
-	AritcleStatusFail    ArticleStatus = 4
+	ArticleStatusDraft    ArticleStatus = 1
+	ArticleStatusReview   ArticleStatus = 2
+	ArticleStatusPublish  ArticleStatus = 3
+	AritcleStatusFail     ArticleStatus = 4
+	ArticleStatusSchedule ArticleStatus = 5 // 定时发布，到点后进入审核或直接发布
+	ArticleStatusRevise   ArticleStatus = 6 // 退回修改，作者修改后可以重新提交审核
 )
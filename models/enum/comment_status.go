@@ -0,0 +1,49 @@
+// Path: ./models/enum/comment_status.go
+
+package enum
+
+// CommentStatus 评论的审核状态，只有已发布的评论会展示并计入评论数
+type CommentStatus uint8
+
+const (
+	CommentStatusPublished CommentStatus = 1 // 已发布
+	CommentStatusPending   CommentStatus = 2 // 待审核
+	CommentStatusRejected  CommentStatus = 3 // 未通过
+)
+
+func (s CommentStatus) String() string {
+	switch s {
+	case CommentStatusPublished:
+		return "已发布"
+	case CommentStatusPending:
+		return "待审核"
+	case CommentStatusRejected:
+		return "未通过"
+	}
+	return ""
+}
+
+// CommentModeration 评论的先审后发模式
+// 站点设置中 0 和 1 都是关闭；文章设置中 0 表示跟随站点设置
+type CommentModeration uint8
+
+const (
+	CommentModerationInherit CommentModeration = 0 // 跟随站点设置
+	CommentModerationOff     CommentModeration = 1 // 不审核，直接发布
+	CommentModerationRisky   CommentModeration = 2 // 新注册的用户或含有链接的评论需要审核
+	CommentModerationAll     CommentModeration = 3 // 所有评论都需要审核
+)
+
+func (m CommentModeration) String() string {
+	switch m {
+	case CommentModerationInherit:
+		return "跟随站点设置"
+	case CommentModerationOff:
+		return "不审核"
+	case CommentModerationRisky:
+		return "新用户或含链接的评论需审核"
+	case CommentModerationAll:
+		return "全部审核"
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+// Path: ./models/enum/import_job_status.go
+
+package enum
+
+// ImportJobStatus 后台导入任务的状态
+type ImportJobStatus uint8
+
+const (
+	ImportJobStatusPending ImportJobStatus = 1 // 等待执行
+	ImportJobStatusRunning ImportJobStatus = 2 // 执行中，服务重启后会从断点继续
+	ImportJobStatusDone    ImportJobStatus = 3 // 已完成
+	ImportJobStatusFailed  ImportJobStatus = 4 // 中途出错，可以从断点继续
+)
+
+func (s ImportJobStatus) String() string {
+	switch s {
+	case ImportJobStatusPending:
+		return "等待执行"
+	case ImportJobStatusRunning:
+		return "执行中"
+	case ImportJobStatusDone:
+		return "已完成"
+	case ImportJobStatusFailed:
+		return "失败"
+	}
+	return ""
+}
@@ -7,6 +7,9 @@ const (
 	EmailPasswordLoginType    LoginType = 2
 	QQLoginType               LoginType = 3
 	WechatLoginType           LoginType = 4
+	GithubLoginType           LoginType = 5
+	GiteeLoginType            LoginType = 6
+	OIDCLoginType             LoginType = 7 // 通过配置的 OIDC 服务登录，如公司统一认证
 )
 
 func (l LoginType) String() string {
@@ -19,6 +22,12 @@ func (l LoginType) String() string {
 		return "QQ登录"
 	case WechatLoginType:
 		return "微信登录"
+	case GithubLoginType:
+		return "GitHub登录"
+	case GiteeLoginType:
+		return "Gitee登录"
+	case OIDCLoginType:
+		return "OIDC登录"
 	}
 	return ""
 }
@@ -15,6 +15,13 @@ const (
 	ArticleUnlikeType    Type = 7
 	ArticleUncollectType Type = 8
 	CommentUnlikeType    Type = 9
+
+	MentionType    Type = 10
+	WatchType      Type = 11 // 关注的讨论有新评论
+	CommentPinType Type = 12 // 评论被作者置顶
+
+	ArticleReactionType Type = 13 // 文章收到表情回应
+	CommentReactionType Type = 14 // 评论收到表情回应
 )
 
 func (t Type) String() string {
@@ -31,6 +38,16 @@ func (t Type) String() string {
 		return "评论回复"
 	case SystemType:
 		return "系统消息"
+	case MentionType:
+		return "提及"
+	case WatchType:
+		return "关注的讨论"
+	case CommentPinType:
+		return "评论被置顶"
+	case ArticleReactionType:
+		return "文章表情回应"
+	case CommentReactionType:
+		return "评论表情回应"
 	}
 	return "Unknown"
 }
@@ -0,0 +1,21 @@
+// Path: ./models/enum/reaction.go
+
+package enum
+
+// ReactionTargetType 表情回应的对象
+type ReactionTargetType uint8
+
+const (
+	ReactionTargetArticle ReactionTargetType = 1
+	ReactionTargetComment ReactionTargetType = 2
+)
+
+func (t ReactionTargetType) String() string {
+	switch t {
+	case ReactionTargetArticle:
+		return "文章"
+	case ReactionTargetComment:
+		return "评论"
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+// Path: ./models/enum/recycle_type.go
+
+package enum
+
+type RecycleType uint8
+
+const (
+	RecycleArticleType RecycleType = 1 // 文章
+	RecycleCommentType RecycleType = 2 // 评论
+)
+
+func (r RecycleType) String() string {
+	switch r {
+	case RecycleArticleType:
+		return "文章"
+	case RecycleCommentType:
+		return "评论"
+	}
+	return ""
+}
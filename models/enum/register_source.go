@@ -8,4 +8,11 @@ const (
 	RegisterSourceEmailType    RegisterSourceType = 1
 	RegisterSourceQQType       RegisterSourceType = 2
 	RegisterSourceTerminalType RegisterSourceType = 3
+	// RegisterSourceImportType 导入 WordPress 评论时为评论者创建的占位用户，没有密码，不能登录
+	RegisterSourceImportType RegisterSourceType = 4
+	// RegisterSourceGuestType 游客评论时为其创建的占位用户，角色为访客，没有密码，不能登录
+	RegisterSourceGuestType  RegisterSourceType = 5
+	RegisterSourceGithubType RegisterSourceType = 6
+	RegisterSourceGiteeType  RegisterSourceType = 7
+	RegisterSourceOIDCType   RegisterSourceType = 8
 )
@@ -0,0 +1,102 @@
+// Path: ./models/enum/report.go
+
+package enum
+
+// ReportTargetType 举报的对象
+type ReportTargetType uint8
+
+const (
+	ReportTargetArticle ReportTargetType = 1
+	ReportTargetComment ReportTargetType = 2
+	ReportTargetUser    ReportTargetType = 3
+)
+
+func (t ReportTargetType) String() string {
+	switch t {
+	case ReportTargetArticle:
+		return "文章"
+	case ReportTargetComment:
+		return "评论"
+	case ReportTargetUser:
+		return "用户"
+	}
+	return ""
+}
+
+// ReportReason 举报的原因
+type ReportReason uint8
+
+const (
+	ReportReasonSpam      ReportReason = 1 // 垃圾广告
+	ReportReasonPorn      ReportReason = 2 // 色情低俗
+	ReportReasonIllegal   ReportReason = 3 // 违法违规
+	ReportReasonAbuse     ReportReason = 4 // 人身攻击
+	ReportReasonInfringe  ReportReason = 5 // 侵权抄袭
+	ReportReasonOtherType ReportReason = 6 // 其他，需要填写说明
+)
+
+func (r ReportReason) String() string {
+	switch r {
+	case ReportReasonSpam:
+		return "垃圾广告"
+	case ReportReasonPorn:
+		return "色情低俗"
+	case ReportReasonIllegal:
+		return "违法违规"
+	case ReportReasonAbuse:
+		return "人身攻击"
+	case ReportReasonInfringe:
+		return "侵权抄袭"
+	case ReportReasonOtherType:
+		return "其他"
+	}
+	return ""
+}
+
+// ReportStatus 举报的处理状态
+type ReportStatus uint8
+
+const (
+	ReportStatusPending   ReportStatus = 1 // 待处理
+	ReportStatusResolved  ReportStatus = 2 // 已处理
+	ReportStatusDismissed ReportStatus = 3 // 已驳回
+)
+
+func (s ReportStatus) String() string {
+	switch s {
+	case ReportStatusPending:
+		return "待处理"
+	case ReportStatusResolved:
+		return "已处理"
+	case ReportStatusDismissed:
+		return "已驳回"
+	}
+	return ""
+}
+
+// ReportAction 管理员对举报的处理方式
+type ReportAction uint8
+
+const (
+	ReportActionDismiss ReportAction = 1 // 驳回，内容没有问题
+	ReportActionHide    ReportAction = 2 // 隐藏内容：文章退回修改，评论设为未通过
+	ReportActionDelete  ReportAction = 3 // 删除内容，放入回收站
+	ReportActionWarn    ReportAction = 4 // 警告发布者
+	ReportActionBan     ReportAction = 5 // 封禁发布者
+)
+
+func (a ReportAction) String() string {
+	switch a {
+	case ReportActionDismiss:
+		return "驳回"
+	case ReportActionHide:
+		return "隐藏内容"
+	case ReportActionDelete:
+		return "删除内容"
+	case ReportActionWarn:
+		return "警告用户"
+	case ReportActionBan:
+		return "封禁用户"
+	}
+	return ""
+}
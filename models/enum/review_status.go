@@ -0,0 +1,43 @@
+// Path: ./models/enum/review_status.go
+
+package enum
+
+// ReviewStatus 一轮审核的状态
+type ReviewStatus uint8
+
+const (
+	ReviewStatusPending  ReviewStatus = 1 // 待审核（可能已被认领）
+	ReviewStatusApproved ReviewStatus = 2 // 通过
+	ReviewStatusRejected ReviewStatus = 3 // 拒绝
+	ReviewStatusChanges  ReviewStatus = 4 // 退回修改
+	ReviewStatusWithdraw ReviewStatus = 5 // 作者撤回（审核期间转为草稿等）
+)
+
+func (r ReviewStatus) String() string {
+	switch r {
+	case ReviewStatusPending:
+		return "待审核"
+	case ReviewStatusApproved:
+		return "通过"
+	case ReviewStatusRejected:
+		return "拒绝"
+	case ReviewStatusChanges:
+		return "退回修改"
+	case ReviewStatusWithdraw:
+		return "已撤回"
+	}
+	return ""
+}
+
+// ArticleStatus 审核结果对应的文章状态
+func (r ReviewStatus) ArticleStatus() ArticleStatus {
+	switch r {
+	case ReviewStatusApproved:
+		return ArticleStatusPublish
+	case ReviewStatusRejected:
+		return AritcleStatusFail
+	case ReviewStatusChanges:
+		return ArticleStatusRevise
+	}
+	return ArticleStatusReview
+}
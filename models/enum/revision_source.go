@@ -0,0 +1,29 @@
+// Path: ./models/enum/revision_source.go
+
+package enum
+
+type RevisionSourceType uint8
+
+const (
+	RevisionSourceCreate  RevisionSourceType = 1 // 创建文章
+	RevisionSourceUpdate  RevisionSourceType = 2 // 修改文章
+	RevisionSourceAutoGen RevisionSourceType = 3 // 自动发布
+	RevisionSourceRestore RevisionSourceType = 4 // 回滚到历史版本
+	RevisionSourceImport  RevisionSourceType = 5 // 从其他博客导入
+)
+
+func (r RevisionSourceType) String() string {
+	switch r {
+	case RevisionSourceCreate:
+		return "创建"
+	case RevisionSourceUpdate:
+		return "修改"
+	case RevisionSourceAutoGen:
+		return "自动发布"
+	case RevisionSourceRestore:
+		return "版本回滚"
+	case RevisionSourceImport:
+		return "导入"
+	}
+	return ""
+}
@@ -8,6 +8,8 @@ const (
 	AdminRoleType RoleType = 1
 	UserRoleType  RoleType = 2
 	GuestRoleType RoleType = 3
+	// ReviewerRoleType 审核员，可以认领和审核文章，其他权限同普通用户
+	ReviewerRoleType RoleType = 4
 )
 
 func (r RoleType) String() string {
@@ -18,6 +20,8 @@ func (r RoleType) String() string {
 		return "User"
 	case GuestRoleType:
 		return "Guest"
+	case ReviewerRoleType:
+		return "Reviewer"
 	}
 	return ""
 }
@@ -0,0 +1,24 @@
+// Path: ./models/enum/sensitive_action.go
+
+package enum
+
+// SensitiveAction 命中敏感词后的处理方式
+type SensitiveAction uint8
+
+const (
+	SensitiveActionBlock  SensitiveAction = 1 // 拒绝提交
+	SensitiveActionMask   SensitiveAction = 2 // 替换为 *
+	SensitiveActionReview SensitiveAction = 3 // 允许提交，但需要人工审核
+)
+
+func (s SensitiveAction) String() string {
+	switch s {
+	case SensitiveActionBlock:
+		return "拦截"
+	case SensitiveActionMask:
+		return "打码"
+	case SensitiveActionReview:
+		return "审核"
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+// Path: ./models/import_job_model.go
+
+package models
+
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
+// ImportJobModel 后台导入任务（目前是 WordPress 的 WXR 文件）
+// 每篇文章导入后在同一个事务中推进 Cursor，任务中断后从 Cursor 继续，不会重复导入
+type ImportJobModel struct {
+	Model
+	UserID     uint                 `gorm:"index;not null" json:"userID"` // 文章导入到谁名下
+	FileName   string               `gorm:"size:128" json:"fileName"`     // 上传时的文件名
+	FilePath   string               `gorm:"size:256" json:"-"`            // 服务器上保存的文件，任务完成后删除
+	DryRun     bool                 `json:"dryRun"`                       // 只统计，不写入
+	Status     enum.ImportJobStatus `gorm:"index;not null" json:"status"`
+	Total      int                  `json:"total"`    // 需要导入的文章数
+	Cursor     int                  `json:"cursor"`   // 已处理的文章数
+	Created    int                  `json:"created"`  // 导入的文章数
+	Skipped    int                  `json:"skipped"`  // 已有同名文章而跳过的
+	Failed     int                  `json:"failed"`   // 导入失败的
+	Comments   int                  `json:"comments"` // 导入的评论数
+	Users      int                  `json:"users"`    // 为评论者新建的占位用户数
+	Errors     []string             `gorm:"type:longtext; serializer:json" json:"errors"`
+	Error      string               `gorm:"size:1024" json:"error"` // 整个任务失败的原因
+	FinishedAt *time.Time           `json:"finishedAt"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
+
+// ImportJobProgressColumns 每处理一篇文章需要更新的字段
+var ImportJobProgressColumns = []string{"cursor", "created", "skipped", "failed", "comments", "users", "errors"}
@@ -0,0 +1,19 @@
+// Path: ./models/mention_model.go
+
+package models
+
+// MentionModel 文章正文或评论中 @ 了哪些用户
+// 同一处内容对同一个用户只有一条记录，修改内容时不会重复提醒
+type MentionModel struct {
+	Model
+	UserID       uint `gorm:"not null;uniqueIndex:idx_uniq_mention" json:"userID"` // 被提及的用户
+	ArticleID    uint `gorm:"not null;uniqueIndex:idx_uniq_mention" json:"articleID"`
+	CommentID    uint `gorm:"not null;uniqueIndex:idx_uniq_mention" json:"commentID"` // 0 表示在文章正文中提及
+	ActionUserID uint `gorm:"not null" json:"actionUserID"`                           // 谁提及的
+	Notified     bool `gorm:"not null; default:false" json:"notified"`                // 内容发布后才会提醒，草稿、待审核的先不提醒
+
+	// FK
+	UserModel       UserModel    `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	ActionUserModel UserModel    `gorm:"foreignKey:ActionUserID;references:ID" json:"-"`
+	ArticleModel    ArticleModel `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
+}
@@ -0,0 +1,25 @@
+// Path: ./models/reaction_model.go
+
+package models
+
+import "blogX_server/models/enum"
+
+// ReactionModel 用户对文章或评论的表情回应，同一个对象可以回应多个不同的表情
+type ReactionModel struct {
+	Model
+	UserID     uint                    `gorm:"not null;uniqueIndex:idx_uniq_reaction" json:"userID"`
+	TargetType enum.ReactionTargetType `gorm:"not null;uniqueIndex:idx_uniq_reaction;index:idx_reaction_target" json:"targetType"`
+	TargetID   uint                    `gorm:"not null;uniqueIndex:idx_uniq_reaction;index:idx_reaction_target" json:"targetID"`
+	Emoji      string                  `gorm:"size:16;not null;uniqueIndex:idx_uniq_reaction;index:idx_reaction_target" json:"emoji"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
+
+// ReactionCountModel 每个对象各表情的回应数，增量先记在 redis 中，定时同步到这里
+type ReactionCountModel struct {
+	TargetType enum.ReactionTargetType `gorm:"primaryKey" json:"targetType"`
+	TargetID   uint                    `gorm:"primaryKey" json:"targetID"`
+	Emoji      string                  `gorm:"size:16;primaryKey" json:"emoji"`
+	Count      int                     `gorm:"not null; default:0" json:"count"`
+}
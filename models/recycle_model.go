@@ -0,0 +1,24 @@
+// Path: ./models/recycle_model.go
+
+package models
+
+import (
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
+)
+
+// RecycleModel 回收站记录，文章和评论被删除时只做软删除，并在这里记一笔
+// 超过保留期限后由定时任务彻底删除
+type RecycleModel struct {
+	Model
+	Type       enum.RecycleType `gorm:"not null;index:idx_recycle_target" json:"type"`
+	TargetID   uint             `gorm:"not null;index:idx_recycle_target" json:"targetID"` // 文章或评论的 id
+	OwnerID    uint             `gorm:"not null;index" json:"ownerID"`                     // 内容的发布者
+	OperatorID uint             `gorm:"not null" json:"operatorID"`                        // 执行删除的人
+	Title      string           `gorm:"size:128" json:"title"`                             // 文章标题或评论内容摘要
+	RelatedIDs ctype.List       `gorm:"type:longtext" json:"-"`                            // 一起被删除的子评论 id
+
+	// FK
+	OwnerModel    UserModel `gorm:"foreignKey:OwnerID;references:ID" json:"-"`
+	OperatorModel UserModel `gorm:"foreignKey:OperatorID;references:ID" json:"-"`
+}
@@ -0,0 +1,27 @@
+// Path: ./models/report_model.go
+
+package models
+
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
+// ReportModel 用户对文章、评论、用户的举报
+// 同一个人对同一个对象只有一条记录，处理完后再次举报会重新进入待处理
+type ReportModel struct {
+	Model
+	UserID     uint                  `gorm:"not null;uniqueIndex:idx_uniq_report" json:"userID"` // 举报人
+	TargetType enum.ReportTargetType `gorm:"not null;uniqueIndex:idx_uniq_report;index:idx_report_target" json:"targetType"`
+	TargetID   uint                  `gorm:"not null;uniqueIndex:idx_uniq_report;index:idx_report_target" json:"targetID"`
+	Reason     enum.ReportReason     `gorm:"not null" json:"reason"`
+	Content    string                `gorm:"size:512" json:"content"` // 补充说明
+	Status     enum.ReportStatus     `gorm:"not null; default:1; index" json:"status"`
+	Action     enum.ReportAction     `json:"action"`               // 处理方式，待处理时为 0
+	Note       string                `gorm:"size:256" json:"note"` // 处理说明，会通知举报人
+	HandlerID  *uint                 `json:"handlerID"`            // 处理的管理员
+	HandledAt  *time.Time            `json:"handledAt"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
@@ -0,0 +1,13 @@
+// Path: ./models/sensitive_word_model.go
+
+package models
+
+import "blogX_server/models/enum"
+
+// SensitiveWordModel 敏感词，修改后需要重新加载到内存中的自动机
+type SensitiveWordModel struct {
+	Model
+	Word     string               `gorm:"size:64; not null; uniqueIndex" json:"word"`
+	Category string               `gorm:"size:32; not null; index" json:"category"` // 分类，如 广告、辱骂、政治
+	Action   enum.SensitiveAction `gorm:"not null; default:1" json:"action"`
+}
@@ -0,0 +1,16 @@
+// Path: ./models/series_article_model.go
+
+package models
+
+// SeriesArticleModel 系列与文章的关联，一篇文章最多属于一个系列
+// Rank 越小越靠前，允许不连续（移除文章时不需要整理）
+type SeriesArticleModel struct {
+	Model
+	SeriesID  uint `gorm:"not null;index" json:"seriesID"`
+	ArticleID uint `gorm:"not null;uniqueIndex" json:"articleID"`
+	Rank      int  `gorm:"not null" json:"rank"`
+
+	// FK
+	SeriesModel  SeriesModel  `gorm:"foreignKey:SeriesID;references:ID" json:"-"`
+	ArticleModel ArticleModel `gorm:"foreignKey:ArticleID;references:ID" json:"-"`
+}
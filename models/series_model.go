@@ -0,0 +1,16 @@
+// Path: ./models/series_model.go
+
+package models
+
+// SeriesModel 文章系列（专栏），一个系列由同一作者的多篇文章按顺序组成
+type SeriesModel struct {
+	Model
+	UserID       uint   `gorm:"not null;uniqueIndex:idx_uniq_series" json:"userID"`
+	Title        string `gorm:"not null;uniqueIndex:idx_uniq_series;size:64" json:"title"`
+	Abstract     string `gorm:"size:256" json:"abstract"`
+	CoverURL     string `gorm:"size:256" json:"coverURL"`
+	ArticleCount int    `gorm:"not null;default:0" json:"articleCount"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
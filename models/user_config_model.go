@@ -26,6 +26,8 @@ type UserMessageConfModel struct {
 	ReceiveCollectNotify   bool `gorm:"not null; default:true" json:"receiveCollectNotify"`
 	ReceivePrivateMessage  bool `gorm:"not null; default:true" json:"receivePrivateMessage"`
 	ReceiveStrangerMessage bool `gorm:"not null; default:true" json:"receiveStrangerMessage"`
+	ReceiveMentionNotify   bool `gorm:"not null; default:true" json:"receiveMentionNotify"` // 被 @ 时提醒
+	AutoWatchComment       bool `gorm:"not null; default:true" json:"autoWatchComment"`     // 评论后自动关注所在的讨论
 
 	// FK
 	UserModel UserModel `gorm:"foreignKey:UserID; reference:ID" json:"userModel"`
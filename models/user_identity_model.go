@@ -0,0 +1,18 @@
+// Path: ./models/user_identity_model.go
+
+package models
+
+// UserIdentityModel 用户绑定的第三方账号，一个用户可以绑定多个服务，每个服务一个账号
+// QQ 登录仍然使用 UserModel.OpenID
+type UserIdentityModel struct {
+	Model
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_uniq_user_provider" json:"userID"`
+	Provider  string `gorm:"size:32;not null;uniqueIndex:idx_uniq_user_provider;uniqueIndex:idx_uniq_identity" json:"provider"` // 配置中的服务名称
+	Subject   string `gorm:"size:128;not null;uniqueIndex:idx_uniq_identity" json:"subject"`                                    // 第三方账号的唯一标识
+	Username  string `gorm:"size:64" json:"username"`
+	Email     string `gorm:"size:256" json:"email"`
+	AvatarURL string `gorm:"size:256" json:"avatarURL"`
+
+	// FK
+	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
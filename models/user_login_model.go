@@ -1,12 +1,29 @@
 package models
 
+import (
+	"blogX_server/models/enum"
+	"time"
+)
+
+// UserLoginModel 用户的登录设备，每次登录一条，用 refresh token 续期
 type UserLoginModel struct {
 	Model
-	UserID     uint   `gorm:"not null" json:"userID"`
-	IP         string `gorm:"size:32; not null" json:"ip"`
-	IPLocation string `gorm:"size:64; not null" json:"ipLocation"`
-	UA         string `gorm:"size:256; not null" json:"ua"`
+	UserID       uint           `gorm:"not null; index" json:"userID"`
+	IP           string         `gorm:"size:32; not null" json:"ip"`
+	IPLocation   string         `gorm:"size:64; not null" json:"ipLocation"`
+	UA           string         `gorm:"size:256; not null" json:"ua"`
+	LoginType    enum.LoginType `json:"loginType"`
+	RefreshGen   int            `json:"-"`                      // refresh token 的轮换次数，旧的 refresh token 再次使用时可以识别出来
+	RefreshSalt  string         `gorm:"size:32" json:"-"`       // refresh token 签名用，每个设备不同
+	LastActiveAt *time.Time     `json:"lastActiveAt"`           // 最后一次刷新 token 的时间
+	ExpiresAt    *time.Time     `json:"expiresAt"`              // 到期后要重新登录，每次刷新顺延
+	RevokedAt    *time.Time     `gorm:"index" json:"revokedAt"` // 注销或被下线的时间
 
 	// FK
 	UserModel UserModel `gorm:"foreignKey:UserID;references:ID" json:"-"`
 }
+
+// Active 设备是否还在登录中，之前没有 refresh token 的登录记录不算
+func (s *UserLoginModel) Active() bool {
+	return s.RevokedAt == nil && s.ExpiresAt != nil && s.ExpiresAt.After(time.Now())
+}
@@ -17,7 +17,7 @@ type UserModel struct {
 	NicknameUpdate int64                   `gorm:"default:null" json:"-"` // 昵称更新时间 秒级时间戳
 	AvatarURL      string                  `gorm:"size:256" json:"avatarURL"`
 	Bio            string                  `gorm:"size:256" json:"bio"`
-	OpenID         string                  `gorm:"size:64" json:"openid"`
+	OpenID         string                  `gorm:"size:64; index" json:"openid"` // QQ 登录的 openid，没有绑定为空
 	Gender         int8                    `json:"gender"`
 	Phone          string                  `gorm:"size:16" json:"phone"`
 	Country        string                  `gorm:"size:16" json:"country"`
@@ -29,6 +29,8 @@ type UserModel struct {
 	RegisterSource enum.RegisterSourceType `gorm:"not null" json:"registerSource"`
 	DateOfBirth    time.Time               `gorm:"default:null" json:"dateOfBirth"`
 	Role           enum.RoleType           `gorm:"not null" json:"role"` // 角色 1管理员 2普通用户 3访客
+	BannedUntil    *time.Time              `json:"bannedUntil"`          // 封禁到期时间，nil 为未封禁
+	BanReason      string                  `gorm:"size:256" json:"banReason"`
 
 	// FK
 	UserConfigModel      *UserConfigModel      `gorm:"foreignKey:UserID;references:ID" json:"-"` // 注意是指针，否则会报错：嵌套循环
@@ -39,6 +41,11 @@ type UserModel struct {
 	Images []ImageModel `gorm:"many2many:user_upload_images;joinForeignKey:UserID;JoinReferences:ImageID" json:"images"`
 }
 
+// IsBanned 是否在封禁期内
+func (u *UserModel) IsBanned() bool {
+	return u.BannedUntil != nil && u.BannedUntil.After(time.Now())
+}
+
 func (u *UserModel) SiteAge() int {
 	return int(time.Now().Sub(u.CreatedAt).Hours() / 24 / 365)
 }
@@ -0,0 +1,23 @@
+// Path: ./models/user_two_factor_model.go
+
+package models
+
+import "time"
+
+// UserTwoFactorModel 用户的 TOTP 两步验证，开始绑定时创建，确认第一个验证码后才开启
+type UserTwoFactorModel struct {
+	Model
+	UserID    uint       `gorm:"not null;uniqueIndex" json:"userID"`
+	Secret    string     `gorm:"size:64;not null" json:"-"`
+	Enabled   bool       `json:"enabled"`
+	EnabledAt *time.Time `json:"enabledAt"`
+	LastStep  int64      `json:"-"` // 最后一次通过验证的周期，同一个验证码不能用两次
+}
+
+// UserRecoveryCodeModel 两步验证的恢复码，只保存哈希，每个只能用一次
+type UserRecoveryCodeModel struct {
+	Model
+	UserID   uint       `gorm:"not null;index" json:"userID"`
+	CodeHash string     `gorm:"size:64;not null" json:"-"`
+	UsedAt   *time.Time `json:"usedAt"`
+}
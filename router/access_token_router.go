@@ -0,0 +1,20 @@
+// Path: ./router/access_token_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/access_token_api"
+	"blogX_server/middleware"
+	"blogX_server/models"
+	"github.com/gin-gonic/gin"
+)
+
+func AccessTokenRouter(rg *gin.RouterGroup) {
+	app := api.App.AccessTokenApi
+
+	rg.GET("access_token/scopes", app.AccessTokenScopeOptionsView)
+	rg.GET("access_token", mdw.BindQueryMiddleware[access_token_api.AccessTokenListReq], mdw.AuthMiddleware, app.AccessTokenListView)
+	rg.POST("access_token", mdw.BindJsonMiddleware[access_token_api.AccessTokenCreateReq], mdw.AuthMiddleware, app.AccessTokenCreateView)
+	rg.DELETE("access_token", mdw.BindJsonMiddleware[models.IDRequest], mdw.AuthMiddleware, app.AccessTokenRemoveView)
+}
@@ -7,6 +7,7 @@ import (
 	"blogX_server/api/article_api"
 	mdw "blogX_server/middleware"
 	"blogX_server/models"
+	"blogX_server/models/enum"
 	"blogX_server/service/redis_service/redis_cache"
 	"github.com/gin-gonic/gin"
 )
@@ -15,8 +16,8 @@ func ArticleRouter(rg *gin.RouterGroup) {
 	app := api.App.ArticleApi
 
 	// 文章 CRUD
-	rg.POST("article", mdw.BindJsonMiddleware[article_api.ArticleCreateReq], mdw.CaptchaMiddleware, mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleCreateView)
-	rg.PUT("article", mdw.BindJsonMiddleware[article_api.ArticleUpdateReq], mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleUpdateView)
+	rg.POST("article", mdw.BindJsonMiddleware[article_api.ArticleCreateReq], mdw.CaptchaMiddleware, mdw.ScopeMiddleware(enum.ScopeArticleWrite), mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleCreateView)
+	rg.PUT("article", mdw.BindJsonMiddleware[article_api.ArticleUpdateReq], mdw.ScopeMiddleware(enum.ScopeArticleWrite), mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleUpdateView)
 	rg.GET("article", mdw.BindQueryMiddleware[article_api.ArticleListReq], app.ArticleListView)
 	rg.GET("article/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.CacheMiddleware(redis_cache.NewArticleDetailCacheOption()), app.ArticleDetailView)
 	rg.DELETE("article/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleRemoveView)
@@ -34,7 +35,33 @@ func ArticleRouter(rg *gin.RouterGroup) {
 	rg.PUT("article/pin/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleNewUserPinView) // 配合前端重新写的置顶方法
 
 	// 审核
-	rg.POST("article/review", mdw.BindJsonMiddleware[article_api.ArticleReviewReq], mdw.AdminMiddleware, app.ArticleReviewView)
+	rg.POST("article/review", mdw.BindJsonMiddleware[article_api.ArticleReviewReq], mdw.ReviewerMiddleware, app.ArticleReviewView)
+	rg.GET("article/review/queue", mdw.BindQueryMiddleware[article_api.ArticleReviewQueueReq], mdw.ReviewerMiddleware, app.ArticleReviewQueueView)
+	rg.PUT("article/review/claim/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.ReviewerMiddleware, app.ArticleReviewClaimView)
+	rg.DELETE("article/review/claim/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.ReviewerMiddleware, app.ArticleReviewReleaseView)
+	rg.PUT("article/review/resubmit", mdw.BindJsonMiddleware[article_api.ArticleReviewResubmitReq], mdw.ScopeMiddleware(enum.ScopeArticleWrite), mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleReviewResubmitView)
+	rg.GET("article/review/history/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleReviewHistoryView)
+
+	// 定时发布
+	rg.GET("article/schedule", mdw.BindQueryMiddleware[article_api.ArticleScheduleListReq], mdw.AuthMiddleware, app.ArticleScheduleListView)
+	rg.PUT("article/schedule", mdw.BindJsonMiddleware[article_api.ArticleScheduleUpdateReq], mdw.ScopeMiddleware(enum.ScopeArticleWrite), mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleScheduleUpdateView)
+	rg.DELETE("article/schedule/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleScheduleCancelView)
+
+	// 修订历史
+	rg.GET("article/revision", mdw.BindQueryMiddleware[article_api.ArticleRevisionListReq], mdw.AuthMiddleware, app.ArticleRevisionListView)
+	rg.GET("article/revision/diff", mdw.BindQueryMiddleware[article_api.ArticleRevisionDiffReq], mdw.AuthMiddleware, app.ArticleRevisionDiffView)
+	rg.GET("article/revision/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleRevisionDetailView)
+	rg.PUT("article/revision/restore/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleRevisionRestoreView)
+
+	// 导入导出（Hexo / Hugo 的 zip）
+	rg.POST("article/import", mdw.ScopeMiddleware(enum.ScopeArticleWrite), mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleImportView)
+	rg.GET("article/export", mdw.BindQueryMiddleware[article_api.ArticleExportReq], mdw.AuthMiddleware, app.ArticleExportView)
+
+	// WordPress 导入（后台任务）
+	rg.POST("article/import/wordpress", mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleImportWordpressView)
+	rg.GET("article/import/wordpress", mdw.BindQueryMiddleware[article_api.ImportJobListReq], mdw.AuthMiddleware, app.ArticleImportJobListView)
+	rg.GET("article/import/wordpress/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleImportJobView)
+	rg.PUT("article/import/wordpress/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, mdw.VerifySiteModeMiddleware, app.ArticleImportJobResumeView)
 
 	// 点赞收藏 CD
 	rg.POST("article/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.ArticleLikeView)
@@ -56,6 +83,15 @@ func ArticleRouter(rg *gin.RouterGroup) {
 	rg.GET("article/category/options", mdw.AuthMiddleware, app.ArticleCategoryOptionsView)
 	rg.GET("article/tag/options", mdw.AuthMiddleware, app.ArticleTagOptionsView)
 
+	// 文章系列 CRUD
+	rg.POST("article/series", mdw.BindJsonMiddleware[article_api.ArticleSeriesCreateReq], mdw.AuthMiddleware, app.ArticleSeriesCreateView)
+	rg.GET("article/series", mdw.BindQueryMiddleware[article_api.ArticleSeriesListReq], app.ArticleSeriesListView)
+	rg.GET("article/series/:id", mdw.BindUriMiddleware[models.IDRequest], app.ArticleSeriesDetailView)
+	rg.DELETE("article/series", mdw.BindJsonMiddleware[models.IDListRequest], mdw.AuthMiddleware, app.ArticleSeriesRemoveView)
+	rg.POST("article/series/article", mdw.BindJsonMiddleware[article_api.ArticleSeriesArticleReq], mdw.AuthMiddleware, app.ArticleSeriesAddView)
+	rg.DELETE("article/series/article", mdw.BindJsonMiddleware[article_api.ArticleSeriesArticleReq], mdw.AuthMiddleware, app.ArticleSeriesRemoveArticleView)
+	rg.PUT("article/series/sort", mdw.BindJsonMiddleware[article_api.ArticleSeriesSortReq], mdw.AuthMiddleware, app.ArticleSeriesSortView)
+
 	// 收藏夹 CRUD
 	rg.POST("article/collections", mdw.BindJsonMiddleware[article_api.ArticleCollectionCreateReq], mdw.AuthMiddleware, app.ArticleCollectionFolderCreateView)
 	rg.PUT("article/collections", mdw.BindJsonMiddleware[article_api.ArticleCollectionUpdateReq], mdw.AuthMiddleware, app.ArticleCollectionFolderUpdateView)
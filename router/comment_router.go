@@ -7,15 +7,28 @@ import (
 	"blogX_server/api/comment_api"
 	"blogX_server/middleware"
 	"blogX_server/models"
+	"blogX_server/models/enum"
 	"github.com/gin-gonic/gin"
 )
 
 func CommentRouter(rg *gin.RouterGroup) {
 	app := api.App.CommentApi
 
-	rg.POST("comment", mdw.BindJsonMiddleware[comment_api.CommentCreateReq], mdw.AuthMiddleware, app.CommentCreateView)
+	rg.POST("comment", mdw.BindJsonMiddleware[comment_api.CommentCreateReq], mdw.ScopeMiddleware(enum.ScopeCommentWrite), mdw.AuthMiddleware, app.CommentCreateView)
+	rg.POST("comment/guest", mdw.BindJsonMiddleware[comment_api.GuestCommentCreateReq], mdw.CaptchaMiddleware, app.GuestCommentCreateView)
+	rg.GET("comment/guest/verify", mdw.BindQueryMiddleware[comment_api.GuestCommentVerifyReq], app.GuestCommentVerifyView)
 	rg.POST("comment/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.CommentLikeView)
 	rg.GET("comment/tree/:id", mdw.BindUriMiddleware[models.IDRequest], app.CommentTreeView)
+	rg.GET("comment/replies/:id", mdw.BindUriMiddleware[models.IDRequest], app.CommentReplyView)
+	rg.POST("comment/pin", mdw.BindJsonMiddleware[comment_api.CommentPinReq], mdw.AuthMiddleware, app.CommentPinView)
+	rg.POST("comment/highlight", mdw.BindJsonMiddleware[comment_api.CommentHighlightReq], mdw.AuthMiddleware, app.CommentHighlightView)
+	rg.POST("comment/watch", mdw.BindJsonMiddleware[comment_api.CommentWatchReq], mdw.AuthMiddleware, app.CommentWatchView)
+	rg.DELETE("comment/watch", mdw.BindJsonMiddleware[comment_api.CommentWatchReq], mdw.AuthMiddleware, app.CommentUnwatchView)
+	rg.GET("comment/watch", mdw.BindQueryMiddleware[comment_api.CommentWatchListReq], mdw.AuthMiddleware, app.CommentWatchListView)
 	rg.GET("comment", mdw.BindQueryMiddleware[comment_api.CommentListReq], mdw.AuthMiddleware, app.CommentListView)
+	rg.GET("comment/moderation", mdw.BindQueryMiddleware[comment_api.CommentModerationListReq], mdw.AuthMiddleware, app.CommentModerationListView)
+	rg.POST("comment/moderation", mdw.BindJsonMiddleware[comment_api.CommentModerationReq], mdw.AuthMiddleware, app.CommentModerationView)
+	rg.PUT("comment", mdw.BindJsonMiddleware[comment_api.CommentUpdateReq], mdw.ScopeMiddleware(enum.ScopeCommentWrite), mdw.AuthMiddleware, app.CommentUpdateView)
+	rg.GET("comment/edit/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AdminMiddleware, app.CommentEditListView)
 	rg.DELETE("comment/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.CommentRemoveView)
 }
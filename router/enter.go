@@ -15,6 +15,7 @@ func Run() {
 	r := gin.Default()
 
 	r.Static("/uploads", "uploads") // 配置静态路由访问上传文件
+	SitemapRouter(&r.RouterGroup)   // robots.txt 和 sitemap 需要在根路径下
 
 	nr := r.Group("/api")
 
@@ -38,6 +39,14 @@ func Run() {
 	AIRouter(nr)
 	DataRouter(nr)
 	FocusRouter(nr)
+	RecycleRouter(nr)
+	FeedRouter(nr)
+	SeoRouter(nr)
+	SensitiveRouter(nr)
+	ReportRouter(nr)
+	ReactionRouter(nr)
+	OAuthRouter(nr)
+	AccessTokenRouter(nr)
 
 	MytestRouter(nr) // 测试用
 
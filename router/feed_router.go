@@ -0,0 +1,20 @@
+// Path: ./router/feed_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/feed_api"
+	"blogX_server/middleware"
+	"blogX_server/service/redis_service/redis_cache"
+	"github.com/gin-gonic/gin"
+)
+
+func FeedRouter(rg *gin.RouterGroup) {
+	app := api.App.FeedApi
+
+	// 不带参数是全站，userID / categoryID / tag 用于筛选
+	rg.GET("feed/rss", mdw.BindQueryMiddleware[feed_api.FeedReq], mdw.CacheMiddleware(redis_cache.NewFeedCacheOption("rss")), app.FeedRssView)
+	rg.GET("feed/atom", mdw.BindQueryMiddleware[feed_api.FeedReq], mdw.CacheMiddleware(redis_cache.NewFeedCacheOption("atom")), app.FeedAtomView)
+	rg.GET("feed/json", mdw.BindQueryMiddleware[feed_api.FeedReq], mdw.CacheMiddleware(redis_cache.NewFeedCacheOption("json")), app.FeedJsonView)
+}
@@ -0,0 +1,20 @@
+// Path: ./router/oauth_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/oauth_api"
+	"blogX_server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func OAuthRouter(rg *gin.RouterGroup) {
+	app := api.App.OAuthApi
+
+	rg.GET("oauth/providers", app.OAuthProviderListView)
+	rg.GET("oauth/identities", mdw.AuthMiddleware, app.OAuthIdentityListView)
+	rg.GET("oauth/:name/authorize", mdw.BindUriMiddleware[oauth_api.OAuthNameReq], app.OAuthAuthorizeView)
+	rg.GET("oauth/:name/callback", mdw.BindUriMiddleware[oauth_api.OAuthNameReq], app.OAuthCallbackView)
+	rg.DELETE("oauth/:name/bind", mdw.BindUriMiddleware[oauth_api.OAuthNameReq], mdw.AuthMiddleware, app.OAuthUnbindView)
+}
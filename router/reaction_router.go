@@ -0,0 +1,18 @@
+// Path: ./router/reaction_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/reaction_api"
+	"blogX_server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func ReactionRouter(rg *gin.RouterGroup) {
+	app := api.App.ReactionApi
+
+	rg.POST("reaction", mdw.BindJsonMiddleware[reaction_api.ReactionToggleReq], mdw.AuthMiddleware, app.ReactionToggleView)
+	rg.GET("reaction", mdw.BindQueryMiddleware[reaction_api.ReactionCountReq], app.ReactionCountView)
+	rg.GET("reaction/users", mdw.BindQueryMiddleware[reaction_api.ReactionUserListReq], app.ReactionUserListView)
+}
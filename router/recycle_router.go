@@ -0,0 +1,19 @@
+// Path: ./router/recycle_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/recycle_api"
+	"blogX_server/middleware"
+	"blogX_server/models"
+	"github.com/gin-gonic/gin"
+)
+
+func RecycleRouter(rg *gin.RouterGroup) {
+	app := api.App.RecycleApi
+
+	rg.GET("recycle", mdw.BindQueryMiddleware[recycle_api.RecycleListReq], mdw.AuthMiddleware, app.RecycleListView)
+	rg.PUT("recycle", mdw.BindJsonMiddleware[models.IDListRequest], mdw.AuthMiddleware, app.RecycleRestoreView)
+	rg.DELETE("recycle", mdw.BindJsonMiddleware[models.IDListRequest], mdw.AuthMiddleware, app.RecycleRemoveView)
+}
@@ -0,0 +1,19 @@
+// Path: ./router/report_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/report_api"
+	"blogX_server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func ReportRouter(rg *gin.RouterGroup) {
+	app := api.App.ReportApi
+
+	rg.POST("report", mdw.BindJsonMiddleware[report_api.ReportCreateReq], mdw.AuthMiddleware, app.ReportCreateView)
+	rg.GET("report", mdw.BindQueryMiddleware[report_api.ReportListReq], mdw.AdminMiddleware, app.ReportListView)
+	rg.GET("report/target", mdw.BindQueryMiddleware[report_api.ReportTargetListReq], mdw.AdminMiddleware, app.ReportTargetListView)
+	rg.POST("report/handle", mdw.BindJsonMiddleware[report_api.ReportHandleReq], mdw.AdminMiddleware, app.ReportHandleView)
+}
@@ -0,0 +1,21 @@
+// Path: ./router/sensitive_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/sensitive_api"
+	"blogX_server/middleware"
+	"blogX_server/models"
+	"github.com/gin-gonic/gin"
+)
+
+func SensitiveRouter(rg *gin.RouterGroup) {
+	app := api.App.SensitiveApi
+
+	rg.POST("sensitive", mdw.BindJsonMiddleware[sensitive_api.SensitiveCreateReq], mdw.AdminMiddleware, app.SensitiveCreateView)
+	rg.GET("sensitive", mdw.BindQueryMiddleware[sensitive_api.SensitiveListReq], mdw.AdminMiddleware, app.SensitiveListView)
+	rg.PUT("sensitive", mdw.BindJsonMiddleware[sensitive_api.SensitiveUpdateReq], mdw.AdminMiddleware, app.SensitiveUpdateView)
+	rg.DELETE("sensitive", mdw.BindJsonMiddleware[models.IDListRequest], mdw.AdminMiddleware, app.SensitiveRemoveView)
+	rg.POST("sensitive/check", mdw.BindJsonMiddleware[sensitive_api.SensitiveCheckReq], mdw.AdminMiddleware, app.SensitiveCheckView)
+}
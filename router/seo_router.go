@@ -0,0 +1,27 @@
+// Path: ./router/seo_router.go
+
+package router
+
+import (
+	"blogX_server/api"
+	"blogX_server/api/seo_api"
+	"blogX_server/middleware"
+	"blogX_server/models"
+	"blogX_server/service/redis_service/redis_cache"
+	"github.com/gin-gonic/gin"
+)
+
+func SeoRouter(rg *gin.RouterGroup) {
+	app := api.App.SeoApi
+
+	rg.GET("seo/article/:id", mdw.BindUriMiddleware[models.IDRequest], app.ArticleSeoView)
+}
+
+// SitemapRouter 挂在根路径下，不经过 /api 的日志和点击统计中间件
+func SitemapRouter(rg *gin.RouterGroup) {
+	app := api.App.SeoApi
+
+	rg.GET("robots.txt", app.RobotsView)
+	rg.GET("sitemap.xml", mdw.CacheMiddleware(redis_cache.NewSitemapCacheOption()), app.SitemapView)
+	rg.GET("sitemap/:name", mdw.BindUriMiddleware[seo_api.SitemapPageReq], mdw.CacheMiddleware(redis_cache.NewSitemapCacheOption()), app.SitemapPageView)
+}
@@ -16,9 +16,20 @@ func UserRouter(rg *gin.RouterGroup) {
 	rg.POST("user/send_email", mdw.BindJsonMiddleware[user_api.SendEmailReq], mdw.CaptchaMiddleware, app.SendEmailView)
 	rg.POST("user/register_email", mdw.BindJsonMiddleware[user_api.RegisterEmailReq], mdw.EmailRegisterMiddleware, mdw.CaptchaMiddleware, mdw.EmailVerifyMiddleware, mdw.RegisterVerifyMiddleware, app.RegisterEmailView)
 	rg.POST("user/login", mdw.BindJsonMiddleware[user_api.PwdLoginReq], mdw.UsernamePwdLoginMiddleware, mdw.CaptchaMiddleware, app.PwdLoginView)
+	rg.POST("user/qq_login", mdw.BindJsonMiddleware[user_api.QQLoginReq], mdw.QQLoginMiddleware, app.QQLoginView)
+	rg.POST("user/qq_bind", mdw.BindJsonMiddleware[user_api.QQLoginReq], mdw.QQLoginMiddleware, mdw.AuthMiddleware, app.QQBindView)
+	rg.DELETE("user/qq_bind", mdw.AuthMiddleware, app.QQUnbindView)
+	rg.POST("user/login/2fa", mdw.BindJsonMiddleware[user_api.TwoFactorLoginReq], app.TwoFactorLoginView)
+	rg.GET("user/2fa", mdw.AuthMiddleware, app.TwoFactorInfoView)
+	rg.POST("user/2fa/enroll", mdw.AuthMiddleware, app.TwoFactorEnrollView)
+	rg.POST("user/2fa/confirm", mdw.BindJsonMiddleware[user_api.TwoFactorCodeReq], mdw.AuthMiddleware, app.TwoFactorConfirmView)
+	rg.POST("user/2fa/recovery_codes", mdw.BindJsonMiddleware[user_api.TwoFactorCodeReq], mdw.AuthMiddleware, app.TwoFactorRecoveryCodesView)
+	rg.DELETE("user/2fa", mdw.BindJsonMiddleware[user_api.TwoFactorCodeReq], mdw.AuthMiddleware, app.TwoFactorDisableView)
+	rg.DELETE("user/2fa/reset", mdw.BindJsonMiddleware[models.IDRequest], mdw.AdminMiddleware, app.TwoFactorResetView)
 	rg.GET("user/:id", mdw.BindUriMiddleware[models.IDRequest], mdw.AuthMiddleware, app.UserDetailView)
 	rg.GET("user/brief", mdw.BindQueryMiddleware[models.IDRequest], app.UserBriefInfoView)
 	rg.GET("user/login_list", mdw.BindQueryMiddleware[user_api.UserLoginListReq], mdw.AuthMiddleware, app.UserLoginListView)
+	rg.GET("user/mention", mdw.BindQueryMiddleware[user_api.UserMentionReq], mdw.AuthMiddleware, app.UserMentionView)
 	rg.GET("user/list", mdw.BindQueryMiddleware[user_api.UserListReq], mdw.AdminMiddleware, app.UserListView)
 	rg.PUT("user/password", mdw.BindJsonMiddleware[user_api.ChangePasswordReq], mdw.AuthMiddleware, app.ChangePasswordView)
 	rg.PUT("user/pwd/reset", mdw.BindJsonMiddleware[user_api.ResetPasswordReq], mdw.CaptchaMiddleware, mdw.EmailVerifyMiddleware, app.ResetPasswordView)
@@ -26,4 +37,9 @@ func UserRouter(rg *gin.RouterGroup) {
 	rg.PUT("user/update", mdw.BindJsonMiddleware[user_api.UserInfoUpdateReq], mdw.AuthMiddleware, app.UserInfoUpdateView)
 	rg.PUT("user/admin_update", mdw.BindJsonMiddleware[user_api.AdminUpdateUserReq], mdw.AdminMiddleware, app.AdminUpdateUserView)
 	rg.DELETE("user/logout", mdw.AuthMiddleware, app.UserLogoutView)
+	rg.POST("user/refresh", mdw.BindJsonMiddleware[user_api.RefreshTokenReq], app.RefreshTokenView)
+	rg.GET("user/session", mdw.BindQueryMiddleware[user_api.UserSessionListReq], mdw.AuthMiddleware, app.UserSessionListView)
+	rg.DELETE("user/session", mdw.BindJsonMiddleware[models.IDRequest], mdw.AuthMiddleware, app.UserSessionRemoveView)
+	rg.DELETE("user/session/others", mdw.AuthMiddleware, app.UserSessionRemoveOthersView)
+	rg.DELETE("user/session/all", mdw.BindJsonMiddleware[models.IDRequest], mdw.AdminMiddleware, app.UserSessionRemoveAllView)
 }
@@ -0,0 +1,113 @@
+// Path: ./service/access_token_service/enter.go
+
+package access_token_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Prefix 访问令牌的前缀，和 jwt 区分开，泄露后也方便扫描出来
+const Prefix = "bxp_"
+
+// MaxPerUser 每个用户最多的有效令牌数
+const MaxPerUser = 20
+
+// lastUsedInterval 最后使用时间的更新间隔，不用每个请求都写库
+const lastUsedInterval = time.Minute
+
+// IsAccessToken 请求带的是不是访问令牌
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Generate 生成新的令牌，明文只在创建时返回一次
+func Generate() (token string, err error) {
+	b := make([]byte, 20)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	return Prefix + hex.EncodeToString(b), nil
+}
+
+// Hash 令牌是随机生成的，不需要慢哈希
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidScopes 创建令牌时校验权限范围
+func ValidScopes(scopes []enum.AccessScope) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, s := range scopes {
+		if !slices.Contains(enum.AccessScopeList, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Allow 令牌能不能访问这个接口
+// 路由声明了权限的要有这个权限；没有声明的只有只读令牌可以访问 GET 请求，修改密码等操作不能用令牌
+func Allow(scopes []enum.AccessScope, method string, required enum.AccessScope) bool {
+	if required != "" {
+		return slices.Contains(scopes, required)
+	}
+	return (method == http.MethodGet || method == http.MethodHead) && slices.Contains(scopes, enum.ScopeRead)
+}
+
+// Verify 校验令牌，返回令牌和所属的用户
+func Verify(token, ip string) (t models.AccessTokenModel, user models.UserModel, err error) {
+	if err = global.DB.Take(&t, "token_hash = ?", Hash(token)).Error; err != nil {
+		return t, user, errors.New("访问令牌无效")
+	}
+	if t.RevokedAt != nil {
+		return t, user, errors.New("访问令牌已撤销")
+	}
+	if t.ExpiresAt != nil && t.ExpiresAt.Before(time.Now()) {
+		return t, user, errors.New("访问令牌已过期")
+	}
+	if err = global.DB.Take(&user, t.UserID).Error; err != nil {
+		return t, user, errors.New("用户不存在")
+	}
+	if user.IsBanned() {
+		return t, user, errors.New("账号封禁中")
+	}
+
+	if t.LastUsedAt == nil || time.Since(*t.LastUsedAt) > lastUsedInterval {
+		global.DB.Model(&t).Updates(map[string]any{
+			"last_used_at": time.Now(),
+			"last_used_ip": ip,
+		})
+	}
+	return
+}
+
+// Revoke 撤销令牌，带上未撤销的条件
+func Revoke(t *models.AccessTokenModel) error {
+	result := global.DB.Model(t).Where("revoked_at is null").Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("令牌已撤销")
+	}
+	return nil
+}
+
+// RevokeAll 撤销用户所有的令牌，修改密码、下线所有设备和封禁时调用
+func RevokeAll(userID uint) error {
+	return global.DB.Model(&models.AccessTokenModel{}).Where("user_id = ? and revoked_at is null", userID).
+		Update("revoked_at", time.Now()).Error
+}
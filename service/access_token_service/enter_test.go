@@ -0,0 +1,111 @@
+// Path: ./service/access_token_service/enter_test.go
+
+package access_token_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"net/http"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	a, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Generate()
+	if !IsAccessToken(a) || len(a) != len(Prefix)+40 {
+		t.Errorf("Generate() = %s", a)
+	}
+	if a == b || Hash(a) == Hash(b) {
+		t.Error("两次生成的令牌不应相同")
+	}
+	if Hash(a) != Hash(a) || len(Hash(a)) != 64 {
+		t.Errorf("Hash() = %s", Hash(a))
+	}
+	if IsAccessToken("eyJhbGciOiJIUzI1NiJ9.x.y") {
+		t.Error("jwt 不是访问令牌")
+	}
+}
+
+func TestValidScopes(t *testing.T) {
+	tests := []struct {
+		scopes []enum.AccessScope
+		want   bool
+	}{
+		{nil, false},
+		{[]enum.AccessScope{enum.ScopeRead}, true},
+		{[]enum.AccessScope{enum.ScopeArticleWrite, enum.ScopeImageUpload}, true},
+		{[]enum.AccessScope{enum.ScopeRead, "admin"}, false},
+	}
+	for _, tt := range tests {
+		if got := ValidScopes(tt.scopes); got != tt.want {
+			t.Errorf("ValidScopes(%v) = %v, want %v", tt.scopes, got, tt.want)
+		}
+	}
+}
+
+func TestAllow(t *testing.T) {
+	read := []enum.AccessScope{enum.ScopeRead}
+	write := []enum.AccessScope{enum.ScopeArticleWrite}
+	tests := []struct {
+		name     string
+		scopes   []enum.AccessScope
+		method   string
+		required enum.AccessScope
+		want     bool
+	}{
+		{"只读访问 GET", read, http.MethodGet, "", true},
+		{"只读不能修改", read, http.MethodPost, "", false},
+		{"只读不能发文章", read, http.MethodPost, enum.ScopeArticleWrite, false},
+		{"发文章", write, http.MethodPost, enum.ScopeArticleWrite, true},
+		{"没有只读权限不能 GET", write, http.MethodGet, "", false},
+		{"没有声明权限的修改接口", write, http.MethodPut, "", false},
+		{"权限不对", write, http.MethodPost, enum.ScopeImageUpload, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allow(tt.scopes, tt.method, tt.required); got != tt.want {
+				t.Errorf("Allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	testutil.Setup(t, &models.UserModel{}, &models.AccessTokenModel{})
+	global.DB.Create(&[]models.UserModel{
+		{Username: "a", Email: "a@example.com"},
+		{Username: "b", Email: "b@example.com"},
+	})
+	tokens := make([]string, 3)
+	for i := range tokens {
+		tokens[i], _ = Generate()
+		global.DB.Create(&models.AccessTokenModel{
+			UserID:    uint(i/2 + 1), // 前两个属于用户 1，最后一个属于用户 2
+			Name:      "ci",
+			TokenHash: Hash(tokens[i]),
+			Scopes:    []enum.AccessScope{enum.ScopeRead},
+		})
+	}
+	for _, token := range tokens {
+		if _, _, err := Verify(token, "127.0.0.1"); err != nil {
+			t.Fatalf("撤销前 Verify() err = %v", err)
+		}
+	}
+
+	if err := RevokeAll(1); err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range tokens[:2] {
+		if _, _, err := Verify(token, "127.0.0.1"); err == nil {
+			t.Error("撤销后的令牌不能再用")
+		}
+	}
+	if _, _, err := Verify(tokens[2], "127.0.0.1"); err != nil {
+		t.Errorf("其他用户的令牌不受影响, err = %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+// Path: ./service/archive_service/enter.go
+
+// Package archive_service 文章批量导入导出，格式为 Hexo / Hugo 的目录结构打包成的 zip
+package archive_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Format 导出的目录结构
+type Format string
+
+const (
+	FormatHexo Format = "hexo" // source/_posts  source/_drafts  source/images
+	FormatHugo Format = "hugo" // content/posts  static/images
+)
+
+const (
+	maxArchiveFiles    = 5000
+	maxMarkdownSize    = 10 << 20  // 单篇文章 10MB
+	maxUncompressedAll = 512 << 20 // 解压后总大小
+)
+
+// archive 压缩包中的文件，按路径索引，图片按需读取
+type archive struct {
+	files map[string]*zip.File
+	paths []string
+}
+
+func openArchive(data []byte) (*archive, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("压缩包读取失败: %w", err)
+	}
+	if len(r.File) > maxArchiveFiles {
+		return nil, fmt.Errorf("压缩包中的文件不能超过 %d 个", maxArchiveFiles)
+	}
+
+	a := &archive{files: map[string]*zip.File{}}
+	var total uint64
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.ReplaceAll(f.Name, "\\", "/"))
+		if skipArchivePath(name) {
+			continue
+		}
+		total += f.UncompressedSize64
+		if total > maxUncompressedAll {
+			return nil, errors.New("压缩包解压后过大")
+		}
+		a.files[name] = f
+		a.paths = append(a.paths, name)
+	}
+	return a, nil
+}
+
+// skipArchivePath 系统生成的文件、主题、生成的站点等不需要处理
+func skipArchivePath(name string) bool {
+	if strings.HasPrefix(name, "../") || strings.HasPrefix(name, "/") {
+		return true
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == "__MACOSX" || part == "node_modules" || part == "themes" || part == "public" ||
+			(strings.HasPrefix(part, ".") && part != ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *archive) read(name string, limit int64) ([]byte, error) {
+	f, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("文件 %s 不存在", name)
+	}
+	if int64(f.UncompressedSize64) > limit {
+		return nil, fmt.Errorf("文件 %s 过大", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, limit+1))
+}
+
+// markdownFiles 需要导入的文章
+// 能识别出 Hexo（_posts / _drafts）或 Hugo（content）的结构时只导入其中的文章，否则导入所有 markdown 文件
+func (a *archive) markdownFiles() (list []string) {
+	var all, hexo, hugo []string
+	for _, name := range a.paths {
+		ext := strings.ToLower(path.Ext(name))
+		if ext != ".md" && ext != ".markdown" {
+			continue
+		}
+		all = append(all, name)
+		if isHexoPost(name) {
+			hexo = append(hexo, name)
+		}
+		// Hugo 的 _index.md 是栏目页
+		if hasDir(name, "content") && path.Base(name) != "_index.md" {
+			hugo = append(hugo, name)
+		}
+	}
+	switch {
+	case len(hexo) > 0:
+		return hexo
+	case len(hugo) > 0:
+		return hugo
+	}
+	return all
+}
+
+// findImage 在压缩包中找到文章引用的图片
+// 相对路径先按文章所在目录找，再按 Hexo 的资源文件夹（与文章同名的目录）找
+// 绝对路径（如 /images/a.png）对应 Hexo 的 source 或 Hugo 的 static 目录，按后缀匹配
+func (a *archive) findImage(mdName, src string) (string, bool) {
+	src = cleanImagePath(src)
+	if src == "" {
+		return "", false
+	}
+	dir := path.Dir(mdName)
+	if !strings.HasPrefix(src, "/") {
+		stem := strings.TrimSuffix(path.Base(mdName), path.Ext(mdName))
+		for _, name := range []string{path.Join(dir, src), path.Join(dir, stem, src)} {
+			if _, ok := a.files[name]; ok {
+				return name, true
+			}
+		}
+	}
+
+	suffix := "/" + strings.TrimPrefix(path.Clean("/"+src), "/")
+	var found string
+	for _, name := range a.paths {
+		if "/"+name != suffix && !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		if found == "" || len(name) < len(found) {
+			found = name
+		}
+	}
+	return found, found != ""
+}
+
+func isHexoPost(name string) bool {
+	return hasDir(name, "_posts") || hasDir(name, "_drafts")
+}
+
+func isHexoDraft(name string) bool {
+	return hasDir(name, "_drafts")
+}
+
+func hasDir(name, dir string) bool {
+	return strings.HasPrefix(name, dir+"/") || strings.Contains(name, "/"+dir+"/")
+}
@@ -0,0 +1,179 @@
+package archive_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMarkdownYAML(t *testing.T) {
+	data := "\xef\xbb\xbf---\r\ntitle: Hello\r\ndate: 2020-01-02 03:04:05\r\ntags: go\r\ncategories:\r\n  - [Tech, Go]\r\n  - Life\r\npublished: false\r\n---\r\n\r\nbody <!-- more --> rest\r\n"
+	fm, body, err := ParseMarkdown([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.Title != "Hello" || !fm.Draft {
+		t.Fatalf("unexpected front matter %+v", fm)
+	}
+	if fm.Date.Format("2006-01-02 15:04:05") != "2020-01-02 03:04:05" {
+		t.Fatalf("date = %v", fm.Date)
+	}
+	if !reflect.DeepEqual(fm.Tags, []string{"go"}) || !reflect.DeepEqual(fm.Categories, []string{"Tech", "Go", "Life"}) {
+		t.Fatalf("tags = %v categories = %v", fm.Tags, fm.Categories)
+	}
+	if body != "body <!-- more --> rest\n" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestParseMarkdownTOML(t *testing.T) {
+	data := "+++\ntitle = \"Hugo\"\ndate = 2021-05-06T07:08:09+08:00\ndraft = true\ntags = [\"a\", \"b\"]\n+++\ncontent"
+	fm, body, err := ParseMarkdown([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.Title != "Hugo" || !fm.Draft || len(fm.Tags) != 2 || fm.Date.IsZero() {
+		t.Fatalf("unexpected front matter %+v", fm)
+	}
+	if body != "content" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestParseMarkdownNoFrontMatter(t *testing.T) {
+	fm, body, err := ParseMarkdown([]byte("# title\n"))
+	if err != nil || fm.Title != "" || body != "# title\n" {
+		t.Fatalf("fm = %+v body = %q err = %v", fm, body, err)
+	}
+	if _, _, err = ParseMarkdown([]byte("---\ntitle: x\n")); err == nil {
+		t.Fatal("expect error for unterminated front matter")
+	}
+}
+
+func TestRenderMarkdownRoundTrip(t *testing.T) {
+	date := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	fm := FrontMatter{
+		Title:      "a: b",
+		Date:       date,
+		Updated:    date.Add(time.Hour),
+		Tags:       []string{"x", "y"},
+		Categories: []string{"c"},
+		Draft:      true,
+	}
+	byteData, err := RenderMarkdown(fm, "hello", FormatHugo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(byteData), "lastmod:") || strings.Contains(string(byteData), "updated:") {
+		t.Fatalf("hugo front matter should use lastmod:\n%s", byteData)
+	}
+	got, body, err := ParseMarkdown(byteData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != fm.Title || !got.Date.Equal(fm.Date) || !got.Updated.Equal(fm.Updated) || !got.Draft ||
+		!reflect.DeepEqual(got.Tags, fm.Tags) || !reflect.DeepEqual(got.Categories, fm.Categories) {
+		t.Fatalf("round trip mismatch %+v", got)
+	}
+	if body != "hello\n" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestReplaceImages(t *testing.T) {
+	body := `![a](img/a.png "t") ![b](<my pic.png>) ![c](https://x.com/c.png) <img src="/images/d.png" alt="d"> {% asset_img e.png "E" %}`
+	var seen []string
+	got := ReplaceImages(body, func(src string) (string, bool) {
+		seen = append(seen, src)
+		if !isLocalImage(src) {
+			return "", false
+		}
+		return "/uploads/" + src, true
+	})
+	for _, s := range []string{`![a](/uploads/img/a.png "t")`, `![b](/uploads/my pic.png)`, `![c](https://x.com/c.png)`, `![E](/uploads/e.png)`, `<img src="/uploads//images/d.png"`} {
+		if !strings.Contains(got, s) {
+			t.Fatalf("missing %s in %s", s, got)
+		}
+	}
+	if len(seen) != 5 {
+		t.Fatalf("seen = %v", seen)
+	}
+}
+
+func testArchive(t *testing.T, files ...string) *archive {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(name))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	a, err := openArchive(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestArchiveHexo(t *testing.T) {
+	a := testArchive(t,
+		"blog/source/_posts/hello.md",
+		"blog/source/_posts/hello/pic.png",
+		"blog/source/_drafts/wip.md",
+		"blog/source/about/index.md",
+		"blog/source/images/logo.png",
+		"blog/themes/next/README.md",
+		"__MACOSX/blog/source/_posts/._hello.md",
+	)
+	if got := a.markdownFiles(); !reflect.DeepEqual(got, []string{"blog/source/_posts/hello.md", "blog/source/_drafts/wip.md"}) {
+		t.Fatalf("markdown files = %v", got)
+	}
+	if !isHexoDraft("blog/source/_drafts/wip.md") {
+		t.Fatal("expect draft")
+	}
+	// 资源文件夹
+	if name, ok := a.findImage("blog/source/_posts/hello.md", "pic.png"); !ok || name != "blog/source/_posts/hello/pic.png" {
+		t.Fatalf("asset folder image = %s", name)
+	}
+	// 站点根目录
+	if name, ok := a.findImage("blog/source/_posts/hello.md", "/images/logo.png?v=1"); !ok || name != "blog/source/images/logo.png" {
+		t.Fatalf("absolute image = %s", name)
+	}
+	if _, ok := a.findImage("blog/source/_posts/hello.md", "missing.png"); ok {
+		t.Fatal("expect not found")
+	}
+}
+
+func TestArchiveHugo(t *testing.T) {
+	a := testArchive(t,
+		"content/_index.md",
+		"content/posts/bundle/index.md",
+		"content/posts/bundle/my%20pic.png",
+		"content/posts/bundle/a b.png",
+		"static/images/x.png",
+		"README.md",
+	)
+	if got := a.markdownFiles(); !reflect.DeepEqual(got, []string{"content/posts/bundle/index.md"}) {
+		t.Fatalf("markdown files = %v", got)
+	}
+	if name, ok := a.findImage("content/posts/bundle/index.md", "a%20b.png"); !ok || name != "content/posts/bundle/a b.png" {
+		t.Fatalf("bundle image = %s", name)
+	}
+	if name, ok := a.findImage("content/posts/bundle/index.md", "/images/x.png"); !ok || name != "static/images/x.png" {
+		t.Fatalf("static image = %s", name)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	if got := slug("Hello, 世界! Go/Zip "); got != "Hello-世界-Go-Zip" {
+		t.Fatalf("slug = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+// Path: ./service/archive_service/export.go
+
+package archive_service
+
+import (
+	"archive/zip"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// Export 把 uid 的所有文章（不含回收站）导出为 Hexo / Hugo 目录结构的 zip
+// 未发布的文章作为草稿导出，本地上传的图片一起打包，云端的图片保留原地址
+func Export(uid uint, format Format) ([]byte, error) {
+	var list []models.ArticleModel
+	err := global.DB.Preload("CategoryModel").Where("user_id = ?", uid).Order("id asc").Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	postDir, draftDir, imageDir := "source/_posts", "source/_drafts", "source/images"
+	if format == FormatHugo {
+		postDir, draftDir, imageDir = "content/posts", "content/posts", "static/images"
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	images := map[string]string{} // 本地图片路径 -> 导出后的引用地址
+	localImage := func(src string) (string, bool) {
+		// 正文是用户写的，先规范化路径，只允许读取 uploads 目录下的文件
+		p := strings.TrimPrefix(path.Clean("/"+cleanImagePath(src)), "/")
+		if !strings.HasPrefix(p, "uploads/") {
+			return "", false
+		}
+		if dst, ok := images[p]; ok {
+			return dst, true
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return "", false
+		}
+		name := path.Base(p)
+		f, err := w.Create(path.Join(imageDir, name))
+		if err != nil {
+			return "", false
+		}
+		if _, err = f.Write(data); err != nil {
+			return "", false
+		}
+		images[p] = "/images/" + name
+		return images[p], true
+	}
+
+	for _, a := range list {
+		draft := a.Status != enum.ArticleStatusPublish
+		fm := FrontMatter{
+			Title:       a.Title,
+			Date:        a.CreatedAt,
+			Updated:     a.UpdatedAt,
+			Tags:        a.Tags,
+			Draft:       draft,
+			Description: a.Abstract,
+			Cover:       a.CoverURL,
+		}
+		if a.CategoryModel != nil {
+			fm.Categories = []string{a.CategoryModel.Name}
+		}
+		if dst, ok := localImage(fm.Cover); ok {
+			fm.Cover = dst
+		}
+		body := ReplaceImages(a.Content, localImage)
+
+		byteData, err := RenderMarkdown(fm, body, format)
+		if err != nil {
+			return nil, err
+		}
+		dir := postDir
+		if draft {
+			dir = draftDir
+		}
+		f, err := w.Create(path.Join(dir, fmt.Sprintf("%d-%s.md", a.ID, slug(a.Title))))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = f.Write(byteData); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// slug 文件名只保留字母、数字（含中文），其余替换为 -
+func slug(title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range title {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(truncate(sb.String(), 50), "-")
+}
@@ -0,0 +1,198 @@
+// Path: ./service/archive_service/front_matter.go
+
+package archive_service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"time"
+)
+
+// FrontMatter Hexo / Hugo 文章头部的元信息，只保留 BlogX 用得到的字段
+type FrontMatter struct {
+	Title       string
+	Date        time.Time
+	Updated     time.Time
+	Tags        []string
+	Categories  []string // Hexo 的多级分类会被展开，导入时只取第一个
+	Draft       bool
+	Description string
+	Cover       string
+}
+
+// dateLayouts 常见的日期写法，没有时区的按本地时间处理
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// ParseMarkdown 拆分头部和正文，支持 YAML（---）和 TOML（+++）两种写法，没有头部时整个文件都是正文
+func ParseMarkdown(data []byte) (fm FrontMatter, body string, err error) {
+	// 去掉 BOM，统一换行
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var delim string
+	switch {
+	case strings.HasPrefix(content, "---\n"):
+		delim = "---"
+	case strings.HasPrefix(content, "+++\n"):
+		delim = "+++"
+	default:
+		return fm, content, nil
+	}
+
+	rest := content[len(delim)+1:]
+	var head string
+	if strings.HasPrefix(rest, delim+"\n") || rest == delim {
+		// 空头部
+		head, body = "", strings.TrimPrefix(strings.TrimPrefix(rest, delim), "\n")
+	} else {
+		end := strings.Index(rest, "\n"+delim+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delim) {
+				return fm, "", errors.New("文章头部没有结束标记")
+			}
+			end = len(rest) - len(delim) - 1
+		}
+		head = rest[:end]
+		body = rest[min(len(rest), end+len(delim)+2):]
+	}
+
+	m := map[string]any{}
+	if delim == "---" {
+		err = yaml.Unmarshal([]byte(head), &m)
+	} else {
+		_, err = toml.Decode(head, &m)
+	}
+	if err != nil {
+		return fm, "", fmt.Errorf("文章头部解析失败: %w", err)
+	}
+
+	fm.Title = toString(m["title"])
+	fm.Date = toTime(m["date"])
+	fm.Updated = toTime(firstOf(m, "updated", "lastmod"))
+	fm.Tags = toStrings(m["tags"])
+	fm.Categories = toStrings(firstOf(m, "categories", "category"))
+	fm.Description = toString(firstOf(m, "description", "summary", "excerpt"))
+	fm.Cover = toString(firstOf(m, "cover", "image", "thumbnail"))
+	if draft, ok := m["draft"].(bool); ok {
+		fm.Draft = draft
+	}
+	// Hexo 用 published: false 表示草稿
+	if published, ok := m["published"].(bool); ok && !published {
+		fm.Draft = true
+	}
+	return fm, strings.TrimLeft(body, "\n"), nil
+}
+
+// exportFrontMatter 导出时头部字段的顺序，Hexo 用 updated，Hugo 用 lastmod
+type exportFrontMatter struct {
+	Title       string    `yaml:"title"`
+	Date        time.Time `yaml:"date"`
+	Updated     time.Time `yaml:"updated,omitempty"`
+	Lastmod     time.Time `yaml:"lastmod,omitempty"`
+	Tags        []string  `yaml:"tags,omitempty"`
+	Categories  []string  `yaml:"categories,omitempty"`
+	Draft       bool      `yaml:"draft,omitempty"`
+	Description string    `yaml:"description,omitempty"`
+	Cover       string    `yaml:"cover,omitempty"`
+}
+
+// RenderMarkdown 生成带 YAML 头部的 markdown 文件
+func RenderMarkdown(fm FrontMatter, body string, format Format) ([]byte, error) {
+	efm := exportFrontMatter{
+		Title:       fm.Title,
+		Date:        fm.Date,
+		Tags:        fm.Tags,
+		Categories:  fm.Categories,
+		Draft:       fm.Draft,
+		Description: fm.Description,
+		Cover:       fm.Cover,
+	}
+	if format == FormatHugo {
+		efm.Lastmod = fm.Updated
+	} else {
+		efm.Updated = fm.Updated
+	}
+	head, err := yaml.Marshal(efm)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(head)
+	buf.WriteString("---\n\n")
+	buf.WriteString(body)
+	if !strings.HasSuffix(body, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func firstOf(m map[string]any, keys ...string) any {
+	for _, k := range keys {
+		if v, ok := m[k]; ok && v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func toString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(val)
+	case time.Time:
+		return val.Format(time.RFC3339)
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+// toStrings 标签、分类可以是单个字符串，也可以是列表，Hexo 的多级分类是嵌套列表
+func toStrings(v any) (list []string) {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, item := range val {
+			list = append(list, toStrings(item)...)
+		}
+		return list
+	case []string:
+		for _, item := range val {
+			list = append(list, toStrings(item)...)
+		}
+		return list
+	}
+	if s := toString(v); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+func toTime(v any) time.Time {
+	switch val := v.(type) {
+	case time.Time:
+		return val
+	case string:
+		s := strings.TrimSpace(val)
+		for _, layout := range dateLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
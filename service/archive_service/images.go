@@ -0,0 +1,63 @@
+// Path: ./service/archive_service/images.go
+
+package archive_service
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ![alt](src "title")
+	mdImageRegexp = regexp.MustCompile(`(!\[[^\]]*\]\()\s*(<[^>]+>|[^)\s]+)((?:\s+"[^"]*")?\s*\))`)
+	// <img src="...">
+	htmlImageRegexp = regexp.MustCompile(`(<img\s[^>]*?src\s*=\s*["'])([^"']+)(["'])`)
+	// Hexo 的标签插件 {% asset_img slug.png "alt" %}
+	assetImgRegexp = regexp.MustCompile(`\{%\s*asset_img\s+(\S+)(?:\s+"?([^"%]*?)"?)?\s*%\}`)
+)
+
+// ReplaceImages 替换正文中所有图片的地址，replace 返回 false 时保持原样
+// Hexo 的 asset_img 会先转为普通的 markdown 图片
+func ReplaceImages(body string, replace func(src string) (string, bool)) string {
+	body = assetImgRegexp.ReplaceAllString(body, "![$2]($1)")
+
+	body = mdImageRegexp.ReplaceAllStringFunc(body, func(s string) string {
+		sub := mdImageRegexp.FindStringSubmatch(s)
+		src := strings.TrimSuffix(strings.TrimPrefix(sub[2], "<"), ">")
+		if dst, ok := replace(src); ok {
+			return sub[1] + dst + sub[3]
+		}
+		return s
+	})
+	return htmlImageRegexp.ReplaceAllStringFunc(body, func(s string) string {
+		sub := htmlImageRegexp.FindStringSubmatch(s)
+		if dst, ok := replace(sub[2]); ok {
+			return sub[1] + dst + sub[3]
+		}
+		return s
+	})
+}
+
+// isLocalImage 是否为压缩包内的图片，网络图片和 data url 不处理
+func isLocalImage(src string) bool {
+	if src == "" || strings.HasPrefix(src, "//") || strings.HasPrefix(src, "data:") {
+		return false
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" && u.Host == ""
+}
+
+// cleanImagePath 去掉查询参数和锚点，还原转义的字符（如空格）
+func cleanImagePath(src string) string {
+	if i := strings.IndexAny(src, "?#"); i >= 0 {
+		src = src[:i]
+	}
+	if s, err := url.PathUnescape(src); err == nil {
+		src = s
+	}
+	return src
+}
@@ -0,0 +1,258 @@
+// Path: ./service/archive_service/import.go
+
+package archive_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
+	"blogX_server/service/image_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/markdown"
+	"blogX_server/utils/xss"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated ImportStatus = "created"
+	ImportStatusSkipped ImportStatus = "skipped" // 已有同名文章
+	ImportStatusFailed  ImportStatus = "failed"
+)
+
+// ImportResult 每篇文章的导入结果
+type ImportResult struct {
+	File      string       `json:"file"`
+	Title     string       `json:"title"`
+	ArticleID uint         `json:"articleID,omitempty"`
+	Status    ImportStatus `json:"status"`
+	Images    int          `json:"images"` // 上传的图片数
+	Msg       string       `json:"msg,omitempty"`
+}
+
+// excerptSeparator Hexo / Hugo 的摘要分隔符
+const excerptSeparator = "<!--more-->"
+
+// Import 把压缩包中的文章导入到 uid 名下
+// 草稿导入为草稿，其余的和发布文章一样，开启免审核时直接发布，否则进入审核
+// 已有同名文章的跳过，所以导入失败后可以修正压缩包重新导入
+func Import(data []byte, uid uint) (list []ImportResult, err error) {
+	a, err := openArchive(data)
+	if err != nil {
+		return nil, err
+	}
+	files := a.markdownFiles()
+	if len(files) == 0 {
+		return nil, errors.New("压缩包中没有 markdown 文件")
+	}
+
+	var published bool
+	uploaded := map[string]string{} // 压缩包中的图片 -> 上传后的地址，多篇文章引用同一张图片只上传一次
+	categories := map[string]*uint{}
+	for _, name := range files {
+		r, status := importOne(a, name, uid, uploaded, categories)
+		if status == enum.ArticleStatusPublish {
+			published = true
+		}
+		list = append(list, r)
+	}
+	if published {
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	}
+	return list, nil
+}
+
+// importOne 导入一篇文章，status 为创建后文章的状态
+func importOne(a *archive, name string, uid uint, uploaded map[string]string, categories map[string]*uint) (r ImportResult, status enum.ArticleStatus) {
+	r = ImportResult{File: name, Status: ImportStatusFailed}
+
+	byteData, err := a.read(name, maxMarkdownSize)
+	if err != nil {
+		r.Msg = err.Error()
+		return
+	}
+	fm, body, err := ParseMarkdown(byteData)
+	if err != nil {
+		r.Msg = err.Error()
+		return
+	}
+
+	// 没有标题时用文件名，Hugo 的页面包（post/index.md）用目录名
+	if fm.Title == "" {
+		base := path.Base(name)
+		fm.Title = strings.TrimSuffix(base, path.Ext(base))
+		if fm.Title == "index" {
+			fm.Title = path.Base(path.Dir(name))
+		}
+	}
+	fm.Title = truncate(fm.Title, 128)
+	r.Title = fm.Title
+
+	var count int64
+	global.DB.Model(&models.ArticleModel{}).Where("user_id = ? and title = ?", uid, fm.Title).Count(&count)
+	if count > 0 {
+		r.Status = ImportStatusSkipped
+		r.Msg = "已有同名文章"
+		return
+	}
+
+	// 图片
+	var imageErrs []string
+	upload := func(src string) (string, bool) {
+		if !isLocalImage(src) {
+			return "", false
+		}
+		file, ok := a.findImage(name, src)
+		if !ok {
+			imageErrs = append(imageErrs, fmt.Sprintf("图片 %s 不存在", src))
+			return "", false
+		}
+		if u, ok := uploaded[file]; ok {
+			return u, true
+		}
+		imgData, err := a.read(file, int64(global.Config.Upload.ImageSizeLimit)<<20)
+		if err != nil {
+			imageErrs = append(imageErrs, err.Error())
+			return "", false
+		}
+		model, _, err := image_service.Upload(imgData, imageFilename(file), "import", uid)
+		if err != nil {
+			imageErrs = append(imageErrs, fmt.Sprintf("图片 %s 上传失败: %s", src, err.Error()))
+			return "", false
+		}
+		uploaded[file] = image_service.URL(model)
+		r.Images++
+		return uploaded[file], true
+	}
+	body = ReplaceImages(body, upload)
+	if fm.Cover != "" {
+		if u, ok := upload(fm.Cover); ok {
+			fm.Cover = u
+		}
+	}
+
+	// 摘要：头部的描述，或者摘要分隔符之前的内容，都没有就取正文前 100 字
+	body = strings.ReplaceAll(body, "<!-- more -->", excerptSeparator)
+	abstract := fm.Description
+	if abstract == "" {
+		if i := strings.Index(body, excerptSeparator); i > 0 {
+			abstract = body[:i]
+		} else {
+			abstract = body
+		}
+	}
+	body = xss.Filter(body)
+	abstract, err = markdown.ExtractContent(xss.Filter(abstract), 100)
+	if err != nil {
+		r.Msg = err.Error()
+		return
+	}
+	// 敏感词和发布文章时一样：有拦截词的不导入，需要审核的词不走自动审核
+	review, err := sensitive_service.Filter(&fm.Title, &abstract, &body)
+	if err != nil {
+		r.Msg = err.Error()
+		return
+	}
+	r.Title = fm.Title
+
+	// 分类
+	var categoryID *uint
+	if len(fm.Categories) > 0 {
+		categoryID, err = findOrCreateCategory(uid, fm.Categories[0], categories)
+		if err != nil {
+			r.Msg = err.Error()
+			return
+		}
+	}
+
+	status = enum.ArticleStatusDraft
+	if !fm.Draft && !isHexoDraft(name) {
+		status = enum.ArticleStatusReview
+		if global.Config.Site.Article.AutoApprove && !review {
+			status = enum.ArticleStatusPublish
+		}
+	}
+
+	article := models.ArticleModel{
+		Title:          fm.Title,
+		Abstract:       abstract,
+		CoverURL:       truncate(fm.Cover, 256),
+		Content:        body,
+		CategoryID:     categoryID,
+		Tags:           ctype.List(fm.Tags),
+		UserID:         uid,
+		Status:         status,
+		OpenForComment: true,
+	}
+	// 保留原来的发布时间
+	if !fm.Date.IsZero() {
+		article.CreatedAt = fm.Date
+		article.UpdatedAt = fm.Date
+	}
+	if !fm.Updated.IsZero() && fm.Updated.After(article.CreatedAt) {
+		article.UpdatedAt = fm.Updated
+	}
+	if article.CreatedAt.After(time.Now()) {
+		article.CreatedAt, article.UpdatedAt = time.Time{}, time.Time{}
+	}
+
+	err = transaction.CreateArticleWithRevisionTx(&article, uid, enum.RevisionSourceImport)
+	if err != nil {
+		r.Msg = err.Error()
+		return r, 0
+	}
+	r.Status = ImportStatusCreated
+	r.ArticleID = article.ID
+	if len(imageErrs) > 0 {
+		r.Msg = strings.Join(imageErrs, "; ")
+	}
+	return
+}
+
+// findOrCreateCategory 分类按名称匹配用户已有的分类，没有就创建
+func findOrCreateCategory(uid uint, name string, cache map[string]*uint) (*uint, error) {
+	name = truncate(name, 32)
+	if id, ok := cache[name]; ok {
+		return id, nil
+	}
+	var cat models.CategoryModel
+	err := global.DB.Take(&cat, "user_id = ? and name = ?", uid, name).Error
+	if err != nil {
+		cat = models.CategoryModel{Name: name, UserID: uid}
+		if err = global.DB.Create(&cat).Error; err != nil {
+			return nil, fmt.Errorf("分类 %s 创建失败: %w", name, err)
+		}
+	}
+	cache[name] = &cat.ID
+	return &cat.ID, nil
+}
+
+// imageFilename 图片表的文件名最长 64
+func imageFilename(name string) string {
+	base := path.Base(name)
+	if utf8.RuneCountInString(base) <= 64 {
+		return base
+	}
+	ext := path.Ext(base)
+	return truncate(strings.TrimSuffix(base, ext), 64-utf8.RuneCountInString(ext)) + ext
+}
+
+// truncate 按字符截断
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,96 @@
+package archive_service
+
+import (
+	"archive/zip"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/testutil"
+	"bytes"
+	"testing"
+)
+
+// zipData 文件名 -> 内容打成压缩包
+func zipData(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImportSensitive(t *testing.T) {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.ArticleRevisionModel{},
+		&models.ArticleReviewModel{},
+		&models.CategoryModel{},
+		&models.SensitiveWordModel{},
+	)
+	global.Config.Site.Article.AutoApprove = true
+	global.DB.Create(&models.UserModel{Username: "author", Email: "author@example.com"})
+	global.DB.Create(&[]models.SensitiveWordModel{
+		{Word: "赌博", Category: "违法", Action: enum.SensitiveActionBlock},
+		{Word: "加微信", Category: "广告", Action: enum.SensitiveActionReview},
+	})
+	if err := sensitive_service.Load(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		global.DB.Where("1 = 1").Delete(&models.SensitiveWordModel{})
+		_ = sensitive_service.Load()
+	})
+
+	list, err := Import(zipData(t, map[string]string{
+		"posts/a.md": "---\ntitle: 正常\n---\n正文",
+		"posts/b.md": "---\ntitle: 拦截\n---\n一起赌博",
+		"posts/c.md": "---\ntitle: 审核\n---\n有事加微信",
+	}), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := map[string]ImportResult{}
+	for _, r := range list {
+		results[r.File] = r
+	}
+
+	want := map[string]struct {
+		result ImportStatus
+		status enum.ArticleStatus
+	}{
+		"posts/a.md": {ImportStatusCreated, enum.ArticleStatusPublish},
+		"posts/b.md": {ImportStatusFailed, 0},
+		"posts/c.md": {ImportStatusCreated, enum.ArticleStatusReview},
+	}
+	for name, w := range want {
+		r := results[name]
+		if r.Status != w.result {
+			t.Errorf("%s 导入结果 = %s, want %s (%s)", name, r.Status, w.result, r.Msg)
+			continue
+		}
+		if r.Status != ImportStatusCreated {
+			continue
+		}
+		var a models.ArticleModel
+		global.DB.Take(&a, r.ArticleID)
+		if a.Status != w.status {
+			t.Errorf("%s 文章 status = %d, want %d", name, a.Status, w.status)
+		}
+	}
+	var count int64
+	global.DB.Model(&models.ArticleModel{}).Count(&count)
+	if count != 2 {
+		t.Errorf("导入了 %d 篇文章，want 2", count)
+	}
+}
@@ -0,0 +1,279 @@
+// Path: ./service/comment_service/get_child_page.go
+
+package comment_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/focus_service"
+	"blogX_server/service/redis_service/redis_comment"
+	"blogX_server/utils"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// 评论树分页：根评论按游标翻页，每条评论只带最早的几条回复，更多的回复再按游标加载
+
+// 根评论的排序方式
+const (
+	SortNew = "new" // 最新
+	SortOld = "old" // 最早
+	SortHot = "hot" // 热门
+)
+
+// hotScoreSQL 热度：点赞数和回复数加权，按发布后的小时数衰减
+// 点赞、回复数用的是库里的值，redis 中还没同步的增量不参与排序
+const hotScoreSQL = "(like_count + reply_count * 2 + 1) / POW(TIMESTAMPDIFF(MINUTE, created_at, ?) / 60 + 2, 1.5)"
+
+// cursor 翻页游标，编码后交给前端，下一页原样传回
+type cursor struct {
+	Sort   string `json:"s"`
+	Time   int64  `json:"t,omitempty"` // 上一页最后一条的创建时间，纳秒
+	ID     uint   `json:"i,omitempty"` // 上一页最后一条的 id，创建时间相同时区分先后
+	Offset int    `json:"o,omitempty"` // 热门排序的分数一直在变，用偏移量翻页
+	Now    int64  `json:"n,omitempty"` // 热门排序第一页的时间，翻页时用同一个时间算分数
+}
+
+func encodeCursor(c cursor) string {
+	byteData, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(byteData)
+}
+
+// decodeCursor 解析游标，空游标表示第一页
+func decodeCursor(s string, sort string) (c cursor, err error) {
+	if s == "" {
+		return cursor{Sort: sort}, nil
+	}
+	byteData, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.New("游标格式错误")
+	}
+	if err = json.Unmarshal(byteData, &c); err != nil {
+		return c, errors.New("游标格式错误")
+	}
+	if c.Sort != sort {
+		return c, errors.New("游标与排序方式不一致")
+	}
+	return c, nil
+}
+
+// afterCursor 按 (created_at, id) 取游标之后的记录，op 为 < 时倒序翻页，> 时正序翻页
+func afterCursor(query *gorm.DB, c cursor, op string) *gorm.DB {
+	if c.ID == 0 {
+		return query
+	}
+	t := time.Unix(0, c.Time)
+	return query.Where("created_at "+op+" ? OR (created_at = ? AND id "+op+" ?)", t, t, c.ID)
+}
+
+// nextCursor 多查了一条时说明还有下一页，返回截断后的列表和下一页的游标
+func nextCursor(list []models.CommentModel, c cursor, limit int) ([]models.CommentModel, string) {
+	if len(list) <= limit {
+		return list, ""
+	}
+	list = list[:limit]
+	if c.Sort == SortHot {
+		c.Offset += limit
+	} else {
+		last := list[limit-1]
+		c.Time = last.CreatedAt.UnixNano()
+		c.ID = last.ID
+	}
+	return list, encodeCursor(c)
+}
+
+// GetPinnedRoots 文章置顶的根评论，后置顶的在前
+func GetPinnedRoots(articleID uint) (list []models.CommentModel, err error) {
+	err = global.DB.Preload("UserModel").
+		Where("article_id = ? AND root_id IS NULL AND status = ? AND pinned_at IS NOT NULL", articleID, enum.CommentStatusPublished).
+		Order("pinned_at DESC").Find(&list).Error
+	return
+}
+
+// GetRootPage 文章的一页已发布根评论，next 为空表示没有下一页
+// 置顶的评论不在分页中，由 GetPinnedRoots 单独取
+func GetRootPage(articleID uint, sort string, cur string, limit int) (list []models.CommentModel, next string, err error) {
+	c, err := decodeCursor(cur, sort)
+	if err != nil {
+		return
+	}
+	query := global.DB.Preload("UserModel").
+		Where("article_id = ? AND root_id IS NULL AND status = ? AND pinned_at IS NULL", articleID, enum.CommentStatusPublished)
+	switch sort {
+	case SortHot:
+		if c.Now == 0 {
+			c.Now = time.Now().Unix()
+		}
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                hotScoreSQL + " DESC, id DESC",
+			Vars:               []any{time.Unix(c.Now, 0)},
+			WithoutParentheses: true,
+		}}).Offset(c.Offset)
+	case SortOld:
+		query = afterCursor(query, c, ">").Order("created_at ASC, id ASC")
+	default:
+		query = afterCursor(query, c, "<").Order("created_at DESC, id DESC")
+	}
+	err = query.Limit(limit + 1).Find(&list).Error
+	if err != nil {
+		return
+	}
+	list, next = nextCursor(list, c, limit)
+	return
+}
+
+// GetChildPage 评论的一页已发布直接回复，按时间正序，用于"加载更多回复"
+func GetChildPage(parentID uint, cur string, limit int) (list []models.CommentModel, next string, err error) {
+	c, err := decodeCursor(cur, SortOld)
+	if err != nil {
+		return
+	}
+	query := global.DB.Preload("UserModel").
+		Where("parent_id = ? AND status = ?", parentID, enum.CommentStatusPublished)
+	err = afterCursor(query, c, ">").Order("created_at ASC, id ASC").Limit(limit + 1).Find(&list).Error
+	if err != nil {
+		return
+	}
+	list, next = nextCursor(list, c, limit)
+	return
+}
+
+// CountChildren 每条评论已发布的直接回复数
+func CountChildren(idList []uint) map[uint]int {
+	var rows []struct {
+		ParentID uint
+		Count    int
+	}
+	global.DB.Model(&models.CommentModel{}).Select("parent_id, COUNT(*) AS count").
+		Where("parent_id IN ? AND status = ?", idList, enum.CommentStatusPublished).
+		Group("parent_id").Scan(&rows)
+	counts := make(map[uint]int, len(rows))
+	for _, r := range rows {
+		counts[r.ParentID] = r.Count
+	}
+	return counts
+}
+
+// getFirstChildren 一次查出每条评论最早的 limit 条已发布回复
+func getFirstChildren(idList []uint, limit int) (children []models.CommentModel) {
+	sub := global.DB.Model(&models.CommentModel{}).
+		Select("id, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY created_at, id) AS rn").
+		Where("parent_id IN ? AND status = ?", idList, enum.CommentStatusPublished)
+	var childIDList []uint
+	global.DB.Raw("SELECT id FROM (?) AS t WHERE rn <= ?", sub, limit).Scan(&childIDList)
+	if len(childIDList) == 0 {
+		return
+	}
+	global.DB.Preload("UserModel").Where("id IN ?", childIDList).Order("created_at ASC, id ASC").Find(&children)
+	return
+}
+
+// TreeOptions 每次请求评论树时向下展开的范围
+type TreeOptions struct {
+	ReplyLimit int // 每条评论带上的回复数
+	Depth      int // 向下展开的层数，0 表示只返回传入的评论
+}
+
+// BuildTree 把一页评论转成 CommentResponse，并逐层带上每条评论最早的几条回复
+// 每层只查两次（回复数、回复），没展开完的评论标记 HasMoreReplies，由前端调用 GetChildPage 加载
+func BuildTree(list []models.CommentModel, opt TreeOptions) []*CommentResponse {
+	result := make([]*CommentResponse, 0, len(list))
+	level := map[uint]*CommentResponse{}
+	var idList []uint
+	for _, cmt := range list {
+		resp := newCommentResponse(cmt)
+		result = append(result, resp)
+		level[cmt.ID] = resp
+		idList = append(idList, cmt.ID)
+	}
+
+	for depth := 0; len(idList) > 0; depth++ {
+		counts := CountChildren(idList)
+		var children []models.CommentModel
+		if depth < opt.Depth && opt.ReplyLimit > 0 && len(counts) > 0 {
+			children = getFirstChildren(idList, opt.ReplyLimit)
+		}
+
+		next := map[uint]*CommentResponse{}
+		var nextIDList []uint
+		for _, child := range children {
+			parent, ok := level[*child.ParentID]
+			if !ok {
+				continue
+			}
+			resp := newCommentResponse(child)
+			parent.ChildComments = append(parent.ChildComments, resp)
+			next[child.ID] = resp
+			nextIDList = append(nextIDList, child.ID)
+		}
+		for id, resp := range level {
+			resp.ChildCount = counts[id]
+			resp.HasMoreReplies = counts[id] > len(resp.ChildComments)
+		}
+		level, idList = next, nextIDList
+	}
+	return result
+}
+
+func newCommentResponse(cmt models.CommentModel) *CommentResponse {
+	return &CommentResponse{
+		ID:            cmt.ID,
+		CreatedAt:     cmt.CreatedAt,
+		Content:       cmt.Content,
+		UserID:        cmt.UserID,
+		UserNickname:  cmt.UserModel.Nickname,
+		UserAvatarURL: cmt.UserModel.AvatarURL,
+		IsGuest:       cmt.UserModel.Role == enum.GuestRoleType,
+		ArticleID:     cmt.ArticleID,
+		ParentID:      cmt.ParentID,
+		RootID:        cmt.RootID,
+		Depth:         cmt.Depth,
+		LikeCount:     cmt.LikeCount + redis_comment.GetCommentLikeCount(cmt.ID),
+		ReplyCount:    cmt.ReplyCount + redis_comment.GetCommentReplyCount(cmt.ID),
+		Edited:        cmt.EditCount > 0,
+		EditedAt:      cmt.EditedAt,
+		Pinned:        cmt.PinnedAt != nil,
+		Highlighted:   cmt.Highlighted,
+		ChildComments: []*CommentResponse{},
+	}
+}
+
+// FillViewerState 填上当前用户对树中每条评论的点赞状态和与评论者的关系，点赞只查一次
+func FillViewerState(list []*CommentResponse, userID uint) {
+	var all []*CommentResponse
+	var walk func([]*CommentResponse)
+	walk = func(list []*CommentResponse) {
+		for _, resp := range list {
+			all = append(all, resp)
+			walk(resp.ChildComments)
+		}
+	}
+	walk(list)
+	if len(all) == 0 {
+		return
+	}
+
+	var commentIDList, userIDList []uint
+	for _, resp := range all {
+		commentIDList = append(commentIDList, resp.ID)
+		userIDList = append(userIDList, resp.UserID)
+	}
+	var likedIDList []uint
+	global.DB.Model(&models.CommentLikesModel{}).
+		Where("user_id = ? AND comment_id IN ?", userID, commentIDList).Pluck("comment_id", &likedIDList)
+	likedMap := make(map[uint]bool, len(likedIDList))
+	for _, id := range likedIDList {
+		likedMap[id] = true
+	}
+	relationMap := focus_service.CalcUserPatchRelationship(userID, utils.Unique(userIDList))
+
+	for _, resp := range all {
+		resp.IsLiked = likedMap[resp.ID]
+		resp.Relation = relationMap[resp.UserID]
+	}
+}
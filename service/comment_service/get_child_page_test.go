@@ -0,0 +1,53 @@
+// Path: ./service/comment_service/get_child_page_test.go
+
+package comment_service
+
+import (
+	"blogX_server/models"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	c := cursor{Sort: SortNew, Time: time.Now().UnixNano(), ID: 42}
+	got, err := decodeCursor(encodeCursor(c), SortNew)
+	if err != nil || got != c {
+		t.Fatalf("decodeCursor = %+v, %v, want %+v", got, err, c)
+	}
+
+	if got, err = decodeCursor("", SortHot); err != nil || got != (cursor{Sort: SortHot}) {
+		t.Errorf("空游标 = %+v, %v", got, err)
+	}
+	if _, err = decodeCursor(encodeCursor(c), SortHot); err == nil {
+		t.Error("排序方式不一致时应该报错")
+	}
+	if _, err = decodeCursor("not a cursor!", SortNew); err == nil {
+		t.Error("格式错误时应该报错")
+	}
+}
+
+func TestNextCursor(t *testing.T) {
+	now := time.Now()
+	list := []models.CommentModel{
+		{Model: models.Model{ID: 3, CreatedAt: now}},
+		{Model: models.Model{ID: 2, CreatedAt: now.Add(-time.Second)}},
+		{Model: models.Model{ID: 1, CreatedAt: now.Add(-2 * time.Second)}},
+	}
+
+	page, next := nextCursor(list, cursor{Sort: SortNew}, 3)
+	if len(page) != 3 || next != "" {
+		t.Errorf("最后一页 = %d, %q", len(page), next)
+	}
+
+	page, next = nextCursor(list, cursor{Sort: SortNew}, 2)
+	c, _ := decodeCursor(next, SortNew)
+	if len(page) != 2 || c.ID != 2 || c.Time != list[1].CreatedAt.UnixNano() {
+		t.Errorf("游标应指向本页最后一条: %d, %+v", len(page), c)
+	}
+
+	_, next = nextCursor(list, cursor{Sort: SortHot, Offset: 4, Now: now.Unix()}, 2)
+	c, _ = decodeCursor(next, SortHot)
+	if c.Offset != 6 || c.Now != now.Unix() {
+		t.Errorf("热门排序应累加偏移量并保留时间: %+v", c)
+	}
+}
@@ -5,27 +5,35 @@ package comment_service
 import (
 	"blogX_server/global"
 	"blogX_server/models"
+	"blogX_server/models/enum"
 	"blogX_server/models/enum/relationship_enum"
 	"blogX_server/service/redis_service/redis_comment"
 	"time"
 )
 
 type CommentResponse struct {
-	ID            uint                       `json:"id"`
-	CreatedAt     time.Time                  `json:"createdAt"`
-	Content       string                     `json:"content"`
-	UserID        uint                       `json:"userID"`
-	UserNickname  string                     `json:"userNickname"`
-	UserAvatarURL string                     `json:"userAvatarURL"`
-	ArticleID     uint                       `json:"articleID"`
-	ParentID      *uint                      `json:"parentID"`
-	RootID        *uint                      `json:"rootID"`
-	Depth         int                        `json:"depth"`
-	LikeCount     int                        `json:"likeCount"`
-	ReplyCount    int                        `json:"replyCount"`
-	IsLiked       bool                       `json:"isLiked"`
-	Relation      relationship_enum.Relation `json:"relation"`
-	ChildComments []*CommentResponse         `json:"childComments"`
+	ID             uint                       `json:"id"`
+	CreatedAt      time.Time                  `json:"createdAt"`
+	Content        string                     `json:"content"`
+	UserID         uint                       `json:"userID"`
+	UserNickname   string                     `json:"userNickname"`
+	UserAvatarURL  string                     `json:"userAvatarURL"`
+	IsGuest        bool                       `json:"isGuest"` // 游客评论，没有个人主页
+	ArticleID      uint                       `json:"articleID"`
+	ParentID       *uint                      `json:"parentID"`
+	RootID         *uint                      `json:"rootID"`
+	Depth          int                        `json:"depth"`
+	LikeCount      int                        `json:"likeCount"`
+	ReplyCount     int                        `json:"replyCount"`
+	IsLiked        bool                       `json:"isLiked"`
+	Edited         bool                       `json:"edited"`      // 是否修改过，前端显示"已编辑"
+	EditedAt       *time.Time                 `json:"editedAt"`    // 最后一次修改的时间
+	Pinned         bool                       `json:"pinned"`      // 作者置顶
+	Highlighted    bool                       `json:"highlighted"` // 作者标记的精选回答
+	Relation       relationship_enum.Relation `json:"relation"`
+	ChildCount     int                        `json:"childCount"`     // 已发布的直接回复数
+	HasMoreReplies bool                       `json:"hasMoreReplies"` // 还有没返回的回复，需要再加载
+	ChildComments  []*CommentResponse         `json:"childComments"`
 }
 
 // PreloadAllChildren 在 comment 对象的 ChildListModel 中，逐级嵌入所有 CommentModel
@@ -36,10 +44,10 @@ func PreloadAllChildren(comment *models.CommentModel) {
 	}
 }
 
-// PreloadAllChildrenResponseFromID 返回一个 CommentResponse，其中的 ChildComments 逐级嵌入所有的 childCommentResponse
+// PreloadAllChildrenResponseFromID 返回一个 CommentResponse，其中的 ChildComments 逐级嵌入所有已发布的 childCommentResponse
 func PreloadAllChildrenResponseFromID(cid uint, userRelationMap map[uint]relationship_enum.Relation, userLikeMap map[uint]struct{}) (resp *CommentResponse) {
 	var cmt models.CommentModel
-	global.DB.Preload("UserModel").Preload("ChildListModel").Take(&cmt, cid)
+	global.DB.Preload("UserModel").Preload("ChildListModel", "status = ?", enum.CommentStatusPublished).Take(&cmt, cid)
 	return PreloadAllChildrenResponseFromModel(&cmt, userRelationMap, userLikeMap)
 }
 
@@ -64,7 +72,7 @@ func preloadByClosure(cmt *models.CommentModel, userLikeMap map[uint]struct{}) (
 			depth--
 			return
 		}
-		global.DB.Preload("UserModel").Preload("ChildListModel").Take(cmt)
+		global.DB.Preload("UserModel").Preload("ChildListModel", "status = ?", enum.CommentStatusPublished).Take(cmt)
 		resp = &CommentResponse{
 			ID:            cmt.ID,
 			CreatedAt:     cmt.CreatedAt,
@@ -78,6 +86,8 @@ func preloadByClosure(cmt *models.CommentModel, userLikeMap map[uint]struct{}) (
 			Depth:         cmt.Depth,
 			LikeCount:     cmt.LikeCount + redis_comment.GetCommentLikeCount(cmt.ID),
 			ReplyCount:    cmt.ReplyCount + redis_comment.GetCommentReplyCount(cmt.ID),
+			Edited:        cmt.EditCount > 0,
+			EditedAt:      cmt.EditedAt,
 			ChildComments: []*CommentResponse{},
 		}
 		// 判断是否被本人点过赞
@@ -100,7 +110,7 @@ func preloadByAttr(cmt *models.CommentModel, userRelationMap map[uint]relationsh
 	if cmt.Depth >= global.Config.Site.Article.CommentDepth {
 		return
 	}
-	global.DB.Preload("UserModel").Preload("ChildListModel").Take(cmt)
+	global.DB.Preload("UserModel").Preload("ChildListModel", "status = ?", enum.CommentStatusPublished).Take(cmt)
 	resp = &CommentResponse{
 		ID:            cmt.ID,
 		CreatedAt:     cmt.CreatedAt,
@@ -114,6 +124,8 @@ func preloadByAttr(cmt *models.CommentModel, userRelationMap map[uint]relationsh
 		Depth:         cmt.Depth,
 		LikeCount:     cmt.LikeCount + redis_comment.GetCommentLikeCount(cmt.ID),
 		ReplyCount:    cmt.ReplyCount + redis_comment.GetCommentReplyCount(cmt.ID),
+		Edited:        cmt.EditCount > 0,
+		EditedAt:      cmt.EditedAt,
 		ChildComments: []*CommentResponse{},
 		Relation:      userRelationMap[cmt.UserID],
 	}
@@ -0,0 +1,106 @@
+// Path: ./service/comment_service/guest.go
+
+package comment_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/hash"
+	"blogX_server/utils/user"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 游客评论：博客模式下游客填写昵称和邮箱评论，点击邮件中的链接确认后才入库，入库后等待审核
+// 游客以占位用户的身份评论，占位用户不保存真实邮箱，以免占用邮箱导致对方无法注册
+// 之后用同一个邮箱注册或绑定时，占位用户的评论转给新用户
+
+// GuestVerifyExpiry 确认链接的有效期
+const GuestVerifyExpiry = 24 * time.Hour
+
+const guestPendingPrefix = "guest_comment_"
+
+// GuestComment 等待邮箱确认的游客评论，确认前只存在 redis 中
+type GuestComment struct {
+	ArticleID uint   `json:"articleID"`
+	ParentID  *uint  `json:"parentID"`
+	Content   string `json:"content"`
+	Nickname  string `json:"nickname"`
+	Email     string `json:"email"`
+	IP        string `json:"ip"`
+}
+
+// NormalizeEmail 邮箱不区分大小写
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// GuestUsername 邮箱对应的占位用户名，同一个邮箱始终是同一个占位用户
+func GuestUsername(email string) string {
+	return user.GuestUsernamePrefix + hash.Md5([]byte(NormalizeEmail(email)))[:16]
+}
+
+// SaveGuestComment 暂存游客评论，返回确认链接中的 token
+func SaveGuestComment(gc GuestComment) (token string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	token = hex.EncodeToString(b)
+	byteData, _ := json.Marshal(gc)
+	err = global.Redis.Set(guestPendingPrefix+token, byteData, GuestVerifyExpiry).Err()
+	return
+}
+
+// RemoveGuestComment 邮件发送失败时删除暂存的评论
+func RemoveGuestComment(token string) {
+	global.Redis.Del(guestPendingPrefix + token)
+}
+
+// TakeGuestComment 取出暂存的游客评论，每个 token 只能用一次
+func TakeGuestComment(token string) (gc GuestComment, err error) {
+	key := guestPendingPrefix + token
+	val, err := global.Redis.Get(key).Result()
+	if err != nil {
+		return gc, errors.New("链接无效或已过期")
+	}
+	// 并发点击同一个链接时只有删除成功的一方继续
+	if n, _ := global.Redis.Del(key).Result(); n == 0 {
+		return gc, errors.New("链接无效或已过期")
+	}
+	err = json.Unmarshal([]byte(val), &gc)
+	return
+}
+
+// GuestUser 取邮箱对应的占位用户，没有就创建，昵称用最近一次填写的
+// 带上注册来源的条件，同名的正常账号不会被当成占位用户
+func GuestUser(email, nickname string) (user models.UserModel, err error) {
+	username := GuestUsername(email)
+	err = global.DB.Take(&user, "username = ? and register_source = ?", username, enum.RegisterSourceGuestType).Error
+	if err == nil {
+		if user.Nickname != nickname {
+			global.DB.Model(&user).Update("nickname", nickname)
+		}
+		return
+	}
+
+	user = models.UserModel{
+		Username:       username,
+		Email:          username + "@guest.invalid",
+		Nickname:       nickname,
+		RegisterSource: enum.RegisterSourceGuestType,
+		Role:           enum.GuestRoleType,
+	}
+	// 占位用户没有配置，游客不能登录，也不接收站内消息
+	err = global.DBMaster.Create(&user).Error
+	if err != nil {
+		return user, fmt.Errorf("创建游客失败: %w", err)
+	}
+	return
+}
@@ -0,0 +1,59 @@
+// Path: ./service/comment_service/guest_test.go
+
+package comment_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"blogX_server/utils/user"
+	"strings"
+	"testing"
+)
+
+func TestGuestUsername(t *testing.T) {
+	a := GuestUsername("Alice@Example.com ")
+	b := GuestUsername("alice@example.com")
+	if a != b {
+		t.Errorf("邮箱大小写、空格不同应得到同一个占位用户: %s %s", a, b)
+	}
+	if !strings.HasPrefix(a, "guest_") || len(a) != len("guest_")+16 {
+		t.Errorf("占位用户名格式错误: %s", a)
+	}
+	if GuestUsername("bob@example.com") == a {
+		t.Error("不同邮箱应得到不同的占位用户")
+	}
+}
+
+func TestGuestUser(t *testing.T) {
+	testutil.Setup(t, &models.UserModel{})
+
+	u, err := GuestUser("a@example.com", "游客")
+	if err != nil || u.RegisterSource != enum.RegisterSourceGuestType {
+		t.Fatalf("创建占位用户 %+v, err = %v", u, err)
+	}
+	again, err := GuestUser("A@example.com", "改名")
+	if err != nil || again.ID != u.ID {
+		t.Fatalf("同一个邮箱应该复用占位用户, got %d, err = %v", again.ID, err)
+	}
+	global.DB.Take(&u, u.ID)
+	if u.Nickname != "改名" {
+		t.Errorf("昵称 = %s, want 最近一次填写的", u.Nickname)
+	}
+
+	// 同名的正常账号不能当成占位用户
+	account := models.UserModel{Username: GuestUsername("b@example.com"), Email: "b@example.com", Nickname: "real", RegisterSource: enum.RegisterSourceEmailType}
+	global.DB.Create(&account)
+	if _, err = GuestUser("b@example.com", "游客"); err == nil {
+		t.Error("用户名被正常账号占用时应该失败")
+	}
+	global.DB.Take(&account, account.ID)
+	if account.Nickname != "real" {
+		t.Errorf("正常账号的昵称被改成了 %s", account.Nickname)
+	}
+
+	if _, ok := user.IsAvailableUsername(strings.ToUpper(GuestUsername("c@example.com"))); ok {
+		t.Error("注册时不能使用占位用户的前缀")
+	}
+}
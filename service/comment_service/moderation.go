@@ -0,0 +1,80 @@
+// Path: ./service/comment_service/moderation.go
+
+package comment_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_article"
+	"blogX_server/service/redis_service/redis_comment"
+	"regexp"
+	"time"
+)
+
+// linkRe 评论中的链接：网址、markdown 链接、a 标签
+var linkRe = regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://|www\.|<a\s|\]\()`)
+
+// HasLink 评论内容中是否含有链接
+func HasLink(content string) bool {
+	return linkRe.MatchString(content)
+}
+
+// ModerationMode 文章单独设置了就用文章的，否则用站点的
+func ModerationMode(site, article enum.CommentModeration) enum.CommentModeration {
+	if article != enum.CommentModerationInherit {
+		return article
+	}
+	return site
+}
+
+// NeedModeration 新评论是否需要先审后发，管理员和文章作者的评论直接发布
+func NeedModeration(article models.ArticleModel, user models.UserModel, role enum.RoleType, content string) bool {
+	if role == enum.AdminRoleType || user.ID == article.UserID {
+		return false
+	}
+	conf := global.Config.Site.Article
+	switch ModerationMode(conf.CommentModeration, article.CommentModeration) {
+	case enum.CommentModerationAll:
+		return true
+	case enum.CommentModerationRisky:
+		days := conf.NewAccountDays
+		if days <= 0 {
+			days = 3
+		}
+		return time.Since(user.CreatedAt) < time.Duration(days)*24*time.Hour || HasLink(content)
+	}
+	return false
+}
+
+// NeedEditModeration 修改后的评论是否需要审核，和 NeedModeration 一致，但只看新增的内容
+// 已经发布的评论不会再因为账号太新而需要审核，只有新加了链接才需要
+func NeedEditModeration(article models.ArticleModel, user models.UserModel, role enum.RoleType, oldContent, newContent string) bool {
+	if role == enum.AdminRoleType || user.ID == article.UserID {
+		return false
+	}
+	conf := global.Config.Site.Article
+	switch ModerationMode(conf.CommentModeration, article.CommentModeration) {
+	case enum.CommentModerationAll:
+		return true
+	case enum.CommentModerationRisky:
+		return HasLink(newContent) && !HasLink(oldContent)
+	}
+	return false
+}
+
+// AddPublishedCount 评论发布（直接发布或审核通过）后，祖先评论的回复数和文章的评论数加一
+// 待审核、未通过的评论不计入评论数
+func AddPublishedCount(cmt models.CommentModel) error {
+	if cmt.ParentID != nil {
+		ancestors, err := GetAncestors(*cmt.ParentID)
+		if err != nil {
+			return err
+		}
+		for _, ans := range ancestors {
+			redis_comment.AddCommentReplyCount(ans.ID)
+		}
+	}
+	redis_article.AddArticleComment(cmt.ArticleID)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Path: ./service/comment_service/moderation_test.go
+
+package comment_service
+
+import (
+	"blogX_server/conf"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"testing"
+)
+
+func TestHasLink(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"写得很好", false},
+		{"看这里 https://example.com", true},
+		{"HTTP://EXAMPLE.COM", true},
+		{"www.example.com", true},
+		{"[点我](/a)", true},
+		{`<a href="/a">a</a>`, true},
+		{"邮箱 a@b.com", false},
+		{"时间 12:30", false},
+	}
+	for _, tt := range tests {
+		if got := HasLink(tt.content); got != tt.want {
+			t.Errorf("HasLink(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestModerationMode(t *testing.T) {
+	if got := ModerationMode(enum.CommentModerationAll, enum.CommentModerationInherit); got != enum.CommentModerationAll {
+		t.Errorf("跟随站点设置 = %v", got)
+	}
+	if got := ModerationMode(enum.CommentModerationAll, enum.CommentModerationOff); got != enum.CommentModerationOff {
+		t.Errorf("文章单独设置 = %v", got)
+	}
+}
+
+func TestNeedEditModeration(t *testing.T) {
+	global.Config = &conf.Config{}
+	global.Config.Site.Article.CommentModeration = enum.CommentModerationRisky
+	article := models.ArticleModel{UserID: 1}
+	user := models.UserModel{Model: models.Model{ID: 2}}
+
+	if NeedEditModeration(article, user, enum.UserRoleType, "看 https://a.com", "看 https://b.com") {
+		t.Error("原来就有链接，修改不需要审核")
+	}
+	if !NeedEditModeration(article, user, enum.UserRoleType, "写得好", "看 https://b.com") {
+		t.Error("新加了链接需要审核")
+	}
+	if NeedEditModeration(article, models.UserModel{Model: models.Model{ID: 1}}, enum.UserRoleType, "", "https://b.com") {
+		t.Error("文章作者不需要审核")
+	}
+	article.CommentModeration = enum.CommentModerationAll
+	if !NeedEditModeration(article, user, enum.UserRoleType, "a", "b") {
+		t.Error("全部审核时修改也需要审核")
+	}
+	if NeedEditModeration(article, user, enum.AdminRoleType, "a", "b") {
+		t.Error("管理员不需要审核")
+	}
+}
@@ -0,0 +1,46 @@
+// Path: ./service/comment_service/watch.go
+
+package comment_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"gorm.io/gorm/clause"
+)
+
+// ThreadRootID 评论所在讨论的根评论，根评论就是自己
+func ThreadRootID(cmt models.CommentModel) uint {
+	if cmt.RootID != nil {
+		return *cmt.RootID
+	}
+	return cmt.ID
+}
+
+// Watch 关注文章评论区（rootID 为 0）或某个讨论，之前取消过的重新打开
+func Watch(userID, articleID, rootID uint) error {
+	return global.DB.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{"muted": false, "auto": false}),
+	}).Create(&models.CommentWatchModel{UserID: userID, ArticleID: articleID, RootID: rootID}).Error
+}
+
+// Unwatch 取消关注，保留记录，之后再评论也不会被自动关注
+func Unwatch(userID, articleID, rootID uint) error {
+	return global.DB.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{"muted": true}),
+	}).Create(&models.CommentWatchModel{UserID: userID, ArticleID: articleID, RootID: rootID, Muted: true}).Error
+}
+
+// AutoWatch 评论后自动关注所在的讨论，用户关闭了自动关注或已经有记录的不处理
+func AutoWatch(cmt models.CommentModel) error {
+	var conf models.UserMessageConfModel
+	err := global.DB.Take(&conf, "user_id = ?", cmt.UserID).Error
+	if err != nil || !conf.AutoWatchComment {
+		return nil
+	}
+	return global.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.CommentWatchModel{
+		UserID:    cmt.UserID,
+		ArticleID: cmt.ArticleID,
+		RootID:    ThreadRootID(cmt),
+		Auto:      true,
+	}).Error
+}
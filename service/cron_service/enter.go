@@ -22,12 +22,18 @@ func Cron() {
 	_, err3 := crontab.AddFunc(global.Config.Redis.CommentSyncTime, SyncComment)
 	_, err4 := crontab.AddFunc(global.Config.Redis.SiteDataSyncTime, SyncData)
 	_, err5 := crontab.AddFunc(global.Config.Redis.UserDataSyncTime, SyncUser)
-	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil {
+	_, err6 := crontab.AddFunc(global.Config.Redis.ArticlePubSpec(), PublishScheduledArticle)
+	_, err7 := crontab.AddFunc(global.Config.Redis.RecyclePurgeSpec(), PurgeRecycle)
+	_, err8 := crontab.AddFunc(global.Config.Redis.ReactionSyncSpec(), SyncReaction)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil || err7 != nil || err8 != nil {
 		logrus.Panicln("crontab.AddFunc err:", err1)
 		logrus.Panicln("crontab.AddFunc err:", err2)
 		logrus.Panicln("crontab.AddFunc err:", err3)
 		logrus.Panicln("crontab.AddFunc err:", err4)
 		logrus.Panicln("crontab.AddFunc err:", err5)
+		logrus.Panicln("crontab.AddFunc err:", err6)
+		logrus.Panicln("crontab.AddFunc err:", err7)
+		logrus.Panicln("crontab.AddFunc err:", err8)
 		return
 	}
 	crontab.Start()
@@ -0,0 +1,94 @@
+// Path: ./service/cron_service/publish_scheduled.go
+
+package cron_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/mention_service"
+	"blogX_server/service/message_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// PublishScheduledArticle 把到点的定时文章发布出去
+// 开启自动审核就直接发布，否则进入待审核状态，走正常的审核流程
+func PublishScheduledArticle() {
+	now := time.Now()
+
+	var articleList []models.ArticleModel
+	err := global.DB.Where("status = ? and publish_at <= ?", enum.ArticleStatusSchedule, now).Find(&articleList).Error
+	if err != nil {
+		logrus.Errorf("get scheduled article list error: %v", err)
+		return
+	}
+	// 每分钟都会跑，没有到点的文章就不记日志了
+	if len(articleList) == 0 {
+		return
+	}
+
+	log := log_service.NewRuntimeLog("定时发布文章", log_service.RuntimeDeltaHour)
+	log.SetItem("开始时间", now.Format("2006-01-02 15:04:05"))
+
+	status := enum.ArticleStatusReview
+	if global.Config.Site.Article.AutoApprove {
+		status = enum.ArticleStatusPublish
+	}
+
+	var success int
+	for _, a := range articleList {
+		// 带上状态条件，用户在这期间取消了定时就不发布；created_at 是创建时间，不跟着改
+		// 这里 update 会触发 AfterUpdate 钩子重建 text_model，es 由 river 同步
+		result := global.DB.Model(&a).Where("status = ?", enum.ArticleStatusSchedule).Updates(map[string]any{
+			"status":     status,
+			"publish_at": nil,
+		})
+		err = result.Error
+		if err != nil {
+			logrus.Errorf("publish scheduled article %d error: %v", a.ID, err)
+			log.SetItemWarn(fmt.Sprintf("文章 %d 发布失败", a.ID), err.Error())
+			log.SetLevel(enum.LogWarnLevel)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		success++
+		if status == enum.ArticleStatusReview {
+			// 定时发布的内容就是最后一次保存的版本，这里只需开启一轮审核
+			if err = transaction.SyncArticleReviewTx(a.ID, a.UserID, ""); err != nil {
+				logrus.Errorf("open review round for article %d error: %v", a.ID, err)
+			}
+		}
+		redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, a.ID))
+		series_service.SyncArticleSeries(a.ID)
+
+		href := fmt.Sprintf("%s/article/%d", global.Config.System.Addr(), a.ID)
+		if status == enum.ArticleStatusPublish {
+			mention_service.SyncArticleByID(a.ID)
+			content := fmt.Sprintf("您定时发布的文章 [ID:%d]%s 已按时发布", a.ID, a.Title)
+			err = message_service.SendSystemNotify(a.UserID, "定时文章已发布", content, a.Title, href)
+		} else {
+			content := fmt.Sprintf("您定时发布的文章 [ID:%d]%s 已到发布时间，现已提交审核", a.ID, a.Title)
+			err = message_service.SendSystemNotify(a.UserID, "定时文章已提交审核", content, a.Title, href)
+		}
+		if err != nil {
+			logrus.Errorf("send scheduled article notify error: %v", err)
+		}
+	}
+
+	if success > 0 && status == enum.ArticleStatusPublish {
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	}
+
+	logrus.Infof("scheduled article publish complete, %d/%d article(s) published", success, len(articleList))
+	log.SetItem("完成", fmt.Sprintf("scheduled article publish complete, %d/%d article(s) published", success, len(articleList)))
+	log.SetTitle(fmt.Sprintf("定时发布 %d 篇", success))
+	log.Save()
+}
@@ -0,0 +1,95 @@
+// Path: ./service/cron_service/publish_scheduled_test.go
+
+package cron_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"testing"
+	"time"
+)
+
+func setupScheduled(t *testing.T) {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.ArticleRevisionModel{},
+		&models.ArticleReviewModel{},
+		&models.MentionModel{},
+		&models.NotifyModel{},
+		&models.LogModel{},
+	)
+	global.DB.Create(&models.UserModel{Username: "author"})
+}
+
+func newScheduled(t *testing.T, title string, publishAt time.Time) models.ArticleModel {
+	created := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	a := models.ArticleModel{
+		Model:     models.Model{CreatedAt: created},
+		Title:     title,
+		Content:   "# " + title,
+		UserID:    1,
+		Status:    enum.ArticleStatusSchedule,
+		PublishAt: &publishAt,
+	}
+	if err := global.DB.Create(&a).Error; err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestPublishScheduledArticle(t *testing.T) {
+	setupScheduled(t)
+	global.Config.Site.Article.AutoApprove = true
+
+	due := newScheduled(t, "due", time.Now().Add(-time.Minute))
+	future := newScheduled(t, "future", time.Now().Add(time.Hour))
+
+	PublishScheduledArticle()
+
+	var a models.ArticleModel
+	global.DB.Take(&a, due.ID)
+	if a.Status != enum.ArticleStatusPublish || a.PublishAt != nil {
+		t.Errorf("到点的文章 status = %d, publishAt = %v", a.Status, a.PublishAt)
+	}
+	if !a.CreatedAt.Equal(due.CreatedAt) {
+		t.Errorf("created_at 被修改: %v -> %v", due.CreatedAt, a.CreatedAt)
+	}
+
+	var b models.ArticleModel
+	global.DB.Take(&b, future.ID)
+	if b.Status != enum.ArticleStatusSchedule || b.PublishAt == nil {
+		t.Errorf("没到点的文章 status = %d", b.Status)
+	}
+
+	var notify models.NotifyModel
+	if err := global.DB.Take(&notify, "receive_user_id = ?", 1).Error; err != nil {
+		t.Fatal("没有发送发布通知")
+	}
+	if notify.LinkHref != global.Config.System.Addr()+"/article/1" {
+		t.Errorf("通知链接 = %s", notify.LinkHref)
+	}
+}
+
+func TestPublishScheduledArticleReview(t *testing.T) {
+	setupScheduled(t)
+
+	due := newScheduled(t, "due", time.Now().Add(-time.Minute))
+
+	PublishScheduledArticle()
+
+	var a models.ArticleModel
+	global.DB.Take(&a, due.ID)
+	if a.Status != enum.ArticleStatusReview {
+		t.Fatalf("没有开启自动审核时 status = %d, want %d", a.Status, enum.ArticleStatusReview)
+	}
+	var count int64
+	global.DB.Model(&models.ArticleReviewModel{}).
+		Where("article_id = ? and status = ?", a.ID, enum.ReviewStatusPending).Count(&count)
+	if count != 1 {
+		t.Errorf("审核轮次 = %d, want 1", count)
+	}
+}
@@ -0,0 +1,61 @@
+// Path: ./service/cron_service/purge_recycle.go
+
+package cron_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// PurgeRecycle 彻底删除回收站中超过保留期限的内容
+func PurgeRecycle() {
+	start := time.Now()
+
+	days := global.Config.Site.Article.RecycleDays
+	if days <= 0 {
+		// 没有配置就不清理，避免误删
+		return
+	}
+
+	log := log_service.NewRuntimeLog("清理回收站", log_service.RuntimeDeltaDay)
+	log.SetItem("开始时间", start.Format("2006-01-02 15:04:05"))
+
+	var list []models.RecycleModel
+	err := global.DB.Where("created_at < ?", start.AddDate(0, 0, -days)).Find(&list).Error
+	if err != nil {
+		logrus.Errorf("get recycle list error: %v", err)
+		log.SetItemError("查询失败", fmt.Sprintf("get recycle list error: %v", err))
+		log.SetLevel(enum.LogErrorLevel)
+		log.Save()
+		return
+	}
+	if len(list) == 0 {
+		logrus.Info("no expired recycle item to purge")
+		log.SetTitle("无过期内容")
+		log.Save()
+		return
+	}
+
+	var count int
+	for _, r := range list {
+		err = transaction.PurgeRecycle(&r)
+		if err != nil {
+			logrus.Errorf("purge recycle[%d] error: %v", r.ID, err)
+			log.SetItemWarn(fmt.Sprintf("%s[id: %d] 删除失败", r.Type, r.TargetID), err.Error())
+			log.SetLevel(enum.LogWarnLevel)
+			continue
+		}
+		count++
+	}
+
+	logrus.Infof("purge recycle complete, %d/%d item(s) purged, %s time elapsed", count, len(list), time.Since(start))
+	log.SetItem("完成", fmt.Sprintf("purge recycle complete, %d/%d item(s) purged, %s time elapsed", count, len(list), time.Since(start)))
+	log.SetTitle("清理成功")
+	log.Save()
+}
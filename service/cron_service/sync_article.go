@@ -50,7 +50,7 @@ func SyncArticle() {
 
 	// 从 DB 中取出本次有修改的文章
 	var articleList []models.ArticleModel
-	err := global.DB.Where("id IN ?", mapKeys(activeArticles)).Find(&articleList).Error
+	err := global.DB.Unscoped().Where("id IN ?", mapKeys(activeArticles)).Find(&articleList).Error
 	if err != nil {
 		logrus.Errorf("get article list error: %v", err)
 		log.SetItemError("查询失败", fmt.Sprintf("get article list error: %v", err))
@@ -48,7 +48,7 @@ func SyncComment() {
 
 	// 从 DB 中取出本次有修改的评论
 	var commentList []models.CommentModel
-	err := global.DB.Where("id IN ?", mapKeys(activeComments)).Find(&commentList).Error
+	err := global.DB.Unscoped().Where("id IN ?", mapKeys(activeComments)).Find(&commentList).Error
 	if err != nil {
 		logrus.Errorf("get comment list error: %v", err)
 		log.SetItemError("查询失败", fmt.Sprintf("get comment list error: %v", err))
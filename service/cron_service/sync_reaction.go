@@ -0,0 +1,52 @@
+// Path: ./service/cron_service/sync_reaction.go
+
+package cron_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/redis_service/redis_reaction"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// SyncReaction 把 redis 中的表情回应数增量同步到数据库
+func SyncReaction() {
+	start := time.Now()
+
+	log := log_service.NewRuntimeLog("同步表情回应数据", log_service.RuntimeDeltaDay)
+	log.SetItem("开始时间", start.Format("2006-01-02 15:04:05"))
+
+	// 从 redis 中读取数据，随后减掉读到的部分（读取之后的增量继续记录，下次再统计）
+	deltas := redis_reaction.GetAll()
+	redis_reaction.Subtract(deltas)
+
+	if len(deltas) == 0 {
+		log.SetTitle("无新数据")
+		log.Save()
+		return
+	}
+
+	err := transaction.SyncReactionTx(deltas)
+	if err != nil {
+		logrus.Errorf("sync reaction error: %v", err)
+		log.SetTitle("同步失败")
+		log.SetItemWarn("事务失败", fmt.Sprintf("sync reaction error: %v", err))
+		log.SetLevel(enum.LogWarnLevel)
+		if err = redis_reaction.Rollback(deltas); err != nil {
+			logrus.Errorf("rollback to Redis error: %v", err)
+			log.SetItemError("回滚失败", fmt.Sprintf("rollback to Redis error: %v", err))
+			log.SetLevel(enum.LogErrorLevel)
+		} else {
+			log.SetItem("回滚成功", "Redis data rolled back...")
+		}
+		log.Save()
+		return
+	}
+	logrus.Infof("update reaction complete, total %d counter(s), %s time elapsed", len(deltas), time.Since(start))
+	log.SetItem("完成", fmt.Sprintf("update reaction data complete, total %d counter(s) involved, %s time elapsed", len(deltas), time.Since(start)))
+	log.SetTitle("同步成功")
+	log.Save()
+}
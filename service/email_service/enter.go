@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/jordan-wright/email"
 	"github.com/sirupsen/logrus"
+	"html"
 	"net/smtp"
 	"strings"
 )
@@ -58,6 +59,19 @@ func SendVerifyCode(to, code string, uid uint) error {
 	return SendEmail(to, subject, text, true)
 }
 
+// SendGuestCommentVerify 游客评论的确认链接
+func SendGuestCommentVerify(to, nickname, articleTitle, link string, expiryHours int) error {
+	var siteName = global.Config.Site.SiteInfo.EnglishTitle
+
+	subject := fmt.Sprintf("%s 评论确认", siteName)
+	text := fmt.Sprintf("<p>%s，您好：</p>"+
+		"<p>您在 %s 的文章《%s》下发表了评论，请点击下面的链接确认，确认后评论将在审核通过后展示：</p>"+
+		"<p><a href=\"%s\">%s</a></p>"+
+		"<p>链接 %d 小时内有效。如非本人操作，请忽略本邮件。</p>",
+		html.EscapeString(nickname), siteName, html.EscapeString(articleTitle), link, link, expiryHours)
+	return SendEmail(to, subject, text, true)
+}
+
 func SendEmail(to, subject, text string, isHTML bool) error {
 	return SendEmails([]string{to}, "", subject, text, isHTML)
 }
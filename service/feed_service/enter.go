@@ -0,0 +1,59 @@
+// Path: ./service/feed_service/enter.go
+
+package feed_service
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Feed 一个订阅源，和具体格式无关，由 RSS / Atom / JSON Feed 各自渲染
+type Feed struct {
+	Title       string
+	Link        string // 网页地址
+	FeedURL     string // 订阅源自身的地址
+	Description string
+	Language    string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	ID          string // 唯一标识，一般就是文章地址
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Author      string
+	Image       string
+	Tags        []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// LastUpdated 订阅源中最新的修改时间，用于 Last-Modified
+func (f *Feed) LastUpdated() time.Time {
+	t := f.Updated
+	for _, item := range f.Items {
+		if item.Updated.After(t) {
+			t = item.Updated
+		}
+	}
+	return t
+}
+
+// imageMimeType 根据后缀猜测图片类型，RSS 的 enclosure 需要
+func imageMimeType(url string) string {
+	switch strings.ToLower(strings.TrimPrefix(path.Ext(url), ".")) {
+	case "png":
+		return "image/png"
+	case "gif":
+		return "image/gif"
+	case "webp":
+		return "image/webp"
+	case "svg":
+		return "image/svg+xml"
+	}
+	return "image/jpeg"
+}
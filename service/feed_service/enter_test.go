@@ -0,0 +1,115 @@
+package feed_service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	t1 := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)
+	return &Feed{
+		Title:       "BlogX",
+		Link:        "https://example.com",
+		FeedURL:     "https://example.com/api/feed/rss",
+		Description: "desc",
+		Updated:     t1,
+		Items: []Item{
+			{
+				ID:          "https://example.com/article/1",
+				Title:       "a < b & c",
+				Link:        "https://example.com/article/1",
+				Summary:     "summary",
+				ContentHTML: "<p>hello ]]> world</p>",
+				Author:      "liran",
+				Image:       "https://example.com/uploads/a.png",
+				Tags:        []string{"go", "blog"},
+				Published:   t1,
+				Updated:     t2,
+			},
+		},
+	}
+}
+
+func TestFeedLastUpdated(t *testing.T) {
+	f := testFeed()
+	if !f.LastUpdated().Equal(f.Items[0].Updated) {
+		t.Fatalf("LastUpdated = %v", f.LastUpdated())
+	}
+}
+
+func TestRSS(t *testing.T) {
+	byteData, err := testFeed().RSS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title     string `xml:"title"`
+				Content   string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Enclosure struct {
+					Type string `xml:"type,attr"`
+				} `xml:"enclosure"`
+				Categories []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err = xml.Unmarshal(byteData, &r); err != nil {
+		t.Fatalf("rss is not valid xml: %v\n%s", err, byteData)
+	}
+	if len(r.Channel.Items) != 1 {
+		t.Fatalf("items = %d", len(r.Channel.Items))
+	}
+	item := r.Channel.Items[0]
+	if item.Title != "a < b & c" || item.Content != "<p>hello ]]> world</p>" {
+		t.Fatalf("unexpected item %+v", item)
+	}
+	if item.Enclosure.Type != "image/png" || len(item.Categories) != 2 {
+		t.Fatalf("unexpected item %+v", item)
+	}
+}
+
+func TestAtom(t *testing.T) {
+	byteData, err := testFeed().Atom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a struct {
+		Updated string `xml:"updated"`
+		Entries []struct {
+			Content string `xml:"content"`
+		} `xml:"entry"`
+	}
+	if err = xml.Unmarshal(byteData, &a); err != nil {
+		t.Fatalf("atom is not valid xml: %v", err)
+	}
+	if a.Updated != "2025-01-03T09:00:00Z" {
+		t.Fatalf("updated = %s", a.Updated)
+	}
+	if len(a.Entries) != 1 || !strings.Contains(a.Entries[0].Content, "hello") {
+		t.Fatalf("unexpected entries %+v", a.Entries)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	byteData, err := testFeed().JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j map[string]any
+	if err = json.Unmarshal(byteData, &j); err != nil {
+		t.Fatal(err)
+	}
+	if j["version"] != "https://jsonfeed.org/version/1.1" {
+		t.Fatalf("version = %v", j["version"])
+	}
+	items := j["items"].([]any)
+	if len(items) != 1 || items[0].(map[string]any)["id"] != "https://example.com/article/1" {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
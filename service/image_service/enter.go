@@ -0,0 +1,113 @@
+// Path: ./service/image_service/enter.go
+
+package image_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/cloud_service/qny_cloud_service"
+	"blogX_server/utils/file"
+	"blogX_server/utils/hash"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UploadStatus 上传结果
+type UploadStatus int8
+
+const (
+	UploadStatusNew   UploadStatus = 0 // 新图片
+	UploadStatusDupe  UploadStatus = 1 // 其他用户上传过相同的图片，只记录关系
+	UploadStatusOwned UploadStatus = 2 // 自己已经上传过相同的图片
+)
+
+// Upload 图片上传流程，接口上传、转存、导入文章都走这里
+// 按 hash 去重入库，记录用户和图片的关系，再根据配置上传云端和（或）保存到本地
+// 重复的图片不会再次保存，返回已有的记录
+func Upload(byteData []byte, filename, source string, uid uint) (model models.ImageModel, status UploadStatus, err error) {
+	hashString := hash.Md5(byteData)
+
+	// 合法格式
+	suffix, err := file.ImageSuffix(filename)
+	if err != nil {
+		return
+	}
+
+	// 入库
+	model = models.ImageModel{
+		Filename: filename,
+		Path:     fmt.Sprintf("uploads/%s/%s", global.Config.Upload.ImageDir, hashString+"."+suffix),
+		Size:     int64(len(byteData)),
+		Hash:     hashString,
+		Source:   source,
+	}
+
+	// 尝试入库，靠数据库 `hash` 字段 `unique` 去重
+	err = global.DB.Create(&model).Error
+	if err != nil {
+		if !strings.Contains(err.Error(), "Duplicate entry") {
+			return
+		}
+		// 找出重复的那个
+		model = models.ImageModel{}
+		if err = global.DB.Take(&model, "hash = ?", hashString).Error; err != nil {
+			return
+		}
+		// 不是同一个用户上传的，加入关系表中
+		err = global.DB.Create(&models.UserUploadImage{
+			UserID:  uid,
+			ImageID: model.ID,
+		}).Error
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return model, UploadStatusOwned, nil
+			}
+			return
+		}
+		return model, UploadStatusDupe, nil
+	}
+
+	// 存入多对多关系数据库
+	err = global.DB.Create(&models.UserUploadImage{
+		UserID:  uid,
+		ImageID: model.ID,
+	}).Error
+	if err != nil {
+		return
+	}
+
+	// DB 部分结束，下面是云存储 or 本地存储 or both
+	if global.Config.Cloud.QNY.Enable {
+		url, _err := qny_cloud_service.UploadBytes(byteData)
+		if _err != nil {
+			return model, status, _err
+		}
+		model.Url = url
+		// 没开启本地存储
+		if !global.Config.Cloud.QNY.LocalSave {
+			model.Path = ""
+			err = global.DB.Model(&model).Updates(map[string]any{"url": model.Url, "path": ""}).Error
+			return
+		}
+		if err = global.DB.Model(&model).Update("url", model.Url).Error; err != nil {
+			return
+		}
+	}
+
+	// 本地存储
+	if err = os.MkdirAll(filepath.Dir(model.Path), 0777); err != nil {
+		return
+	}
+	err = os.WriteFile(model.Path, byteData, 0666)
+	return
+}
+
+// URL 图片的访问地址，优先云端
+func URL(model models.ImageModel) string {
+	if model.Url != "" {
+		return model.Url
+	}
+	return model.WebPath()
+}
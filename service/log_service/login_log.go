@@ -46,6 +46,23 @@ func NewLoginSuccess(user models.UserModel, loginType enum.LoginType, c *gin.Con
 	})
 }
 
+// NewSecurityLog 记录开启两步验证、下线设备等账号安全操作，和登录日志放在一起方便排查
+func NewSecurityLog(user models.UserModel, title string, content string, ok bool, c *gin.Context) {
+	ip := c.ClientIP()
+	location, _ := core.GetLocationFromIP(ip)
+	global.DB.Create(&models.LogModel{
+		LogType:     enum.LoginLogType,
+		Title:       title,
+		Content:     content,
+		UserID:      user.ID,
+		IP:          ip,
+		IPLocation:  location,
+		LoginStatus: ok,
+		Username:    user.Username,
+		UA:          c.Request.UserAgent(),
+	})
+}
+
 func NewLoginFail(loginType enum.LoginType, errMsg string, username string, pwd string, c *gin.Context) {
 	ip := c.ClientIP()
 	location, _ := core.GetLocationFromIP(ip)
@@ -0,0 +1,127 @@
+// Path: ./service/mention_service/enter.go
+
+package mention_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/message_service"
+	"github.com/sirupsen/logrus"
+	"regexp"
+)
+
+// maxMentions 一处内容最多提醒的人数，超出的忽略，防止刷提醒
+const maxMentions = 20
+
+var (
+	codeBlockRe  = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRe = regexp.MustCompile("`[^`\n]*`")
+	// 用户名规则和注册时一致，前面不能是字母数字，避免把邮箱当成提及
+	mentionRe = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_@.])@([a-zA-Z0-9_]{3,32})\b`)
+)
+
+// Parse 解析内容中 @ 的用户名，去重后按出现顺序返回，代码块中的忽略
+func Parse(content string) (names []string) {
+	content = codeBlockRe.ReplaceAllString(content, "")
+	content = inlineCodeRe.ReplaceAllString(content, "")
+	seen := map[string]bool{}
+	for _, m := range mentionRe.FindAllStringSubmatch(content, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		names = append(names, m[1])
+		if len(names) >= maxMentions {
+			break
+		}
+	}
+	return
+}
+
+// SyncArticle 文章创建、修改、发布后调用，同步正文中的提及
+func SyncArticle(a models.ArticleModel) {
+	sync(a, 0, a.UserID, a.Content, a.Status == enum.ArticleStatusPublish, "")
+}
+
+// SyncArticleByID 文章在别处被修改（审核通过、定时发布、回滚）后，重新读取再同步
+func SyncArticleByID(id uint) {
+	var a models.ArticleModel
+	if err := global.DB.Take(&a, id).Error; err != nil {
+		return
+	}
+	SyncArticle(a)
+}
+
+// SyncComment 评论创建、修改、审核通过后调用，cmt.ArticleModel 需要在外部填好
+func SyncComment(cmt models.CommentModel) {
+	sync(cmt.ArticleModel, cmt.ID, cmt.UserID, cmt.Content, cmt.Status == enum.CommentStatusPublished, cmt.Content)
+}
+
+// sync 内容中去掉的提及删除记录，新的提及加记录
+// 内容已发布时，提醒还没有提醒过的用户；草稿、待审核的内容等发布后再调用一次
+func sync(article models.ArticleModel, commentID, actionUserID uint, content string, published bool, notifyContent string) {
+	var users []models.UserModel
+	if names := Parse(content); len(names) > 0 {
+		global.DB.Select("id").Where("username in ?", names).Find(&users)
+	}
+	var existing []models.MentionModel
+	global.DB.Where("article_id = ? and comment_id = ?", article.ID, commentID).Find(&existing)
+
+	keep := map[uint]bool{}
+	for _, u := range users {
+		if u.ID != actionUserID {
+			keep[u.ID] = true
+		}
+	}
+	have := map[uint]models.MentionModel{}
+	var removeIDs []uint
+	for _, m := range existing {
+		if keep[m.UserID] {
+			have[m.UserID] = m
+		} else {
+			removeIDs = append(removeIDs, m.ID)
+		}
+	}
+	if len(removeIDs) > 0 {
+		global.DB.Delete(&models.MentionModel{}, removeIDs)
+	}
+
+	var notifyList []models.MentionModel
+	for _, u := range users {
+		if !keep[u.ID] {
+			continue
+		}
+		m, ok := have[u.ID]
+		if !ok {
+			m = models.MentionModel{UserID: u.ID, ArticleID: article.ID, CommentID: commentID, ActionUserID: actionUserID}
+			if err := global.DB.Create(&m).Error; err != nil {
+				logrus.Errorf("记录提及失败: %v", err)
+				continue
+			}
+		}
+		if published && !m.Notified {
+			notifyList = append(notifyList, m)
+		}
+	}
+	if len(notifyList) == 0 {
+		return
+	}
+
+	var actionUser models.UserModel
+	if err := global.DB.Take(&actionUser, actionUserID).Error; err != nil {
+		return
+	}
+	for _, m := range notifyList {
+		// 条件更新，同时保存时只提醒一次
+		result := global.DB.Model(&models.MentionModel{}).Where("id = ? and notified = ?", m.ID, false).Update("notified", true)
+		if result.Error != nil || result.RowsAffected == 0 {
+			continue
+		}
+		m.ActionUserModel = actionUser
+		m.ArticleModel = article
+		if err := message_service.SendMentionNotify(m, notifyContent); err != nil {
+			logrus.Errorf("发送提及提醒失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Path: ./service/mention_service/enter_test.go
+
+package mention_service
+
+import (
+	"blogX_server/models/enum/relationship_enum"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"@alice 你好", []string{"alice"}},
+		{"感谢 @bob,@alice 和@bob", []string{"bob", "alice"}},
+		{"发邮件到 me@example.com", nil},
+		{"@ab 太短 @" + strings.Repeat("a", 33) + " 太长", nil},
+		{"@carol你好", []string{"carol"}},
+		{"代码 `@alice` 和\n```\n@bob\n```\n里的不算", nil},
+		{"@@dave", nil},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < 30; i++ {
+		sb.WriteString(" @user_")
+		sb.WriteString(strings.Repeat("x", i+1))
+	}
+	if got := Parse(sb.String()); len(got) != maxMentions {
+		t.Errorf("最多 %d 个，得到 %d 个", maxMentions, len(got))
+	}
+}
+
+func TestSortSuggestions(t *testing.T) {
+	list := []Suggestion{
+		{Username: "alice2", Relation: relationship_enum.RelationStranger},
+		{Username: "alice", Relation: relationship_enum.RelationStranger},
+		{Username: "alice3", Relation: relationship_enum.RelationFans},
+		{Username: "alice4", Relation: relationship_enum.RelationFocus},
+		{Username: "alice5", Relation: relationship_enum.RelationFriend},
+	}
+	sortSuggestions(list, "Alice")
+	var got []string
+	for _, s := range list {
+		got = append(got, s.Username)
+	}
+	want := []string{"alice5", "alice4", "alice3", "alice", "alice2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortSuggestions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+// Path: ./service/mention_service/suggest.go
+
+package mention_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum/relationship_enum"
+	"blogX_server/service/focus_service"
+	"sort"
+	"strings"
+)
+
+type Suggestion struct {
+	UserID    uint                       `json:"userID"`
+	Username  string                     `json:"username"`
+	Nickname  string                     `json:"nickname"`
+	AvatarURL string                     `json:"avatarURL"`
+	Relation  relationship_enum.Relation `json:"relation"`
+}
+
+// relationRank 联想结果的排序：互关、我关注的、关注我的、陌生人
+func relationRank(r relationship_enum.Relation) int {
+	switch r {
+	case relationship_enum.RelationFriend:
+		return 0
+	case relationship_enum.RelationFocus:
+		return 1
+	case relationship_enum.RelationFans:
+		return 2
+	}
+	return 3
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Suggest @ 用户时的联想，用户名前缀或昵称包含关键字的用户，自己关注的人排在前面
+func Suggest(self uint, key string, limit int) []Suggestion {
+	key = strings.TrimPrefix(strings.TrimSpace(key), "@")
+	like := likeEscaper.Replace(key)
+	match := global.DB.Where("username like ? or nickname like ?", like+"%", "%"+like+"%")
+
+	// 关注的人单独查一次，保证在匹配的人很多时也能排到前面
+	var users []models.UserModel
+	global.DB.Where(match).Where("id in (?)", global.DB.Model(&models.UserFocusModel{}).
+		Where("user_id = ?", self).Select("focus_user_id")).
+		Where("id <> ?", self).Order("id").Limit(limit).Find(&users)
+	var others []models.UserModel
+	global.DB.Where(match).Where("id <> ?", self).Order("id").Limit(limit).Find(&others)
+
+	seen := map[uint]bool{}
+	var idList []uint
+	var list []models.UserModel
+	for _, u := range append(users, others...) {
+		if seen[u.ID] {
+			continue
+		}
+		seen[u.ID] = true
+		idList = append(idList, u.ID)
+		list = append(list, u)
+	}
+	if len(list) == 0 {
+		return []Suggestion{}
+	}
+
+	relationMap := focus_service.CalcUserPatchRelationship(self, idList)
+	result := make([]Suggestion, 0, len(list))
+	for _, u := range list {
+		result = append(result, Suggestion{
+			UserID:    u.ID,
+			Username:  u.Username,
+			Nickname:  u.Nickname,
+			AvatarURL: u.AvatarURL,
+			Relation:  relationMap[u.ID],
+		})
+	}
+	sortSuggestions(result, key)
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// sortSuggestions 关系近的在前，同样关系的用户名完全匹配的在前
+func sortSuggestions(list []Suggestion, key string) {
+	sort.SliceStable(list, func(i, j int) bool {
+		ri, rj := relationRank(list[i].Relation), relationRank(list[j].Relation)
+		if ri != rj {
+			return ri < rj
+		}
+		return strings.EqualFold(list[i].Username, key) && !strings.EqualFold(list[j].Username, key)
+	})
+}
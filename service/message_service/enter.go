@@ -62,6 +62,13 @@ func SendCommentNotify(cmt models.CommentModel) (err error) {
 	return
 }
 
+// notified 同个人对同一个对象已经提醒过了，点赞、收藏、表情回应都只提醒一次
+// column 为对象所在的字段，article_id 或 comment_id
+func notified(t notify_enum.Type, column string, targetID, actionUserID uint) bool {
+	err := global.DB.Take(&models.NotifyModel{}, "type = ? AND "+column+" = ? AND action_user_id = ?", t, targetID, actionUserID).Error
+	return err == nil
+}
+
 func SendArticleLikeNotify(al models.ArticleLikesModel) (err error) {
 	// 自己赞自己，就不通知了
 	if al.UserID == al.ArticleModel.UserID {
@@ -76,8 +83,7 @@ func SendArticleLikeNotify(al models.ArticleLikesModel) (err error) {
 	}
 
 	// 同个人给同一篇文章点过赞了，就不新发消息了
-	err = global.DB.Take(&models.NotifyModel{}, "type = ? AND article_id = ? AND action_user_id = ?", notify_enum.ArticleLikeType, al.ArticleID, al.UserID).Error
-	if err == nil {
+	if notified(notify_enum.ArticleLikeType, "article_id", al.ArticleID, al.UserID) {
 		return
 	}
 
@@ -116,8 +122,7 @@ func SendArticleCollectNotify(ac models.ArticleCollectionModel) (err error) {
 	}
 
 	// 同个人给同一篇文章收藏过，就不新发消息了
-	err = global.DB.Take(&models.NotifyModel{}, "type = ? AND article_id = ? AND action_user_id = ?", notify_enum.ArticleCollectType, ac.ArticleID, ac.UserID).Error
-	if err == nil {
+	if notified(notify_enum.ArticleCollectType, "article_id", ac.ArticleID, ac.UserID) {
 		return
 	}
 
@@ -156,8 +161,7 @@ func SendCommentLikeNotify(cl models.CommentLikesModel) (err error) {
 	}
 
 	// 同个人给同一篇评论点过赞了，就不新发消息了
-	err = global.DB.Take(&models.NotifyModel{}, "type = ? AND comment_id = ? AND action_user_id = ?", notify_enum.CommentLikeType, cl.CommentID, cl.UserID).Error
-	if err == nil {
+	if notified(notify_enum.CommentLikeType, "comment_id", cl.CommentID, cl.UserID) {
 		return
 	}
 
@@ -182,6 +186,115 @@ func SendCommentLikeNotify(cl models.CommentLikesModel) (err error) {
 	return
 }
 
+// SendReactionNotify 表情回应的提醒，和点赞一样受点赞提醒开关控制
+// 同个人对同一个对象只提醒第一次回应，之后换表情、加表情都不再提醒
+// r 的 UserModel 需要在外部填好，回应评论时 cmt 不为空
+func SendReactionNotify(r models.ReactionModel, article models.ArticleModel, cmt *models.CommentModel) (err error) {
+	msg := models.NotifyModel{
+		Type:                notify_enum.ArticleReactionType,
+		Content:             r.Emoji,
+		ReceiveUserID:       article.UserID,
+		ActionUserID:        r.UserID,
+		ActionUserNickname:  r.UserModel.Nickname,
+		ActionUserAvatarURL: r.UserModel.AvatarURL,
+		ArticleID:           article.ID,
+		ArticleTitle:        article.Title,
+	}
+	column, targetID := "article_id", article.ID
+	if cmt != nil {
+		msg.Type = notify_enum.CommentReactionType
+		msg.ReceiveUserID = cmt.UserID
+		msg.CommentID = cmt.ID
+		msg.CommentContent = utils.ExtractContent(cmt.Content, 30)
+		column, targetID = "comment_id", cmt.ID
+	}
+
+	// 自己回应自己，就不通知了
+	if r.UserID == msg.ReceiveUserID {
+		return
+	}
+
+	// 检验对方是否接受消息
+	var receiveUserConf models.UserMessageConfModel
+	err = global.DB.Take(&receiveUserConf, "user_id = ?", msg.ReceiveUserID).Error
+	if !receiveUserConf.ReceiveLikeNotify {
+		return
+	}
+
+	if notified(msg.Type, column, targetID, r.UserID) {
+		return
+	}
+
+	// 入库
+	err = global.DB.Create(&msg).Error
+	return
+}
+
+// SendMentionNotify 被 @ 的提醒，m 中的 ActionUserModel 和 ArticleModel 需要在外部填好
+// 在评论中提及时 content 是评论内容，在文章中提及时为空
+func SendMentionNotify(m models.MentionModel, content string) (err error) {
+	// 自己 @ 自己，就不通知了
+	if m.UserID == m.ActionUserID {
+		return
+	}
+
+	// 检验对方是否接受消息
+	var receiveUserConf models.UserMessageConfModel
+	err = global.DB.Take(&receiveUserConf, "user_id = ?", m.UserID).Error
+	if !receiveUserConf.ReceiveMentionNotify {
+		return
+	}
+
+	// 入库
+	err = global.DB.Create(&models.NotifyModel{
+		Type:                notify_enum.MentionType,
+		Content:             utils.ExtractContent(content, 30),
+		ReceiveUserID:       m.UserID,
+		ActionUserID:        m.ActionUserID,
+		ActionUserNickname:  m.ActionUserModel.Nickname,
+		ActionUserAvatarURL: m.ActionUserModel.AvatarURL,
+		ArticleID:           m.ArticleID,
+		ArticleTitle:        m.ArticleModel.Title,
+		CommentID:           m.CommentID,
+	}).Error
+	return
+}
+
+// SendCommentPinNotify 评论被文章作者置顶的提醒，cmt 的 ArticleModel 需要在外部填好
+// 同一条评论取消后再置顶不重复提醒
+func SendCommentPinNotify(cmt models.CommentModel, operator models.UserModel) (err error) {
+	// 作者置顶自己的评论，就不通知了
+	if cmt.UserID == operator.ID {
+		return
+	}
+
+	// 检验对方是否接受消息
+	var receiveUserConf models.UserMessageConfModel
+	err = global.DB.Take(&receiveUserConf, "user_id = ?", cmt.UserID).Error
+	if !receiveUserConf.ReceiveCommentNotify {
+		return
+	}
+
+	err = global.DB.Take(&models.NotifyModel{}, "type = ? AND comment_id = ?", notify_enum.CommentPinType, cmt.ID).Error
+	if err == nil {
+		return
+	}
+
+	// 入库
+	err = global.DB.Create(&models.NotifyModel{
+		Type:                notify_enum.CommentPinType,
+		Content:             utils.ExtractContent(cmt.Content, 30),
+		ReceiveUserID:       cmt.UserID,
+		ActionUserID:        operator.ID,
+		ActionUserNickname:  operator.Nickname,
+		ActionUserAvatarURL: operator.AvatarURL,
+		ArticleID:           cmt.ArticleID,
+		ArticleTitle:        cmt.ArticleModel.Title,
+		CommentID:           cmt.ID,
+	}).Error
+	return
+}
+
 func SendSystemNotify(receiver uint, title, content, link, href string) error {
 	// todo 被删除的文章 评论的 id 要记录下
 	var user models.UserModel
@@ -0,0 +1,122 @@
+// Path: ./service/message_service/watch.go
+
+package message_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum/notify_enum"
+	"blogX_server/utils"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// WatchNotifyInterval 同一个讨论对同一个关注者的提醒间隔，讨论很热闹时不会刷屏
+const WatchNotifyInterval = 10 * time.Minute
+
+// SendWatchNotify 新评论发布后提醒关注了文章评论区或所在讨论的用户
+// cmt 的 ArticleModel、ParentModel 需要在外部填好，且在 SendCommentNotify、提及同步之后调用
+// 评论者本人、已经收到评论/回复/提及提醒的用户不再重复提醒
+func SendWatchNotify(cmt models.CommentModel) (err error) {
+	rootID := cmt.ID
+	if cmt.RootID != nil {
+		rootID = *cmt.RootID
+	}
+
+	var watches []models.CommentWatchModel
+	err = global.DB.Where("article_id = ? and root_id in ?", cmt.ArticleID, []uint{0, rootID}).Find(&watches).Error
+	if err != nil || len(watches) == 0 {
+		return
+	}
+
+	skip := map[uint]bool{cmt.UserID: true}
+	if cmt.ParentID == nil {
+		skip[cmt.ArticleModel.UserID] = true
+	} else if cmt.ParentModel != nil {
+		skip[cmt.ParentModel.UserID] = true
+	}
+	var mentioned []uint
+	global.DB.Model(&models.MentionModel{}).Where("comment_id = ?", cmt.ID).Pluck("user_id", &mentioned)
+	for _, id := range mentioned {
+		skip[id] = true
+	}
+
+	userIDList := pickWatchers(watches, rootID, skip)
+	if len(userIDList) == 0 {
+		return
+	}
+
+	// 关闭了评论提醒的用户不提醒
+	var receivers []uint
+	global.DB.Model(&models.UserMessageConfModel{}).
+		Where("user_id in ? and receive_comment_notify = ?", userIDList, true).Pluck("user_id", &receivers)
+
+	var user models.UserModel
+	err = global.DB.Where("id = ?", cmt.UserID).Take(&user).Error
+	if err != nil {
+		return
+	}
+
+	var list []models.NotifyModel
+	for _, id := range receivers {
+		// 间隔内已经提醒过这个讨论的，本次跳过
+		key := fmt.Sprintf("watch_notify_%d_%d", id, rootID)
+		ok, err := global.Redis.SetNX(key, cmt.ID, WatchNotifyInterval).Result()
+		if err != nil {
+			logrus.Errorf("关注提醒限流失败: %v", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		list = append(list, models.NotifyModel{
+			Type:                notify_enum.WatchType,
+			Content:             utils.ExtractContent(cmt.Content, 30),
+			ReceiveUserID:       id,
+			ActionUserID:        cmt.UserID,
+			ActionUserNickname:  user.Nickname,
+			ActionUserAvatarURL: user.AvatarURL,
+			ArticleID:           cmt.ArticleID,
+			ArticleTitle:        cmt.ArticleModel.Title,
+			CommentID:           cmt.ID,
+		})
+	}
+	if len(list) == 0 {
+		return
+	}
+	return global.DB.Create(&list).Error
+}
+
+// pickWatchers 关注文章和关注讨论的同一个用户只提醒一次
+// 对这个讨论的设置优先于对整篇文章的设置，取消关注了讨论的用户即使关注了文章也不提醒
+func pickWatchers(watches []models.CommentWatchModel, rootID uint, skip map[uint]bool) (userIDList []uint) {
+	thread := map[uint]bool{}
+	article := map[uint]bool{}
+	var order []uint
+	for _, w := range watches {
+		_, ok1 := thread[w.UserID]
+		_, ok2 := article[w.UserID]
+		if !ok1 && !ok2 {
+			order = append(order, w.UserID)
+		}
+		if w.RootID == rootID && rootID != 0 {
+			thread[w.UserID] = !w.Muted
+		} else if w.RootID == 0 {
+			article[w.UserID] = !w.Muted
+		}
+	}
+	for _, id := range order {
+		if skip[id] {
+			continue
+		}
+		on, ok := thread[id]
+		if !ok {
+			on = article[id]
+		}
+		if on {
+			userIDList = append(userIDList, id)
+		}
+	}
+	return
+}
@@ -0,0 +1,28 @@
+// Path: ./service/message_service/watch_test.go
+
+package message_service
+
+import (
+	"blogX_server/models"
+	"reflect"
+	"testing"
+)
+
+func TestPickWatchers(t *testing.T) {
+	watches := []models.CommentWatchModel{
+		{UserID: 1, RootID: 0},
+		{UserID: 1, RootID: 5}, // 同时关注文章和讨论，只提醒一次
+		{UserID: 2, RootID: 0}, // 只关注文章
+		{UserID: 3, RootID: 0}, // 关注文章但取消了这个讨论
+		{UserID: 3, RootID: 5, Muted: true},
+		{UserID: 4, RootID: 0, Muted: true}, // 取消了文章，但关注了这个讨论
+		{UserID: 4, RootID: 5},
+		{UserID: 5, RootID: 5, Muted: true}, // 只有取消关注的记录
+		{UserID: 6, RootID: 5},              // 评论者本人
+	}
+	got := pickWatchers(watches, 5, map[uint]bool{6: true})
+	want := []uint{1, 2, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("pickWatchers = %v, want %v", got, want)
+	}
+}
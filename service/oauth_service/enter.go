@@ -0,0 +1,307 @@
+// Path: ./service/oauth_service/enter.go
+
+package oauth_service
+
+import (
+	"blogX_server/conf"
+	"blogX_server/global"
+	"blogX_server/models/enum"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 登录服务的种类
+const (
+	KindGithub = "github"
+	KindGitee  = "gitee"
+	KindOIDC   = "oidc"
+)
+
+// presets 各种类的默认端点、scope 和字段映射
+var presets = map[string]conf.OAuthProvider{
+	KindGithub: {
+		AuthURL:     "https://github.com/login/oauth/authorize",
+		TokenURL:    "https://github.com/login/oauth/access_token",
+		UserInfoURL: "https://api.github.com/user",
+		Scopes:      []string{"read:user", "user:email"},
+		Claims:      conf.OAuthClaimMapping{ID: "id", Username: "login", Nickname: "name", Email: "email", Avatar: "avatar_url"},
+	},
+	KindGitee: {
+		AuthURL:     "https://gitee.com/oauth/authorize",
+		TokenURL:    "https://gitee.com/oauth/token",
+		UserInfoURL: "https://gitee.com/api/v5/user",
+		Scopes:      []string{"user_info"},
+		Claims:      conf.OAuthClaimMapping{ID: "id", Username: "login", Nickname: "name", Email: "email", Avatar: "avatar_url"},
+	},
+	KindOIDC: {
+		Scopes: []string{"openid", "profile", "email"},
+		Claims: conf.OAuthClaimMapping{ID: "sub", Username: "preferred_username", Nickname: "name", Email: "email", Avatar: "picture"},
+	},
+}
+
+// Provider 补全了默认值的登录服务
+type Provider struct {
+	conf.OAuthProvider
+	HTTP *http.Client
+}
+
+// Identity 第三方账号的信息
+type Identity struct {
+	Subject   string
+	Username  string
+	Nickname  string
+	Email     string
+	AvatarURL string
+}
+
+// ProviderInfo 登录页展示的服务
+type ProviderInfo struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+}
+
+// List 启用的登录服务
+func List() []ProviderInfo {
+	list := []ProviderInfo{}
+	for _, pc := range global.Config.OAuth.Providers {
+		if pc.Enable {
+			list = append(list, ProviderInfo{Name: pc.Name, Label: pc.Label})
+		}
+	}
+	return list
+}
+
+// GetProvider 按名称取启用的登录服务
+func GetProvider(name string) (*Provider, error) {
+	for _, pc := range global.Config.OAuth.Providers {
+		if pc.Name == name && pc.Enable {
+			return NewProvider(pc)
+		}
+	}
+	return nil, errors.New("不支持的登录方式")
+}
+
+// NewProvider 补全默认值，oidc 服务没有填端点时从 issuer 发现
+func NewProvider(pc conf.OAuthProvider) (*Provider, error) {
+	preset, ok := presets[pc.Kind]
+	if !ok {
+		return nil, fmt.Errorf("登录服务 %s 的种类错误: %s", pc.Name, pc.Kind)
+	}
+	p := &Provider{OAuthProvider: withDefaults(pc, preset), HTTP: &http.Client{Timeout: 10 * time.Second}}
+	if p.Kind == KindOIDC && (p.AuthURL == "" || p.TokenURL == "" || p.UserInfoURL == "") {
+		if err := p.discover(); err != nil {
+			return nil, err
+		}
+	}
+	if p.ClientID == "" || p.AuthURL == "" || p.TokenURL == "" || p.UserInfoURL == "" {
+		return nil, fmt.Errorf("登录服务 %s 配置不完整", pc.Name)
+	}
+	return p, nil
+}
+
+func withDefaults(pc, preset conf.OAuthProvider) conf.OAuthProvider {
+	or := func(v, def string) string {
+		if v == "" {
+			return def
+		}
+		return v
+	}
+	pc.AuthURL = or(pc.AuthURL, preset.AuthURL)
+	pc.TokenURL = or(pc.TokenURL, preset.TokenURL)
+	pc.UserInfoURL = or(pc.UserInfoURL, preset.UserInfoURL)
+	if len(pc.Scopes) == 0 {
+		pc.Scopes = preset.Scopes
+	}
+	pc.Claims.ID = or(pc.Claims.ID, preset.Claims.ID)
+	pc.Claims.Username = or(pc.Claims.Username, preset.Claims.Username)
+	pc.Claims.Nickname = or(pc.Claims.Nickname, preset.Claims.Nickname)
+	pc.Claims.Email = or(pc.Claims.Email, preset.Claims.Email)
+	pc.Claims.Avatar = or(pc.Claims.Avatar, preset.Claims.Avatar)
+	return pc
+}
+
+// discovery issuer 的端点，同一个 issuer 只请求一次
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+var discoveryCache sync.Map
+
+func (p *Provider) discover() error {
+	if p.Issuer == "" {
+		return fmt.Errorf("登录服务 %s 没有配置 issuer", p.Name)
+	}
+	issuer := strings.TrimSuffix(p.Issuer, "/")
+	d, ok := discoveryCache.Load(issuer)
+	if !ok {
+		var data discovery
+		resp, err := p.HTTP.Get(issuer + "/.well-known/openid-configuration")
+		if err != nil {
+			return fmt.Errorf("获取 OIDC 配置失败: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("获取 OIDC 配置失败: %s", resp.Status)
+		}
+		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			return fmt.Errorf("解析 OIDC 配置失败: %w", err)
+		}
+		if strings.TrimSuffix(data.Issuer, "/") != issuer {
+			return errors.New("OIDC 配置中的 issuer 不一致")
+		}
+		discoveryCache.Store(issuer, data)
+		d = data
+	}
+	data := d.(discovery)
+	if p.AuthURL == "" {
+		p.AuthURL = data.AuthorizationEndpoint
+	}
+	if p.TokenURL == "" {
+		p.TokenURL = data.TokenEndpoint
+	}
+	if p.UserInfoURL == "" {
+		p.UserInfoURL = data.UserinfoEndpoint
+	}
+	return nil
+}
+
+// LoginType 登录日志中的登录方式
+func (p *Provider) LoginType() enum.LoginType {
+	switch p.Kind {
+	case KindGithub:
+		return enum.GithubLoginType
+	case KindGitee:
+		return enum.GiteeLoginType
+	}
+	return enum.OIDCLoginType
+}
+
+// RegisterSource 自动注册的用户的来源
+func (p *Provider) RegisterSource() enum.RegisterSourceType {
+	switch p.Kind {
+	case KindGithub:
+		return enum.RegisterSourceGithubType
+	case KindGitee:
+		return enum.RegisterSourceGiteeType
+	}
+	return enum.RegisterSourceOIDCType
+}
+
+// AuthorizeURL 跳转到第三方授权页的地址
+func (p *Provider) AuthorizeURL(state string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {p.Redirect},
+		"scope":         {strings.Join(p.Scopes, " ")},
+		"state":         {state},
+	}
+	sep := "?"
+	if strings.Contains(p.AuthURL, "?") {
+		sep = "&"
+	}
+	return p.AuthURL + sep + q.Encode()
+}
+
+// Login 用回调拿到的 code 换 access token，再取用户信息
+func (p *Provider) Login(code string) (id Identity, err error) {
+	token, err := p.exchange(code)
+	if err != nil {
+		return
+	}
+	return p.identity(token)
+}
+
+// exchange client secret 放在表单中提交（client_secret_post）
+func (p *Provider) exchange(code string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Redirect},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+	}
+	req, err := http.NewRequest(http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var data struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = p.do(req, &data); err != nil && data.Error == "" {
+		return "", fmt.Errorf("获取 access token 失败: %w", err)
+	}
+	if data.Error != "" || data.AccessToken == "" {
+		return "", fmt.Errorf("获取 access token 失败: %s %s", data.Error, data.ErrorDescription)
+	}
+	return data.AccessToken, nil
+}
+
+func (p *Provider) identity(token string) (id Identity, err error) {
+	req, err := http.NewRequest(http.MethodGet, p.UserInfoURL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	var claims map[string]any
+	if err = p.do(req, &claims); err != nil {
+		return id, fmt.Errorf("获取用户信息失败: %w", err)
+	}
+	id = Identity{
+		Subject:   claimString(claims, p.Claims.ID),
+		Username:  claimString(claims, p.Claims.Username),
+		Nickname:  claimString(claims, p.Claims.Nickname),
+		Email:     claimString(claims, p.Claims.Email),
+		AvatarURL: claimString(claims, p.Claims.Avatar),
+	}
+	if id.Subject == "" {
+		return id, fmt.Errorf("用户信息中没有 %s 字段", p.Claims.ID)
+	}
+	return id, nil
+}
+
+// do 发送请求并解析 json，状态码不是 200 时仍然解析，方便取出错误信息
+func (p *Provider) do(req *http.Request, data any) error {
+	resp, err := p.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber() // 数字 id 不转成浮点数
+	decErr := dec.Decode(data)
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return decErr
+}
+
+// claimString 字段可能是字符串或数字，其他类型当作没有
+func claimString(claims map[string]any, key string) string {
+	if key == "" {
+		return ""
+	}
+	switch v := claims[key].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+// Path: ./service/oauth_service/enter_test.go
+
+package oauth_service
+
+import (
+	"blogX_server/conf"
+	"blogX_server/models/enum"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const (
+	testClientID = "blog"
+	testSecret   = "secret"
+	testCode     = "good-code"
+	testToken    = "token-1"
+)
+
+// newMockOIDC 本地的假 OIDC 服务，提供发现、换 token 和用户信息三个接口
+func newMockOIDC(t *testing.T, userinfo map[string]any) *httptest.Server {
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	write := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, map[string]any{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"userinfo_endpoint":      srv.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.ParseForm() != nil {
+			write(w, http.StatusBadRequest, map[string]any{"error": "invalid_request"})
+			return
+		}
+		if r.PostForm.Get("client_id") != testClientID || r.PostForm.Get("client_secret") != testSecret {
+			write(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+			return
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != testCode {
+			write(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant", "error_description": "bad code"})
+			return
+		}
+		write(w, http.StatusOK, map[string]any{"access_token": testToken, "token_type": "Bearer", "id_token": "x.y.z"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			write(w, http.StatusUnauthorized, map[string]any{"error": "invalid_token"})
+			return
+		}
+		write(w, http.StatusOK, userinfo)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOIDCLogin(t *testing.T) {
+	srv := newMockOIDC(t, map[string]any{
+		"sub":                "u-42",
+		"preferred_username": "alice",
+		"name":               "Alice",
+		"email":              "alice@example.com",
+		"picture":            "http://img/alice.png",
+	})
+	p, err := NewProvider(conf.OAuthProvider{
+		Name: "company", Kind: KindOIDC, ClientID: testClientID, ClientSecret: testSecret,
+		Issuer: srv.URL, Redirect: "http://blog/oauth/company",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.TokenURL != srv.URL+"/token" || p.UserInfoURL != srv.URL+"/userinfo" {
+		t.Fatalf("discovery failed: %+v", p.OAuthProvider)
+	}
+	if p.LoginType() != enum.OIDCLoginType || p.RegisterSource() != enum.RegisterSourceOIDCType {
+		t.Fatal("wrong enum for oidc")
+	}
+
+	u, err := url.Parse(p.AuthorizeURL("st"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if u.Path != "/authorize" || q.Get("client_id") != testClientID || q.Get("state") != "st" ||
+		q.Get("scope") != "openid profile email" || q.Get("redirect_uri") != "http://blog/oauth/company" {
+		t.Fatalf("unexpected authorize url: %s", u)
+	}
+
+	id, err := p.Login(testCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Identity{Subject: "u-42", Username: "alice", Nickname: "Alice", Email: "alice@example.com", AvatarURL: "http://img/alice.png"}
+	if id != want {
+		t.Fatalf("got %+v, want %+v", id, want)
+	}
+}
+
+func TestOIDCBadCode(t *testing.T) {
+	srv := newMockOIDC(t, map[string]any{"sub": "u-42"})
+	p, err := NewProvider(conf.OAuthProvider{Name: "company", Kind: KindOIDC, ClientID: testClientID, ClientSecret: testSecret, Issuer: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.Login("bad-code")
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("want invalid_grant, got %v", err)
+	}
+}
+
+func TestClaimMapping(t *testing.T) {
+	// github 风格：数字 id，端点手动指向假服务，字段映射用默认值，昵称改用自定义字段
+	srv := newMockOIDC(t, map[string]any{
+		"id":         int64(9007199254740993),
+		"login":      "octocat",
+		"name":       nil,
+		"nick":       "The Octocat",
+		"avatar_url": "http://img/octo.png",
+	})
+	p, err := NewProvider(conf.OAuthProvider{
+		Name: "github", Kind: KindGithub, ClientID: testClientID, ClientSecret: testSecret,
+		AuthURL: srv.URL + "/authorize", TokenURL: srv.URL + "/token", UserInfoURL: srv.URL + "/userinfo",
+		Claims: conf.OAuthClaimMapping{Nickname: "nick"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Claims.Username != "login" || len(p.Scopes) == 0 {
+		t.Fatalf("github defaults not applied: %+v", p.OAuthProvider)
+	}
+	id, err := p.Login(testCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Subject != "9007199254740993" || id.Username != "octocat" || id.Nickname != "The Octocat" || id.Email != "" {
+		t.Fatalf("unexpected identity: %+v", id)
+	}
+}
+
+func TestMissingSubject(t *testing.T) {
+	srv := newMockOIDC(t, map[string]any{"name": "nobody"})
+	p, err := NewProvider(conf.OAuthProvider{Name: "company", Kind: KindOIDC, ClientID: testClientID, ClientSecret: testSecret, Issuer: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.Login(testCode); err == nil {
+		t.Fatal("want error when subject is missing")
+	}
+}
+
+func TestNewProviderInvalid(t *testing.T) {
+	if _, err := NewProvider(conf.OAuthProvider{Name: "x", Kind: "ldap", ClientID: "a"}); err == nil {
+		t.Fatal("want error for unknown kind")
+	}
+	if _, err := NewProvider(conf.OAuthProvider{Name: "x", Kind: KindOIDC, ClientID: "a"}); err == nil {
+		t.Fatal("want error for oidc without issuer")
+	}
+}
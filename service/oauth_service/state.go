@@ -0,0 +1,69 @@
+// Path: ./service/oauth_service/state.go
+
+package oauth_service
+
+import (
+	"blogX_server/global"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// StateExpiry 从跳转授权到回调的有效时间
+const StateExpiry = 10 * time.Minute
+
+// StateCookie 保存 state 的 cookie，回调时和带回的 state 比对，确认是同一个浏览器发起的授权
+const StateCookie = "oauth_state"
+
+const statePrefix = "oauth_state_"
+
+var errState = errors.New("授权已过期，请重新登录")
+
+// StateProviderQQ QQ 登录不在配置的登录服务中，也用这里的 state 防止伪造的回调
+const StateProviderQQ = "qq"
+
+// State 授权时生成，回调时校验，防止伪造的回调
+type State struct {
+	Provider string `json:"provider"`
+	UserID   uint   `json:"userID"` // 不为 0 表示已登录的用户在绑定第三方账号
+}
+
+// NewState 生成 state 暂存在 redis 中，同时写入 HttpOnly 的 cookie
+func NewState(c *gin.Context, st State) (state string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	state = hex.EncodeToString(b)
+	byteData, _ := json.Marshal(st)
+	err = global.Redis.Set(statePrefix+state, byteData, StateExpiry).Err()
+	if err != nil {
+		return
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(StateCookie, state, int(StateExpiry.Seconds()), "/", "", c.Request.TLS != nil, true)
+	return
+}
+
+// TakeState 取出 state，必须和 cookie 中的一致，每个 state 只能用一次
+func TakeState(c *gin.Context, state string) (st State, err error) {
+	cookie, _ := c.Cookie(StateCookie)
+	c.SetCookie(StateCookie, "", -1, "/", "", c.Request.TLS != nil, true)
+	if state == "" || cookie != state {
+		return st, errState
+	}
+	key := statePrefix + state
+	val, err := global.Redis.Get(key).Result()
+	if err != nil {
+		return st, errState
+	}
+	if n, _ := global.Redis.Del(key).Result(); n == 0 {
+		return st, errState
+	}
+	err = json.Unmarshal([]byte(val), &st)
+	return
+}
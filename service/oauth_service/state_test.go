@@ -0,0 +1,57 @@
+// Path: ./service/oauth_service/state_test.go
+
+package oauth_service
+
+import (
+	"blogX_server/utils/testutil"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// callback 模拟回调请求，cookie 为空时不带 cookie
+func callback(cookie string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/oauth/github/callback", nil)
+	if cookie != "" {
+		c.Request.AddCookie(&http.Cookie{Name: StateCookie, Value: cookie})
+	}
+	return c
+}
+
+func TestState(t *testing.T) {
+	mr := testutil.Setup(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/oauth/github/authorize", nil)
+	state, err := NewState(c, State{Provider: "github", UserID: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != state || !cookies[0].HttpOnly {
+		t.Fatalf("cookies = %+v", cookies)
+	}
+
+	if _, err = TakeState(callback(""), state); err == nil {
+		t.Error("没有 cookie 的回调应该失败")
+	}
+	if _, err = TakeState(callback("other"), state); err == nil {
+		t.Error("cookie 不一致的回调应该失败")
+	}
+	st, err := TakeState(callback(state), state)
+	if err != nil || st.Provider != "github" || st.UserID != 3 {
+		t.Fatalf("正常回调 st = %+v, err = %v", st, err)
+	}
+	if _, err = TakeState(callback(state), state); err == nil {
+		t.Error("state 只能用一次")
+	}
+
+	state, _ = NewState(c, State{Provider: "github"})
+	mr.FastForward(StateExpiry)
+	if _, err = TakeState(callback(state), state); err == nil {
+		t.Error("过期的 state 应该失败")
+	}
+}
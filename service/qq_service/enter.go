@@ -0,0 +1,149 @@
+// Path: ./service/qq_service/enter.go
+
+package qq_service
+
+import (
+	"blogX_server/conf"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultHost QQ 互联的接口地址
+const DefaultHost = "https://graph.qq.com"
+
+// Client QQ 互联登录，Host 在测试时指向本地的假服务
+type Client struct {
+	Host     string
+	AppID    string
+	AppKey   string
+	Redirect string
+	HTTP     *http.Client
+}
+
+func NewClient(q conf.QQ) *Client {
+	return &Client{
+		Host:     DefaultHost,
+		AppID:    q.AppID,
+		AppKey:   q.AppKey,
+		Redirect: q.Redirect,
+		HTTP:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// UserInfo 登录的 QQ 用户
+type UserInfo struct {
+	OpenID    string
+	Nickname  string
+	AvatarURL string
+}
+
+// Login 用回调拿到的 code 换 access token，再取 openid 和资料
+func (cl *Client) Login(code string) (info UserInfo, err error) {
+	if cl.AppID == "" || cl.AppKey == "" {
+		return info, errors.New("站点未配置QQ登录")
+	}
+	token, err := cl.accessToken(code)
+	if err != nil {
+		return
+	}
+	info.OpenID, err = cl.openID(token)
+	if err != nil {
+		return
+	}
+	err = cl.userInfo(token, &info)
+	return
+}
+
+// apiError 接口出错时返回的内容
+type apiError struct {
+	Error            int    `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (cl *Client) get(path string, query url.Values, data any) error {
+	resp, err := cl.HTTP.Get(cl.Host + path + "?" + query.Encode())
+	if err != nil {
+		return fmt.Errorf("请求QQ接口失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("请求QQ接口失败: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(data)
+}
+
+func (cl *Client) accessToken(code string) (string, error) {
+	var data struct {
+		apiError
+		AccessToken string `json:"access_token"`
+	}
+	err := cl.get("/oauth2.0/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {cl.AppID},
+		"client_secret": {cl.AppKey},
+		"code":          {code},
+		"redirect_uri":  {cl.Redirect},
+		"fmt":           {"json"},
+	}, &data)
+	if err != nil {
+		return "", err
+	}
+	if data.Error != 0 || data.AccessToken == "" {
+		return "", fmt.Errorf("获取access token失败: %d %s", data.Error, data.ErrorDescription)
+	}
+	return data.AccessToken, nil
+}
+
+func (cl *Client) openID(token string) (string, error) {
+	var data struct {
+		apiError
+		ClientID string `json:"client_id"`
+		OpenID   string `json:"openid"`
+	}
+	err := cl.get("/oauth2.0/me", url.Values{
+		"access_token": {token},
+		"fmt":          {"json"},
+	}, &data)
+	if err != nil {
+		return "", err
+	}
+	if data.Error != 0 || data.OpenID == "" {
+		return "", fmt.Errorf("获取openid失败: %d %s", data.Error, data.ErrorDescription)
+	}
+	// access token 必须是发给本站的
+	if data.ClientID != cl.AppID {
+		return "", errors.New("access token 不属于本站")
+	}
+	return data.OpenID, nil
+}
+
+func (cl *Client) userInfo(token string, info *UserInfo) error {
+	var data struct {
+		Ret      int    `json:"ret"`
+		Msg      string `json:"msg"`
+		Nickname string `json:"nickname"`
+		Avatar1  string `json:"figureurl_qq_1"` // 40x40
+		Avatar2  string `json:"figureurl_qq_2"` // 100x100，不一定有
+	}
+	err := cl.get("/user/get_user_info", url.Values{
+		"access_token":       {token},
+		"oauth_consumer_key": {cl.AppID},
+		"openid":             {info.OpenID},
+	}, &data)
+	if err != nil {
+		return err
+	}
+	if data.Ret != 0 {
+		return fmt.Errorf("获取QQ用户资料失败: %d %s", data.Ret, data.Msg)
+	}
+	info.Nickname = data.Nickname
+	info.AvatarURL = data.Avatar2
+	if info.AvatarURL == "" {
+		info.AvatarURL = data.Avatar1
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Path: ./service/qq_service/enter_test.go
+
+package qq_service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testAppID  = "101000"
+	testAppKey = "secret"
+	testCode   = "good-code"
+	testToken  = "token-1"
+	testOpenID = "OPENID123"
+)
+
+// newFakeQQ 本地的假 QQ 互联服务，只认 testCode，clientID 为 /me 返回的应用
+func newFakeQQ(t *testing.T, clientID string) *httptest.Server {
+	mux := http.NewServeMux()
+	write := func(w http.ResponseWriter, v any) {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/oauth2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != testAppID || q.Get("client_secret") != testAppKey || q.Get("grant_type") != "authorization_code" {
+			write(w, map[string]any{"error": 100013, "error_description": "access token is invalid"})
+			return
+		}
+		if q.Get("code") != testCode {
+			write(w, map[string]any{"error": 100019, "error_description": "code to access token error"})
+			return
+		}
+		write(w, map[string]any{"access_token": testToken, "expires_in": "7776000", "refresh_token": "r"})
+	})
+	mux.HandleFunc("/oauth2.0/me", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != testToken {
+			write(w, map[string]any{"error": 100016, "error_description": "access token check failed"})
+			return
+		}
+		write(w, map[string]any{"client_id": clientID, "openid": testOpenID})
+	})
+	mux.HandleFunc("/user/get_user_info", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("access_token") != testToken || q.Get("openid") != testOpenID || q.Get("oauth_consumer_key") != testAppID {
+			write(w, map[string]any{"ret": 1002, "msg": "请先登录"})
+			return
+		}
+		write(w, map[string]any{
+			"ret":            0,
+			"nickname":       "小明",
+			"figureurl_qq_1": "http://q.qlogo.cn/40",
+			"figureurl_qq_2": "http://q.qlogo.cn/100",
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(srv *httptest.Server) *Client {
+	return &Client{Host: srv.URL, AppID: testAppID, AppKey: testAppKey, Redirect: "http://localhost/qq", HTTP: srv.Client()}
+}
+
+func TestLogin(t *testing.T) {
+	srv := newFakeQQ(t, testAppID)
+	info, err := newTestClient(srv).Login(testCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.OpenID != testOpenID || info.Nickname != "小明" || info.AvatarURL != "http://q.qlogo.cn/100" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestLoginBadCode(t *testing.T) {
+	srv := newFakeQQ(t, testAppID)
+	_, err := newTestClient(srv).Login("bad-code")
+	if err == nil || !strings.Contains(err.Error(), "100019") {
+		t.Fatalf("want code error, got %v", err)
+	}
+}
+
+func TestLoginOtherApp(t *testing.T) {
+	// access token 是发给其他应用的，不能用来登录本站
+	srv := newFakeQQ(t, "999999")
+	_, err := newTestClient(srv).Login(testCode)
+	if err == nil {
+		t.Fatal("want client id mismatch error")
+	}
+}
+
+func TestLoginNotConfigured(t *testing.T) {
+	srv := newFakeQQ(t, testAppID)
+	cl := newTestClient(srv)
+	cl.AppKey = ""
+	if _, err := cl.Login(testCode); err == nil {
+		t.Fatal("want not configured error")
+	}
+}
@@ -0,0 +1,106 @@
+// Path: ./service/reaction_service/enter.go
+
+package reaction_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_reaction"
+	"errors"
+	"gorm.io/gorm/clause"
+)
+
+// Target 被回应的对象，回应评论时 Comment 不为空
+type Target struct {
+	Article models.ArticleModel
+	Comment *models.CommentModel
+}
+
+// GetTarget 只能回应已发布的文章和已发布文章下已发布的评论
+func GetTarget(t enum.ReactionTargetType, id uint) (target Target, err error) {
+	switch t {
+	case enum.ReactionTargetArticle:
+		err = global.DB.Take(&target.Article, "id = ? and status = ?", id, enum.ArticleStatusPublish).Error
+		if err != nil {
+			return target, errors.New("文章不存在")
+		}
+	case enum.ReactionTargetComment:
+		var cmt models.CommentModel
+		err = global.DB.Preload("ArticleModel").Take(&cmt, "id = ? and status = ?", id, enum.CommentStatusPublished).Error
+		if err != nil || cmt.ArticleModel.Status != enum.ArticleStatusPublish {
+			return target, errors.New("评论不存在")
+		}
+		target.Article = cmt.ArticleModel
+		target.Comment = &cmt
+	default:
+		return target, errors.New("回应对象错误")
+	}
+	return target, nil
+}
+
+// Toggle 回应过就取消，没有就加上，返回切换后是否回应
+// created 为 true 表示这次新加了回应，同时点击时只有一次生效
+func Toggle(userID uint, t enum.ReactionTargetType, id uint, emoji string) (reacted, created bool, err error) {
+	key := redis_reaction.Key{TargetType: t, TargetID: id, Emoji: emoji}
+
+	result := global.DB.Where("user_id = ? and target_type = ? and target_id = ? and emoji = ?", userID, t, id, emoji).
+		Delete(&models.ReactionModel{})
+	if result.Error != nil {
+		return false, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		redis_reaction.Update(key, -1)
+		return false, false, nil
+	}
+
+	result = global.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ReactionModel{
+		UserID:     userID,
+		TargetType: t,
+		TargetID:   id,
+		Emoji:      emoji,
+	})
+	if result.Error != nil {
+		return false, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return true, false, nil
+	}
+	redis_reaction.Update(key, 1)
+	return true, true, nil
+}
+
+// Count 一个表情的回应数
+type Count struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"` // 当前用户是否回应过，未登录为 false
+}
+
+// GetCounts 对象各表情的回应数，按配置的表情顺序，数据库中的数加上 redis 中还没同步的增量
+func GetCounts(t enum.ReactionTargetType, id uint, userID uint) []Count {
+	emojis := global.Config.Site.Article.ReactionList()
+
+	var rows []models.ReactionCountModel
+	global.DB.Where("target_type = ? and target_id = ? and emoji in ?", t, id, emojis).Find(&rows)
+	counts := redis_reaction.GetCounts(t, id, emojis)
+	for _, r := range rows {
+		counts[r.Emoji] += r.Count
+	}
+
+	reacted := map[string]bool{}
+	if userID != 0 {
+		var mine []string
+		global.DB.Model(&models.ReactionModel{}).
+			Where("user_id = ? and target_type = ? and target_id = ?", userID, t, id).Pluck("emoji", &mine)
+		for _, e := range mine {
+			reacted[e] = true
+		}
+	}
+
+	list := make([]Count, 0, len(emojis))
+	for _, e := range emojis {
+		list = append(list, Count{Emoji: e, Count: max(counts[e], 0), Reacted: reacted[e]})
+	}
+	return list
+}
@@ -0,0 +1,50 @@
+// Path: ./service/redis_service/redis_cache/conditional.go
+
+package redis_cache
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETag 根据响应内容生成 ETag
+func ETag(body string) string {
+	sum := md5.Sum([]byte(body))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// NotModified 设置 ETag / Last-Modified 响应头，并判断客户端缓存是否仍然有效
+// 有效时直接响应 304 并返回 true，调用方不需要再写响应体
+// lastModified 为 http.TimeFormat 格式，可以为空
+func NotModified(c *gin.Context, etag, lastModified string) bool {
+	c.Header("ETag", etag)
+	if lastModified != "" {
+		c.Header("Last-Modified", lastModified)
+	}
+
+	// If-None-Match 优先
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				c.Status(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := c.GetHeader("If-Modified-Since"); ims != "" && lastModified != "" {
+		since, err1 := http.ParseTime(ims)
+		modified, err2 := http.ParseTime(lastModified)
+		if err1 == nil && err2 == nil && !modified.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
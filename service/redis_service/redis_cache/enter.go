@@ -17,6 +17,9 @@ type CacheOption struct {
 	NoCache func(c *gin.Context) bool
 	IsUri   bool
 	UriKey  string // 用于 URI 参数，如 ":id"
+
+	ContentType string // 命中缓存时的响应类型，留空为 json
+	ETag        bool   // 是否支持条件请求（ETag / Last-Modified），命中时可以直接返回 304
 }
 
 type CacheMiddlewarePrefix string
@@ -25,8 +28,13 @@ const (
 	CacheBannerPrefix        CacheMiddlewarePrefix = "cache_banner_"
 	CacheTagsPrefix          CacheMiddlewarePrefix = "cache_tags_"
 	CacheArticleDetailPrefix CacheMiddlewarePrefix = "cache_article_detail_"
+	CacheFeedPrefix          CacheMiddlewarePrefix = "cache_feed_"
+	CacheSitemapPrefix       CacheMiddlewarePrefix = "cache_sitemap_"
 )
 
+// CacheLastModifiedSuffix 缓存响应的 Last-Modified 头，和缓存内容放在一起（同一前缀，可以一起删除）
+const CacheLastModifiedSuffix = "_last_modified"
+
 func CacheOpen(key, value string, expiry time.Duration) {
 	global.Redis.Set(key, value, expiry)
 }
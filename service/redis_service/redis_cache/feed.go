@@ -0,0 +1,32 @@
+// Path: ./service/redis_service/redis_cache/feed.go
+
+package redis_cache
+
+import (
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// NewFeedCacheOption 订阅源缓存，format 为 rss / atom / json，不同格式分开缓存
+// 文章发布、修改、删除时用 CacheCloseAll(CacheFeedPrefix) 一起失效
+func NewFeedCacheOption(format string) CacheOption {
+	contentType := "application/json; charset=utf-8"
+	switch format {
+	case "rss":
+		contentType = "application/rss+xml; charset=utf-8"
+	case "atom":
+		contentType = "application/atom+xml; charset=utf-8"
+	case "json":
+		contentType = "application/feed+json; charset=utf-8"
+	}
+	return CacheOption{
+		Prefix:      CacheFeedPrefix + CacheMiddlewarePrefix(format+"_"),
+		Expiry:      time.Hour,
+		Params:      []string{"userID", "categoryID", "tag"},
+		ContentType: contentType,
+		ETag:        true,
+		NoCache: func(c *gin.Context) bool {
+			return false
+		},
+	}
+}
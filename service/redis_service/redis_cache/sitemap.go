@@ -0,0 +1,24 @@
+// Path: ./service/redis_service/redis_cache/sitemap.go
+
+package redis_cache
+
+import (
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// NewSitemapCacheOption sitemap 缓存，sitemap.xml 和各个分页文件分开缓存
+// 爬虫对时效要求不高，文章变动时不主动失效，等过期即可
+func NewSitemapCacheOption() CacheOption {
+	return CacheOption{
+		Prefix:      CacheSitemapPrefix,
+		Expiry:      time.Hour,
+		IsUri:       true,
+		UriKey:      "name",
+		ContentType: "application/xml; charset=utf-8",
+		ETag:        true,
+		NoCache: func(c *gin.Context) bool {
+			return false
+		},
+	}
+}
@@ -73,6 +73,15 @@ func BlockJWTToken(token string, value BlockType) {
 	logrus.Infof("token [%s...%s] blocked", token[:4], token[len(token)-4:])
 }
 
+// BlockSession 下线一个登录设备，设备上还没过期的 token 同时失效
+func BlockSession(sessionID uint) {
+	key := fmt.Sprintf("jwt_session_block_%d", sessionID)
+	err := global.Redis.Set(key, DeviceBlockType.String(), global.Config.Jwt.AccessExpiry()).Err()
+	if err != nil {
+		logrus.Error("failed to set redis: ", err)
+	}
+}
+
 // IsBlockedJWTToken checks if a provided JWT token is blocked by querying its status from the Redis database.
 func IsBlockedJWTToken(token string) (blockType BlockType, ok bool) {
 	claims, err := jwts.ParseToken(token)
@@ -80,6 +89,14 @@ func IsBlockedJWTToken(token string) (blockType BlockType, ok bool) {
 		return
 	}
 
+	// 设备已下线
+	if claims.SessionID != 0 {
+		n, _ := global.Redis.Exists(fmt.Sprintf("jwt_session_block_%d", claims.SessionID)).Result()
+		if n > 0 {
+			return DeviceBlockType, true
+		}
+	}
+
 	// 增加前缀
 	key1 := fmt.Sprintf("jwt_block_%s", token)
 	key2 := fmt.Sprintf("%dpassword_update", claims.UserID)
@@ -106,10 +123,8 @@ func IsBlockedJWTToken(token string) (blockType BlockType, ok bool) {
 	return blockType, true
 }
 
+// IsBlockedJWTTokenByGin 和解析 token 时从同样的位置取 token，包括 Authorization: Bearer
 func IsBlockedJWTTokenByGin(c *gin.Context) (blockType BlockType, ok bool) {
-	token := c.GetHeader("token")
-	if token == "" {
-		token = c.Query("token")
-	}
+	token, _ := jwts.GetTokenFromRequest(c)
 	return IsBlockedJWTToken(token)
 }
@@ -0,0 +1,127 @@
+// Path: ./service/redis_service/redis_reaction/enter.go
+
+package redis_reaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models/enum"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+)
+
+// ReactionCount 表情回应数的增量，field 为 "对象类型_对象id_表情"
+const ReactionCount = "reaction_count_key"
+
+// Key 某个对象的某个表情
+type Key struct {
+	TargetType enum.ReactionTargetType
+	TargetID   uint
+	Emoji      string
+}
+
+func (k Key) field() string {
+	return fmt.Sprintf("%d_%d_%s", k.TargetType, k.TargetID, k.Emoji)
+}
+
+// parseField 解析 field，格式不对的返回 false
+func parseField(field string) (k Key, ok bool) {
+	parts := strings.SplitN(field, "_", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return
+	}
+	t, err1 := strconv.Atoi(parts[0])
+	id, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return
+	}
+	return Key{TargetType: enum.ReactionTargetType(t), TargetID: uint(id), Emoji: parts[2]}, true
+}
+
+// Update 增减回应数
+func Update(k Key, delta int) {
+	global.Redis.HIncrBy(ReactionCount, k.field(), int64(delta))
+}
+
+// GetCounts 某个对象各表情还没同步的增量
+func GetCounts(t enum.ReactionTargetType, id uint, emojis []string) map[string]int {
+	fields := make([]string, 0, len(emojis))
+	for _, e := range emojis {
+		fields = append(fields, Key{t, id, e}.field())
+	}
+	counts := make(map[string]int, len(emojis))
+	if len(fields) == 0 {
+		return counts
+	}
+	vals, err := global.Redis.HMGet(ReactionCount, fields...).Result()
+	if err != nil {
+		return counts
+	}
+	for i, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			continue
+		}
+		counts[emojis[i]] = n
+	}
+	return counts
+}
+
+// GetAll 所有还没同步的增量
+func GetAll() map[Key]int {
+	res, err := global.Redis.HGetAll(ReactionCount).Result()
+	if err != nil {
+		return nil
+	}
+	mps := make(map[Key]int, len(res))
+	for f, v := range res {
+		k, ok := parseField(f)
+		n, err := strconv.Atoi(v)
+		if !ok || err != nil {
+			continue // skip this invalid entry
+		}
+		mps[k] = n
+	}
+	return mps
+}
+
+// subtractScript 原子地减掉已读取的增量，减到 0 的 field 删除
+var subtractScript = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	if redis.call("HINCRBY", KEYS[1], ARGV[i], -tonumber(ARGV[i + 1])) == 0 then
+		redis.call("HDEL", KEYS[1], ARGV[i])
+	end
+end
+return 0
+`)
+
+// Subtract 同步时减掉 GetAll 读到的增量，不直接删除整个 hash，读取之后新增的增量留到下次同步
+func Subtract(mps map[Key]int) {
+	if len(mps) == 0 {
+		return
+	}
+	args := make([]any, 0, 2*len(mps))
+	for k, n := range mps {
+		args = append(args, k.field(), n)
+	}
+	err := subtractScript.Run(global.Redis, []string{ReactionCount}, args...).Err()
+	if err != nil {
+		logrus.Errorf("Failed to subtract reaction redis cache: %v", err)
+	}
+}
+
+// Rollback 写入数据库失败时把增量加回去，期间新增的增量不受影响
+func Rollback(mps map[Key]int) error {
+	pipe := global.Redis.Pipeline()
+	for k, n := range mps {
+		pipe.HIncrBy(ReactionCount, k.field(), int64(n))
+	}
+	_, err := pipe.Exec()
+	return err
+}
@@ -0,0 +1,48 @@
+// Path: ./service/redis_service/redis_reaction/enter_test.go
+
+package redis_reaction
+
+import (
+	"blogX_server/global"
+	"blogX_server/models/enum"
+	"blogX_server/utils/testutil"
+	"testing"
+)
+
+func TestFieldRoundTrip(t *testing.T) {
+	for _, k := range []Key{
+		{enum.ReactionTargetArticle, 12, "👍"},
+		{enum.ReactionTargetComment, 3, "❤️"},
+	} {
+		got, ok := parseField(k.field())
+		if !ok || got != k {
+			t.Fatalf("parseField(%q) = %v, %v", k.field(), got, ok)
+		}
+	}
+	for _, f := range []string{"", "1_2", "1_2_", "a_2_👍", "1_b_👍"} {
+		if _, ok := parseField(f); ok {
+			t.Fatalf("parseField(%q) should fail", f)
+		}
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	testutil.Setup(t)
+	like := Key{enum.ReactionTargetArticle, 1, "👍"}
+	heart := Key{enum.ReactionTargetComment, 2, "❤️"}
+	Update(like, 2)
+	Update(heart, 1)
+
+	deltas := GetAll()
+	// 读取之后、减掉之前又有新的回应
+	Update(like, 1)
+	Subtract(deltas)
+
+	got := GetAll()
+	if len(got) != 1 || got[like] != 1 {
+		t.Errorf("减掉之后剩下 %v, want 读取之后新增的 1 个", got)
+	}
+	if ok, _ := global.Redis.HExists(ReactionCount, heart.field()).Result(); ok {
+		t.Error("减到 0 的 field 应该删除")
+	}
+}
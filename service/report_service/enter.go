@@ -0,0 +1,90 @@
+// Path: ./service/report_service/enter.go
+
+package report_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils"
+)
+
+// Key 被举报的对象
+type Key struct {
+	Type enum.ReportTargetType `json:"targetType"`
+	ID   uint                  `json:"targetID"`
+}
+
+// Target 被举报对象的摘要，管理员处理时展示
+type Target struct {
+	Key
+	Exists        bool                 `json:"exists"`    // 已被删除的为 false
+	Title         string               `json:"title"`     // 文章标题、评论内容摘要或用户昵称
+	ArticleID     uint                 `json:"articleID"` // 评论所在的文章，方便跳转
+	OwnerID       uint                 `json:"ownerID"`   // 发布者，举报用户时为用户自己
+	OwnerNickname string               `json:"ownerNickname"`
+	Article       *models.ArticleModel `json:"-"`
+	Comment       *models.CommentModel `json:"-"`
+}
+
+// GetTargets 批量取被举报对象的摘要，每种对象查一次
+func GetTargets(keys []Key) map[Key]Target {
+	idMap := map[enum.ReportTargetType][]uint{}
+	targets := make(map[Key]Target, len(keys))
+	for _, k := range keys {
+		idMap[k.Type] = append(idMap[k.Type], k.ID)
+		targets[k] = Target{Key: k}
+	}
+
+	if idList := idMap[enum.ReportTargetArticle]; len(idList) > 0 {
+		var list []models.ArticleModel
+		global.DB.Preload("UserModel").Where("id in ?", idList).Find(&list)
+		for i := range list {
+			a := &list[i]
+			targets[Key{enum.ReportTargetArticle, a.ID}] = Target{
+				Key:           Key{enum.ReportTargetArticle, a.ID},
+				Exists:        true,
+				Title:         a.Title,
+				ArticleID:     a.ID,
+				OwnerID:       a.UserID,
+				OwnerNickname: a.UserModel.Nickname,
+				Article:       a,
+			}
+		}
+	}
+	if idList := idMap[enum.ReportTargetComment]; len(idList) > 0 {
+		var list []models.CommentModel
+		global.DB.Preload("UserModel").Where("id in ?", idList).Find(&list)
+		for i := range list {
+			cmt := &list[i]
+			targets[Key{enum.ReportTargetComment, cmt.ID}] = Target{
+				Key:           Key{enum.ReportTargetComment, cmt.ID},
+				Exists:        true,
+				Title:         utils.ExtractContent(cmt.Content, 64),
+				ArticleID:     cmt.ArticleID,
+				OwnerID:       cmt.UserID,
+				OwnerNickname: cmt.UserModel.Nickname,
+				Comment:       cmt,
+			}
+		}
+	}
+	if idList := idMap[enum.ReportTargetUser]; len(idList) > 0 {
+		var list []models.UserModel
+		global.DB.Where("id in ?", idList).Find(&list)
+		for _, u := range list {
+			targets[Key{enum.ReportTargetUser, u.ID}] = Target{
+				Key:           Key{enum.ReportTargetUser, u.ID},
+				Exists:        true,
+				Title:         u.Nickname,
+				OwnerID:       u.ID,
+				OwnerNickname: u.Nickname,
+			}
+		}
+	}
+	return targets
+}
+
+// GetTarget 取单个被举报对象
+func GetTarget(k Key) Target {
+	return GetTargets([]Key{k})[k]
+}
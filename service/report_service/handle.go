@@ -0,0 +1,170 @@
+// Path: ./service/report_service/handle.go
+
+package report_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/series_service"
+	"blogX_server/service/user_service"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ErrReported 举报过的对象还没有处理
+var ErrReported = errors.New("已经举报过了，请等待处理")
+
+// Submit 举报一个对象，同一个对象在处理前只能举报一次，处理过的再次举报重新进入待处理
+func Submit(userID uint, k Key, reason enum.ReportReason, content string) error {
+	var report models.ReportModel
+	err := global.DB.Take(&report, "user_id = ? and target_type = ? and target_id = ?", userID, k.Type, k.ID).Error
+	switch {
+	case err == nil && report.Status == enum.ReportStatusPending:
+		return ErrReported
+	case err == nil:
+		return global.DB.Model(&report).Updates(map[string]any{
+			"reason":     reason,
+			"content":    content,
+			"status":     enum.ReportStatusPending,
+			"action":     0,
+			"note":       "",
+			"handler_id": nil,
+			"handled_at": nil,
+		}).Error
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return global.DB.Create(&models.ReportModel{
+			UserID:     userID,
+			TargetType: k.Type,
+			TargetID:   k.ID,
+			Reason:     reason,
+			Content:    content,
+			Status:     enum.ReportStatusPending,
+		}).Error
+	}
+	return err
+}
+
+// Pending 对象收到的待处理举报
+func Pending(k Key) (list []models.ReportModel) {
+	global.DB.Where("target_type = ? and target_id = ? and status = ?", k.Type, k.ID, enum.ReportStatusPending).Find(&list)
+	return
+}
+
+// Resolve 先记录处理结果再执行处理，逐条带上状态条件，同时处理时每条举报只有一方记录成功
+// 返回记录成功的举报，为空表示已经被其他管理员处理了，不能再执行处理
+func Resolve(reports []models.ReportModel, action enum.ReportAction, note string, handlerID uint) (list []models.ReportModel, err error) {
+	status := enum.ReportStatusResolved
+	if action == enum.ReportActionDismiss {
+		status = enum.ReportStatusDismissed
+	}
+	now := time.Now()
+	for _, r := range reports {
+		result := global.DB.Model(&models.ReportModel{}).
+			Where("id = ? and status = ?", r.ID, enum.ReportStatusPending).
+			Updates(map[string]any{
+				"status":     status,
+				"action":     action,
+				"note":       note,
+				"handler_id": handlerID,
+				"handled_at": now,
+			})
+		if result.Error != nil {
+			return list, result.Error
+		}
+		if result.RowsAffected > 0 {
+			list = append(list, r)
+		}
+	}
+	return
+}
+
+// Unresolve 执行处理失败时，把 Resolve 记录的举报恢复为待处理
+func Unresolve(reports []models.ReportModel, handlerID uint) error {
+	var idList []uint
+	for _, r := range reports {
+		idList = append(idList, r.ID)
+	}
+	if len(idList) == 0 {
+		return nil
+	}
+	return global.DB.Model(&models.ReportModel{}).
+		Where("id in ? and handler_id = ?", idList, handlerID).
+		Updates(map[string]any{
+			"status":     enum.ReportStatusPending,
+			"action":     0,
+			"note":       "",
+			"handler_id": nil,
+			"handled_at": nil,
+		}).Error
+}
+
+// Apply 对被举报的对象执行处理，返回给发布者的通知，驳回时不通知
+func Apply(t Target, action enum.ReportAction, note string, banDays int, operatorID uint) (title, content string, err error) {
+	reason := "被举报违规"
+	if note != "" {
+		reason = note
+	}
+
+	switch action {
+	case enum.ReportActionDismiss:
+		return
+	case enum.ReportActionHide:
+		switch {
+		case t.Article != nil:
+			// 退回修改，作者修改后可以重新提交审核
+			err = global.DB.Model(t.Article).Update("status", enum.ArticleStatusRevise).Error
+			if err != nil {
+				return
+			}
+			redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, t.Article.ID))
+			series_service.SyncArticleSeries(t.Article.ID)
+			redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+			title, content = "您的文章被退回修改", fmt.Sprintf("您的文章「%s」因%s被退回修改", t.Title, reason)
+		case t.Comment != nil:
+			err = transaction.HideCommentTx(t.Comment, reason)
+			if err != nil {
+				return
+			}
+			title, content = "您的评论被隐藏", fmt.Sprintf("您的评论「%s」因%s被隐藏", t.Title, reason)
+		default:
+			err = errors.New("用户不能隐藏，请选择警告或封禁")
+		}
+	case enum.ReportActionDelete:
+		switch {
+		case t.Article != nil:
+			err = transaction.SoftRemoveArticleTx(t.Article, operatorID)
+			if err != nil {
+				return
+			}
+			title, content = "您的文章被删除", fmt.Sprintf("您的文章「%s」因%s被删除", t.Title, reason)
+		case t.Comment != nil:
+			err = transaction.RemoveComment(t.Comment, operatorID)
+			if err != nil {
+				return
+			}
+			title, content = "您的评论被删除", fmt.Sprintf("您的评论「%s」因%s被删除", t.Title, reason)
+		default:
+			err = errors.New("用户不能删除，请选择警告或封禁")
+		}
+	case enum.ReportActionWarn:
+		title = "违规警告"
+		content = fmt.Sprintf("您发布的%s「%s」因%s被警告，多次违规将被封禁", t.Type, t.Title, reason)
+		if t.Type == enum.ReportTargetUser {
+			content = fmt.Sprintf("您的账号因%s被警告，多次违规将被封禁", reason)
+		}
+	case enum.ReportActionBan:
+		var until time.Time
+		until, err = user_service.Ban(t.OwnerID, banDays, reason)
+		if err != nil {
+			return
+		}
+		title = "账号被封禁"
+		content = fmt.Sprintf("您的账号因%s被封禁至 %s", reason, until.Format("2006-01-02 15:04"))
+	}
+	return
+}
@@ -0,0 +1,237 @@
+// Path: ./service/report_service/handle_test.go
+
+package report_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_article"
+	"blogX_server/service/redis_service/redis_comment"
+	"blogX_server/utils/testutil"
+	"testing"
+)
+
+func setupReport(t *testing.T) {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.UserLoginModel{},
+		&models.AccessTokenModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.CommentModel{},
+		&models.ReportModel{},
+		&models.RecycleModel{},
+		&models.SeriesModel{},
+		&models.SeriesArticleModel{},
+	)
+	global.DB.Create(&[]models.UserModel{
+		{Username: "author", Email: "author@example.com", Nickname: "author"},
+		{Username: "reporter", Email: "reporter@example.com", Nickname: "reporter"},
+	})
+}
+
+func TestSubmit(t *testing.T) {
+	setupReport(t)
+	k := Key{Type: enum.ReportTargetComment, ID: 1}
+
+	if err := Submit(2, k, enum.ReportReasonOtherType, "第一次"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Submit(2, k, enum.ReportReasonOtherType, "重复"); err != ErrReported {
+		t.Fatalf("待处理时重复举报 err = %v, want ErrReported", err)
+	}
+	if list := Pending(k); len(list) != 1 || list[0].Content != "第一次" {
+		t.Fatalf("pending = %+v", list)
+	}
+
+	if list, err := Resolve(Pending(k), enum.ReportActionDismiss, "没有违规", 1); err != nil || len(list) != 1 {
+		t.Fatalf("驳回 list = %v, err = %v", list, err)
+	}
+	var r models.ReportModel
+	global.DB.Take(&r, 1)
+	if r.Status != enum.ReportStatusDismissed || r.HandlerID == nil || r.Note != "没有违规" {
+		t.Fatalf("驳回后 report = %+v", r)
+	}
+	if len(Pending(k)) != 0 {
+		t.Fatal("处理过的举报不应该是待处理")
+	}
+
+	// 处理过的再次举报，同一条记录重新进入待处理
+	if err := Submit(2, k, enum.ReportReasonOtherType, "又出现了"); err != nil {
+		t.Fatal(err)
+	}
+	r = models.ReportModel{}
+	global.DB.Take(&r, 1)
+	if r.Status != enum.ReportStatusPending || r.HandlerID != nil || r.HandledAt != nil || r.Note != "" || r.Content != "又出现了" {
+		t.Errorf("重新举报后 report = %+v", r)
+	}
+	var count int64
+	global.DB.Model(&models.ReportModel{}).Count(&count)
+	if count != 1 {
+		t.Errorf("举报记录 %d 条，want 1", count)
+	}
+}
+
+func TestResolveTwice(t *testing.T) {
+	setupReport(t)
+	k := Key{Type: enum.ReportTargetUser, ID: 1}
+	if err := Submit(2, k, enum.ReportReasonOtherType, "违规"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 两个管理员同时打开，拿到的都是同一条待处理的举报
+	first, second := Pending(k), Pending(k)
+	list, err := Resolve(first, enum.ReportActionBan, "", 1)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("第一次处理 list = %v, err = %v", list, err)
+	}
+	list, err = Resolve(second, enum.ReportActionWarn, "", 2)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("第二次处理不应该记录成功, list = %v, err = %v", list, err)
+	}
+	var r models.ReportModel
+	global.DB.Take(&r, first[0].ID)
+	if r.Action != enum.ReportActionBan || r.HandlerID == nil || *r.HandlerID != 1 {
+		t.Errorf("处理结果被覆盖了: %+v", r)
+	}
+
+	// 执行处理失败时恢复为待处理，可以重新处理
+	if err = Unresolve(first, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(Pending(k)) != 1 {
+		t.Fatal("恢复后应该是待处理")
+	}
+	if list, _ = Resolve(second, enum.ReportActionWarn, "", 2); len(list) != 1 {
+		t.Error("恢复后可以重新处理")
+	}
+}
+
+// newThread 一篇已发布的文章，根评论 1 下有回复 2，回复 2 下有回复 3，另有根评论 4
+func newThread(t *testing.T) (a models.ArticleModel, list []models.CommentModel) {
+	a = models.ArticleModel{Title: "a", Content: "x", UserID: 1, Status: enum.ArticleStatusPublish, CommentCount: 4}
+	global.DB.Create(&a)
+	root := uint(1)
+	parent2 := uint(2)
+	list = []models.CommentModel{
+		{Content: "1", UserID: 1, ArticleID: a.ID, ReplyCount: 2, Status: enum.CommentStatusPublished},
+		{Content: "2", UserID: 1, ArticleID: a.ID, ParentID: &root, RootID: &root, Depth: 1, ReplyCount: 1, Status: enum.CommentStatusPublished},
+		{Content: "3", UserID: 1, ArticleID: a.ID, ParentID: &parent2, RootID: &root, Depth: 2, Status: enum.CommentStatusPublished},
+		{Content: "4", UserID: 1, ArticleID: a.ID, Status: enum.CommentStatusPublished},
+	}
+	for i := range list {
+		if err := global.DB.Create(&list[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+// commentCount 评论数的变化先记在 redis 中，由定时任务同步到数据库
+func commentCount(a models.ArticleModel) int {
+	return a.CommentCount + redis_article.GetArticleComment(a.ID)
+}
+
+func TestApplyCommentCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      enum.ReportAction
+		target      int // 被举报的评论下标
+		wantArticle int // 文章的评论数
+		wantRoot    int // 根评论 1 的回复数
+		wantNotify  bool
+	}{
+		{"驳回", enum.ReportActionDismiss, 1, 4, 2, false},
+		{"警告", enum.ReportActionWarn, 1, 4, 2, true},
+		{"封禁", enum.ReportActionBan, 1, 4, 2, true},
+		{"隐藏回复", enum.ReportActionHide, 1, 2, 0, true},
+		{"删除回复", enum.ReportActionDelete, 1, 2, 0, true},
+		{"删除根评论", enum.ReportActionDelete, 3, 3, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupReport(t)
+			a, list := newThread(t)
+
+			target := GetTarget(Key{Type: enum.ReportTargetComment, ID: list[tt.target].ID})
+			title, _, err := Apply(target, tt.action, "", 3, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (title != "") != tt.wantNotify {
+				t.Errorf("通知标题 = %q", title)
+			}
+			if got := commentCount(a); got != tt.wantArticle {
+				t.Errorf("文章评论数 = %d, want %d", got, tt.wantArticle)
+			}
+			if got := list[0].ReplyCount + redis_comment.GetCommentReplyCount(list[0].ID); got != tt.wantRoot {
+				t.Errorf("根评论回复数 = %d, want %d", got, tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestApplyHideTwice(t *testing.T) {
+	setupReport(t)
+	a, list := newThread(t)
+
+	target := GetTarget(Key{Type: enum.ReportTargetComment, ID: list[3].ID})
+	if _, _, err := Apply(target, enum.ReportActionHide, "", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	// 同一条评论被再次处理（如两个管理员同时处理），评论数不能重复扣除
+	if _, _, err := Apply(target, enum.ReportActionHide, "", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := commentCount(a); got != 3 {
+		t.Errorf("文章评论数 = %d, want 3", got)
+	}
+	var cmt models.CommentModel
+	global.DB.Take(&cmt, list[3].ID)
+	if cmt.Status != enum.CommentStatusRejected {
+		t.Errorf("隐藏后评论 status = %d", cmt.Status)
+	}
+}
+
+func TestApplyArticle(t *testing.T) {
+	setupReport(t)
+	a, _ := newThread(t)
+	k := Key{Type: enum.ReportTargetArticle, ID: a.ID}
+
+	if _, _, err := Apply(GetTarget(k), enum.ReportActionHide, "", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	global.DB.Take(&a, a.ID)
+	if a.Status != enum.ArticleStatusRevise {
+		t.Errorf("隐藏后文章 status = %d", a.Status)
+	}
+
+	if _, _, err := Apply(GetTarget(k), enum.ReportActionDelete, "", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if GetTarget(k).Exists {
+		t.Error("删除后文章还在")
+	}
+	var count int64
+	global.DB.Model(&models.RecycleModel{}).Where("type = ? and target_id = ?", enum.RecycleArticleType, a.ID).Count(&count)
+	if count != 1 {
+		t.Error("删除的文章没有进回收站")
+	}
+
+	if _, _, err := Apply(GetTarget(Key{Type: enum.ReportTargetUser, ID: 1}), enum.ReportActionHide, "", 0, 2); err == nil {
+		t.Error("用户不能隐藏")
+	}
+}
+
+func TestApplyBan(t *testing.T) {
+	setupReport(t)
+	if _, _, err := Apply(GetTarget(Key{Type: enum.ReportTargetUser, ID: 1}), enum.ReportActionBan, "", 3, 2); err != nil {
+		t.Fatal(err)
+	}
+	var u models.UserModel
+	global.DB.Take(&u, 1)
+	if !u.IsBanned() || u.BanReason != "被举报违规" {
+		t.Errorf("封禁后 bannedUntil = %v, reason = %q", u.BannedUntil, u.BanReason)
+	}
+}
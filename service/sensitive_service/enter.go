@@ -0,0 +1,155 @@
+// Path: ./service/sensitive_service/enter.go
+
+package sensitive_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/sensitive"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"sync/atomic"
+)
+
+// dict 内存中的词表，整体替换，检查时不需要加锁
+type dict struct {
+	matcher *sensitive.Matcher
+	words   []models.SensitiveWordModel
+}
+
+var current atomic.Pointer[dict]
+
+func newDict(words []models.SensitiveWordModel) *dict {
+	list := make([]string, 0, len(words))
+	for _, w := range words {
+		list = append(list, w.Word)
+	}
+	return &dict{matcher: sensitive.New(list), words: words}
+}
+
+// Load 从数据库加载词表，启动时和词表修改后调用
+func Load() error {
+	var words []models.SensitiveWordModel
+	if err := global.DB.Find(&words).Error; err != nil {
+		return err
+	}
+	current.Store(newDict(words))
+	return nil
+}
+
+// Init 启动时加载，失败不影响启动，只是不过滤
+func Init() {
+	if err := Load(); err != nil {
+		logrus.Errorf("加载敏感词失败: %v", err)
+		return
+	}
+	logrus.Infof("加载敏感词 %d 个", len(current.Load().words))
+}
+
+type Hit struct {
+	Word     string               `json:"word"`
+	Category string               `json:"category"`
+	Action   enum.SensitiveAction `json:"action"`
+}
+
+type Result struct {
+	Text   string `json:"text"`   // 打码后的文本
+	Hits   []Hit  `json:"hits"`   // 命中的词，去重
+	Block  bool   `json:"block"`  // 有拦截词
+	Review bool   `json:"review"` // 有需要审核的词
+}
+
+// Check 检查文本，打码词会被替换，拦截和审核词由调用方决定如何处理
+func Check(text string) Result {
+	r := Result{Text: text}
+	d := current.Load()
+	if d == nil || text == "" {
+		return r
+	}
+	var masks []sensitive.Hit
+	seen := map[int]bool{}
+	for _, h := range d.matcher.FindAll(text) {
+		w := d.words[h.Index]
+		switch w.Action {
+		case enum.SensitiveActionBlock:
+			r.Block = true
+		case enum.SensitiveActionReview:
+			r.Review = true
+		default:
+			masks = append(masks, h)
+		}
+		if !seen[h.Index] {
+			seen[h.Index] = true
+			r.Hits = append(r.Hits, Hit{Word: w.Word, Category: w.Category, Action: w.Action})
+		}
+	}
+	r.Text = sensitive.Mask(text, masks)
+	return r
+}
+
+// words 命中的词，用于提示
+func (r Result) words(actions ...enum.SensitiveAction) string {
+	var list []string
+	for _, h := range r.Hits {
+		for _, a := range actions {
+			if h.Action == a {
+				list = append(list, h.Word)
+				break
+			}
+		}
+	}
+	return strings.Join(list, "、")
+}
+
+// Filter 检查并就地打码，命中拦截词返回错误，review 表示内容需要人工审核
+// 用于文章、评论等有审核流程的内容
+func Filter(texts ...*string) (review bool, err error) {
+	var blocked []string
+	for _, t := range texts {
+		if t == nil {
+			continue
+		}
+		r := Check(*t)
+		if r.Block {
+			blocked = append(blocked, r.words(enum.SensitiveActionBlock))
+			continue
+		}
+		*t = r.Text
+		review = review || r.Review
+	}
+	if len(blocked) > 0 {
+		return false, errors.New("内容包含敏感词：" + strings.Join(blocked, "、"))
+	}
+	return review, nil
+}
+
+// FilterNoReview 用于昵称、分类名、通知等没有审核流程的内容，需要审核的词也直接拒绝
+func FilterNoReview(texts ...*string) error {
+	var blocked []string
+	for _, t := range texts {
+		if t == nil {
+			continue
+		}
+		r := Check(*t)
+		if r.Block || r.Review {
+			blocked = append(blocked, r.words(enum.SensitiveActionBlock, enum.SensitiveActionReview))
+			continue
+		}
+		*t = r.Text
+	}
+	if len(blocked) > 0 {
+		return errors.New("内容包含敏感词：" + strings.Join(blocked, "、"))
+	}
+	return nil
+}
+
+// Validate 用于用户名这类不能打码的内容，命中任何敏感词都拒绝
+func Validate(text string) error {
+	r := Check(text)
+	if len(r.Hits) > 0 {
+		return errors.New("包含敏感词：" + r.words(enum.SensitiveActionBlock, enum.SensitiveActionMask, enum.SensitiveActionReview))
+	}
+	return nil
+}
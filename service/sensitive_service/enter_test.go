@@ -0,0 +1,66 @@
+// Path: ./service/sensitive_service/enter_test.go
+
+package sensitive_service
+
+import (
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"testing"
+)
+
+func setup() {
+	current.Store(newDict([]models.SensitiveWordModel{
+		{Word: "赌博", Category: "违法", Action: enum.SensitiveActionBlock},
+		{Word: "傻瓜", Category: "辱骂", Action: enum.SensitiveActionMask},
+		{Word: "加微信", Category: "广告", Action: enum.SensitiveActionReview},
+	}))
+}
+
+func TestCheck(t *testing.T) {
+	setup()
+	r := Check("你这个傻瓜，傻 瓜")
+	if r.Block || r.Review || r.Text != "你这个**，* *" || len(r.Hits) != 1 {
+		t.Errorf("Check() = %+v", r)
+	}
+	r = Check("加微信一起赌博")
+	if !r.Block || !r.Review || len(r.Hits) != 2 {
+		t.Errorf("Check() = %+v", r)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	setup()
+	title, content := "傻瓜", "详情加微信"
+	review, err := Filter(&title, nil, &content)
+	if err != nil || !review || title != "**" || content != "详情加微信" {
+		t.Errorf("Filter() = %v, %v, %q, %q", review, err, title, content)
+	}
+
+	content = "来赌博"
+	if _, err = Filter(&content); err == nil || err.Error() != "内容包含敏感词：赌博" {
+		t.Errorf("Filter() err = %v", err)
+	}
+
+	name := "加微信"
+	if err = FilterNoReview(&name); err == nil {
+		t.Error("FilterNoReview() 应该拒绝需要审核的词")
+	}
+	name = "大傻瓜"
+	if err = FilterNoReview(&name); err != nil || name != "大**" {
+		t.Errorf("FilterNoReview() = %v, %q", err, name)
+	}
+
+	if err = Validate("shaGua"); err != nil {
+		t.Error(err)
+	}
+	if err = Validate("我是傻瓜"); err == nil {
+		t.Error("Validate() 应该拒绝打码词")
+	}
+}
+
+func TestNotLoaded(t *testing.T) {
+	current.Store(nil)
+	if r := Check("赌博"); r.Block || r.Text != "赌博" {
+		t.Errorf("没有加载词表时不应该过滤: %+v", r)
+	}
+}
@@ -0,0 +1,51 @@
+// Path: ./service/seo_service/enter.go
+
+package seo_service
+
+import (
+	"blogX_server/global"
+	"fmt"
+	"net/url"
+)
+
+// 前端页面的地址规则，订阅源、sitemap、SEO 信息都从这里生成，保持一致
+
+// HomeURL 首页
+func HomeURL() string {
+	return global.Config.Site.BaseURL() + "/"
+}
+
+// ArticleURL 文章详情页
+func ArticleURL(aid uint) string {
+	return fmt.Sprintf("%s/article/%d", global.Config.Site.BaseURL(), aid)
+}
+
+// UserURL 用户主页
+func UserURL(uid uint) string {
+	return fmt.Sprintf("%s/user/%d", global.Config.Site.BaseURL(), uid)
+}
+
+// CategoryURL 用户主页下的某个分类
+func CategoryURL(uid, cid uint) string {
+	return fmt.Sprintf("%s/user/%d?categoryID=%d", global.Config.Site.BaseURL(), uid, cid)
+}
+
+// TagURL 按标签搜索
+func TagURL(tag string) string {
+	return fmt.Sprintf("%s/search?tag=%s", global.Config.Site.BaseURL(), url.QueryEscape(tag))
+}
+
+// AbsURL 站内上传的图片等是相对地址，对外时需要补全为绝对地址
+func AbsURL(u string) string {
+	baseURL := global.Config.Site.BaseURL()
+	if u == "" || baseURL == "" {
+		return u
+	}
+	if parsed, err := url.Parse(u); err == nil && parsed.IsAbs() {
+		return u
+	}
+	if u[0] != '/' {
+		u = "/" + u
+	}
+	return baseURL + u
+}
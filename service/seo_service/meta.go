@@ -0,0 +1,154 @@
+// Path: ./service/seo_service/meta.go
+
+package seo_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/utils/markdown"
+	"strings"
+	"time"
+)
+
+// descriptionLength 描述的长度，搜索引擎一般只展示前 150 个字左右
+const descriptionLength = 150
+
+// MetaTag 页面 head 中的 meta 标签，Open Graph 用 property，其他用 name
+type MetaTag struct {
+	Name     string `json:"name,omitempty"`
+	Property string `json:"property,omitempty"`
+	Content  string `json:"content"`
+}
+
+// ArticleSeo 文章详情页的 SEO 信息，前端渲染（或预渲染）时写入 head
+type ArticleSeo struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Keywords    string         `json:"keywords"`
+	Canonical   string         `json:"canonical"`
+	Image       string         `json:"image"`
+	Meta        []MetaTag      `json:"meta"`
+	JsonLd      map[string]any `json:"jsonLd"` // schema.org BlogPosting
+}
+
+// ArticleMeta 根据文章和作者生成 SEO 信息，没有摘要时从正文中截取描述
+func ArticleMeta(a models.ArticleModel, author models.UserModel, categoryName string) ArticleSeo {
+	site := global.Config.Site
+	siteName := site.SiteInfo.Title
+	canonical := ArticleURL(a.ID)
+	authorURL := UserURL(author.ID)
+
+	description := strings.TrimSpace(a.Abstract)
+	if description == "" {
+		description, _ = markdown.ExtractContent(a.Content, descriptionLength)
+	}
+	keywords := strings.Join(a.Tags, ",")
+	image := AbsURL(a.CoverURL)
+	if image == "" {
+		image = AbsURL(site.SiteInfo.LogoURL)
+	}
+	published := a.CreatedAt.Format(time.RFC3339)
+	modified := a.UpdatedAt.Format(time.RFC3339)
+
+	twitterCard := "summary"
+	if a.CoverURL != "" {
+		twitterCard = "summary_large_image"
+	}
+	meta := []MetaTag{
+		{Name: "description", Content: description},
+		{Name: "keywords", Content: keywords},
+		{Name: "author", Content: author.Nickname},
+		{Property: "og:type", Content: "article"},
+		{Property: "og:site_name", Content: siteName},
+		{Property: "og:title", Content: a.Title},
+		{Property: "og:description", Content: description},
+		{Property: "og:url", Content: canonical},
+		{Property: "og:image", Content: image},
+		{Property: "article:published_time", Content: published},
+		{Property: "article:modified_time", Content: modified},
+		{Property: "article:author", Content: authorURL},
+	}
+	if categoryName != "" {
+		meta = append(meta, MetaTag{Property: "article:section", Content: categoryName})
+	}
+	for _, tag := range a.Tags {
+		meta = append(meta, MetaTag{Property: "article:tag", Content: tag})
+	}
+	meta = append(meta,
+		MetaTag{Name: "twitter:card", Content: twitterCard},
+		MetaTag{Name: "twitter:title", Content: a.Title},
+		MetaTag{Name: "twitter:description", Content: description},
+		MetaTag{Name: "twitter:image", Content: image},
+	)
+	// 内容为空的标签不输出，如没有封面也没有 logo 时的图片
+	list := make([]MetaTag, 0, len(meta))
+	for _, m := range meta {
+		if m.Content == "" {
+			continue
+		}
+		list = append(list, m)
+	}
+
+	publisher := map[string]any{
+		"@type": "Organization",
+		"name":  siteName,
+		"url":   HomeURL(),
+	}
+	if logo := AbsURL(site.SiteInfo.LogoURL); logo != "" {
+		publisher["logo"] = map[string]any{
+			"@type": "ImageObject",
+			"url":   logo,
+		}
+	}
+	jsonLd := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         a.Title,
+		"description":      description,
+		"url":              canonical,
+		"datePublished":    published,
+		"dateModified":     modified,
+		"mainEntityOfPage": map[string]any{"@type": "WebPage", "@id": canonical},
+		"author": map[string]any{
+			"@type": "Person",
+			"name":  author.Nickname,
+			"url":   authorURL,
+		},
+		"publisher":            publisher,
+		"interactionStatistic": interactionStatistic(a),
+	}
+	if image != "" {
+		jsonLd["image"] = image
+	}
+	if keywords != "" {
+		jsonLd["keywords"] = keywords
+	}
+	if categoryName != "" {
+		jsonLd["articleSection"] = categoryName
+	}
+
+	return ArticleSeo{
+		Title:       a.Title + " - " + siteName,
+		Description: description,
+		Keywords:    keywords,
+		Canonical:   canonical,
+		Image:       image,
+		Meta:        list,
+		JsonLd:      jsonLd,
+	}
+}
+
+func interactionStatistic(a models.ArticleModel) []map[string]any {
+	counter := func(action string, count int) map[string]any {
+		return map[string]any{
+			"@type":                "InteractionCounter",
+			"interactionType":      "https://schema.org/" + action,
+			"userInteractionCount": count,
+		}
+	}
+	return []map[string]any{
+		counter("ReadAction", a.ReadCount),
+		counter("LikeAction", a.LikeCount),
+		counter("CommentAction", a.CommentCount),
+	}
+}
@@ -0,0 +1,38 @@
+// Path: ./service/seo_service/robots.go
+
+package seo_service
+
+import (
+	"blogX_server/conf/site"
+	"fmt"
+	"strings"
+)
+
+// Robots 根据站点配置生成 robots.txt，sitemap 为 sitemap.xml 的完整地址，为空则不写
+func Robots(r site.Robots, sitemap string) string {
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	if r.DisallowAll {
+		sb.WriteString("Disallow: /\n")
+		return sb.String()
+	}
+	if len(r.Disallow) == 0 {
+		sb.WriteString("Disallow:\n")
+	}
+	for _, p := range r.Disallow {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+		sb.WriteString(fmt.Sprintf("Disallow: %s\n", p))
+	}
+	if r.CrawlDelay > 0 {
+		sb.WriteString(fmt.Sprintf("Crawl-delay: %d\n", r.CrawlDelay))
+	}
+	if sitemap != "" {
+		sb.WriteString(fmt.Sprintf("\nSitemap: %s\n", sitemap))
+	}
+	return sb.String()
+}
@@ -0,0 +1,216 @@
+// Path: ./service/seo_service/sitemap.go
+
+package seo_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SitemapPageSize 单个 sitemap 的地址数，协议上限是 5 万，这里留足余量
+// 全站地址数超过这个值时 sitemap.xml 改为输出 sitemap 索引
+const SitemapPageSize = 10000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURL sitemap 中的一个地址
+type SitemapURL struct {
+	Loc        string
+	LastMod    time.Time
+	ChangeFreq string
+	Priority   float64
+}
+
+// SitemapGroup 同一类页面的地址，分页后各自成为 sitemap 文件
+type SitemapGroup struct {
+	Kind string // page article user category tag
+	URLs []SitemapURL
+}
+
+// SitemapPage 分页后的一个 sitemap 文件
+type SitemapPage struct {
+	Name string // 如 article-1.xml
+	URLs []SitemapURL
+}
+
+// LastMod 该页中最近的修改时间，用于索引文件
+func (p SitemapPage) LastMod() time.Time {
+	var t time.Time
+	for _, u := range p.URLs {
+		if u.LastMod.After(t) {
+			t = u.LastMod
+		}
+	}
+	return t
+}
+
+// CollectSitemap 收集所有需要被收录的页面：首页、已发布的文章，以及有已发布文章的用户主页、分类和标签
+// 只查一次文章表，用户、分类、标签都从文章中推导，最后修改时间取其中文章的最大值
+func CollectSitemap() (list []SitemapGroup, err error) {
+	var articleList []models.ArticleModel
+	err = global.DB.Select("id", "user_id", "category_id", "tags", "updated_at").
+		Where("status = ?", enum.ArticleStatusPublish).
+		Order("id asc").Find(&articleList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type category struct {
+		UserID uint
+		ID     uint
+	}
+	var (
+		home        time.Time
+		articleURLs = make([]SitemapURL, 0, len(articleList))
+		userMap     = map[uint]time.Time{}
+		categoryMap = map[category]time.Time{}
+		tagMap      = map[string]time.Time{}
+	)
+	for _, a := range articleList {
+		articleURLs = append(articleURLs, SitemapURL{
+			Loc:        ArticleURL(a.ID),
+			LastMod:    a.UpdatedAt,
+			ChangeFreq: "weekly",
+			Priority:   0.8,
+		})
+		home = latestTime(home, a.UpdatedAt)
+		userMap[a.UserID] = latestTime(userMap[a.UserID], a.UpdatedAt)
+		if a.CategoryID != nil {
+			k := category{UserID: a.UserID, ID: *a.CategoryID}
+			categoryMap[k] = latestTime(categoryMap[k], a.UpdatedAt)
+		}
+		for _, tag := range a.Tags {
+			tagMap[tag] = latestTime(tagMap[tag], a.UpdatedAt)
+		}
+	}
+
+	userURLs := make([]SitemapURL, 0, len(userMap))
+	for uid, t := range userMap {
+		userURLs = append(userURLs, SitemapURL{Loc: UserURL(uid), LastMod: t, ChangeFreq: "daily", Priority: 0.6})
+	}
+	categoryURLs := make([]SitemapURL, 0, len(categoryMap))
+	for k, t := range categoryMap {
+		categoryURLs = append(categoryURLs, SitemapURL{Loc: CategoryURL(k.UserID, k.ID), LastMod: t, ChangeFreq: "weekly", Priority: 0.5})
+	}
+	tagURLs := make([]SitemapURL, 0, len(tagMap))
+	for tag, t := range tagMap {
+		tagURLs = append(tagURLs, SitemapURL{Loc: TagURL(tag), LastMod: t, ChangeFreq: "weekly", Priority: 0.4})
+	}
+	// map 遍历无序，排序后分页才稳定
+	for _, l := range [][]SitemapURL{userURLs, categoryURLs, tagURLs} {
+		sort.Slice(l, func(i, j int) bool {
+			return l[i].Loc < l[j].Loc
+		})
+	}
+
+	list = []SitemapGroup{
+		{Kind: "page", URLs: []SitemapURL{{Loc: HomeURL(), LastMod: home, ChangeFreq: "daily", Priority: 1}}},
+		{Kind: "article", URLs: articleURLs},
+		{Kind: "user", URLs: userURLs},
+		{Kind: "category", URLs: categoryURLs},
+		{Kind: "tag", URLs: tagURLs},
+	}
+	return list, nil
+}
+
+// SplitSitemap 按 size 分页，每类页面单独编号，如 article-1.xml article-2.xml
+func SplitSitemap(list []SitemapGroup, size int) (pages []SitemapPage) {
+	for _, g := range list {
+		for i := 0; i < len(g.URLs); i += size {
+			end := i + size
+			if end > len(g.URLs) {
+				end = len(g.URLs)
+			}
+			pages = append(pages, SitemapPage{
+				Name: fmt.Sprintf("%s-%d.xml", g.Kind, i/size+1),
+				URLs: g.URLs[i:end],
+			})
+		}
+	}
+	return pages
+}
+
+type xmlURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type xmlSitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []xmlSitemap `xml:"sitemap"`
+}
+
+type xmlSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// RenderURLSet 生成 urlset 格式的 sitemap
+func RenderURLSet(urls []SitemapURL) ([]byte, error) {
+	set := xmlURLSet{XMLNS: sitemapNS, URLs: make([]xmlURL, 0, len(urls))}
+	for _, u := range urls {
+		item := xmlURL{
+			Loc:        u.Loc,
+			LastMod:    sitemapTime(u.LastMod),
+			ChangeFreq: u.ChangeFreq,
+		}
+		if u.Priority > 0 {
+			item.Priority = fmt.Sprintf("%.1f", u.Priority)
+		}
+		set.URLs = append(set.URLs, item)
+	}
+	return renderXML(set)
+}
+
+// RenderSitemapIndex 生成 sitemap 索引，loc 为各个 sitemap 文件的地址前缀，如 https://blog.example.com/sitemap/
+func RenderSitemapIndex(loc string, pages []SitemapPage) ([]byte, error) {
+	index := xmlSitemapIndex{XMLNS: sitemapNS, Sitemaps: make([]xmlSitemap, 0, len(pages))}
+	for _, p := range pages {
+		index.Sitemaps = append(index.Sitemaps, xmlSitemap{
+			Loc:     loc + p.Name,
+			LastMod: sitemapTime(p.LastMod()),
+		})
+	}
+	return renderXML(index)
+}
+
+func renderXML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// sitemapTime 协议要求 W3C Datetime 格式
+func sitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,101 @@
+package seo_service
+
+import (
+	"blogX_server/conf/site"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testGroups(articles int) []SitemapGroup {
+	t := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	urls := make([]SitemapURL, 0, articles)
+	for i := 1; i <= articles; i++ {
+		urls = append(urls, SitemapURL{
+			Loc:     fmt.Sprintf("https://example.com/article/%d", i),
+			LastMod: t.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return []SitemapGroup{
+		{Kind: "page", URLs: []SitemapURL{{Loc: "https://example.com/", Priority: 1}}},
+		{Kind: "article", URLs: urls},
+		{Kind: "tag"},
+	}
+}
+
+func TestSplitSitemap(t *testing.T) {
+	pages := SplitSitemap(testGroups(5), 2)
+	var names []string
+	for _, p := range pages {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "page-1.xml,article-1.xml,article-2.xml,article-3.xml" {
+		t.Fatalf("pages = %v", names)
+	}
+	if len(pages[3].URLs) != 1 {
+		t.Fatalf("last page = %d", len(pages[3].URLs))
+	}
+	if !pages[2].LastMod().Equal(time.Date(2025, 1, 2, 13, 0, 0, 0, time.UTC)) {
+		t.Fatalf("lastmod = %v", pages[2].LastMod())
+	}
+}
+
+func TestRenderURLSet(t *testing.T) {
+	byteData, err := RenderURLSet(testGroups(1)[0].URLs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set struct {
+		XMLName xml.Name
+		URLs    []struct {
+			Loc      string `xml:"loc"`
+			LastMod  string `xml:"lastmod"`
+			Priority string `xml:"priority"`
+		} `xml:"url"`
+	}
+	if err = xml.Unmarshal(byteData, &set); err != nil {
+		t.Fatalf("sitemap is not valid xml: %v", err)
+	}
+	if set.XMLName.Space != sitemapNS || set.XMLName.Local != "urlset" {
+		t.Fatalf("root = %v", set.XMLName)
+	}
+	if len(set.URLs) != 1 || set.URLs[0].Priority != "1.0" || set.URLs[0].LastMod != "" {
+		t.Fatalf("unexpected urls %+v", set.URLs)
+	}
+}
+
+func TestRenderSitemapIndex(t *testing.T) {
+	byteData, err := RenderSitemapIndex("https://example.com/sitemap/", SplitSitemap(testGroups(3), 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index struct {
+		Sitemaps []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"sitemap"`
+	}
+	if err = xml.Unmarshal(byteData, &index); err != nil {
+		t.Fatalf("index is not valid xml: %v", err)
+	}
+	if len(index.Sitemaps) != 3 || index.Sitemaps[2].Loc != "https://example.com/sitemap/article-2.xml" {
+		t.Fatalf("unexpected sitemaps %+v", index.Sitemaps)
+	}
+	if index.Sitemaps[1].LastMod != "2025-01-02T11:00:00Z" {
+		t.Fatalf("lastmod = %s", index.Sitemaps[1].LastMod)
+	}
+}
+
+func TestRobots(t *testing.T) {
+	s := Robots(site.Robots{Disallow: []string{"admin", " /api ", ""}, CrawlDelay: 5}, "https://example.com/sitemap.xml")
+	want := "User-agent: *\nDisallow: /admin\nDisallow: /api\nCrawl-delay: 5\n\nSitemap: https://example.com/sitemap.xml\n"
+	if s != want {
+		t.Fatalf("robots = %q", s)
+	}
+	s = Robots(site.Robots{DisallowAll: true}, "https://example.com/sitemap.xml")
+	if s != "User-agent: *\nDisallow: /\n" {
+		t.Fatalf("robots = %q", s)
+	}
+}
@@ -0,0 +1,103 @@
+// Path: ./service/series_service/enter.go
+
+package series_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_cache"
+	"fmt"
+)
+
+type SeriesArticle struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+// SeriesNav 文章详情中的系列导航信息
+type SeriesNav struct {
+	SeriesID uint           `json:"seriesID"`
+	Title    string         `json:"title"`
+	Index    int            `json:"index"` // 当前文章是系列中的第几篇（从 1 开始），未发布的文章为 0
+	Total    int            `json:"total"` // 系列中已发布的文章数
+	Prev     *SeriesArticle `json:"prev"`
+	Next     *SeriesArticle `json:"next"`
+}
+
+// GetSeriesArticleList 按顺序取出系列中的文章，onlyPublished 为 true 时只取已发布的
+func GetSeriesArticleList(seriesID uint, onlyPublished bool) (list []models.ArticleModel, err error) {
+	query := global.DB.Model(&models.ArticleModel{}).
+		Joins("JOIN series_article_models sa ON sa.article_id = article_models.id").
+		Where("sa.series_id = ?", seriesID)
+	if onlyPublished {
+		query = query.Where("article_models.status = ?", enum.ArticleStatusPublish)
+	}
+	err = query.Order("sa.`rank` ASC").Order("sa.created_at ASC").Find(&list).Error
+	return
+}
+
+// GetArticleSeriesNav 查询文章所在的系列及上一篇、下一篇（只考虑已发布的文章）
+// 文章不属于任何系列时返回 nil
+func GetArticleSeriesNav(aid uint) *SeriesNav {
+	var sa models.SeriesArticleModel
+	err := global.DB.Preload("SeriesModel").Take(&sa, "article_id = ?", aid).Error
+	if err != nil {
+		return nil
+	}
+
+	list, err := GetSeriesArticleList(sa.SeriesID, true)
+	if err != nil {
+		return nil
+	}
+
+	nav := &SeriesNav{
+		SeriesID: sa.SeriesID,
+		Title:    sa.SeriesModel.Title,
+		Total:    len(list),
+	}
+	for i, a := range list {
+		if a.ID != aid {
+			continue
+		}
+		nav.Index = i + 1
+		if i > 0 {
+			nav.Prev = &SeriesArticle{ID: list[i-1].ID, Title: list[i-1].Title}
+		}
+		if i < len(list)-1 {
+			nav.Next = &SeriesArticle{ID: list[i+1].ID, Title: list[i+1].Title}
+		}
+		break
+	}
+	return nav
+}
+
+// SyncSeries 系列的文章有变动（加入、移出、发布、撤回、删除）时调用
+// 重新统计已发布的文章数，并清掉系列中文章的详情缓存
+func SyncSeries(seriesID uint) {
+	var count int64
+	global.DB.Model(&models.SeriesArticleModel{}).
+		Joins("JOIN article_models a ON a.id = series_article_models.article_id").
+		Where("series_article_models.series_id = ? and a.status = ? and a.deleted_at is null", seriesID, enum.ArticleStatusPublish).
+		Count(&count)
+	global.DB.Model(&models.SeriesModel{}).Where("id = ?", seriesID).Update("article_count", count)
+	CloseSeriesCache(seriesID)
+}
+
+// SyncArticleSeries 文章状态或标题变化后，同步文章所在的系列，不在系列中就什么也不做
+func SyncArticleSeries(aid uint) {
+	var sa models.SeriesArticleModel
+	if err := global.DB.Take(&sa, "article_id = ?", aid).Error; err != nil {
+		return
+	}
+	SyncSeries(sa.SeriesID)
+}
+
+// CloseSeriesCache 系列有变动时，系列中所有文章的详情缓存（上一篇、下一篇）都需要失效
+func CloseSeriesCache(seriesID uint) {
+	var idList []uint
+	global.DB.Model(&models.SeriesArticleModel{}).Where("series_id = ?", seriesID).Pluck("article_id", &idList)
+	for _, id := range idList {
+		redis_cache.CacheCloseCertain(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, id))
+	}
+}
@@ -0,0 +1,115 @@
+// Path: ./service/series_service/enter_test.go
+
+package series_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/utils/testutil"
+	"fmt"
+	"testing"
+)
+
+// setupSeries 一个系列，按 rank 顺序是 1 已发布、2 草稿、3 已发布、4 已发布
+func setupSeries(t *testing.T) models.SeriesModel {
+	testutil.Setup(t,
+		&models.UserModel{},
+		&models.ArticleModel{},
+		&models.TextModel{},
+		&models.SeriesModel{},
+		&models.SeriesArticleModel{},
+	)
+	global.DB.Create(&models.UserModel{Username: "author"})
+	s := models.SeriesModel{UserID: 1, Title: "series"}
+	global.DB.Create(&s)
+
+	status := []enum.ArticleStatus{enum.ArticleStatusPublish, enum.ArticleStatusDraft, enum.ArticleStatusPublish, enum.ArticleStatusPublish}
+	// 加入顺序和 rank 不一致，确认按 rank 排序
+	rank := []int{1, 2, 4, 3}
+	for i, st := range status {
+		a := models.ArticleModel{Title: fmt.Sprintf("a%d", i+1), Content: "x", UserID: 1, Status: st}
+		global.DB.Create(&a)
+		global.DB.Create(&models.SeriesArticleModel{SeriesID: s.ID, ArticleID: a.ID, Rank: rank[i]})
+	}
+	return s
+}
+
+func articleIDs(list []models.ArticleModel) (ids []uint) {
+	for _, a := range list {
+		ids = append(ids, a.ID)
+	}
+	return
+}
+
+func TestGetSeriesArticleList(t *testing.T) {
+	s := setupSeries(t)
+
+	all, err := GetSeriesArticleList(s.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(articleIDs(all)); got != "[1 2 4 3]" {
+		t.Errorf("全部文章 = %s", got)
+	}
+	published, _ := GetSeriesArticleList(s.ID, true)
+	if got := fmt.Sprint(articleIDs(published)); got != "[1 4 3]" {
+		t.Errorf("已发布文章 = %s", got)
+	}
+}
+
+func TestGetArticleSeriesNav(t *testing.T) {
+	setupSeries(t)
+
+	// 未发布的文章不占位置
+	nav := GetArticleSeriesNav(4)
+	if nav == nil || nav.Index != 2 || nav.Total != 3 {
+		t.Fatalf("nav = %+v", nav)
+	}
+	if nav.Prev == nil || nav.Prev.ID != 1 || nav.Next == nil || nav.Next.ID != 3 {
+		t.Errorf("prev = %+v, next = %+v", nav.Prev, nav.Next)
+	}
+
+	nav = GetArticleSeriesNav(2)
+	if nav == nil || nav.Index != 0 || nav.Prev != nil || nav.Next != nil {
+		t.Errorf("草稿的 nav = %+v", nav)
+	}
+
+	if GetArticleSeriesNav(99) != nil {
+		t.Error("不在系列中的文章应该返回 nil")
+	}
+}
+
+func TestSyncSeries(t *testing.T) {
+	s := setupSeries(t)
+	for i := 1; i <= 4; i++ {
+		redis_cache.CacheOpen(fmt.Sprintf("%s%d", redis_cache.CacheArticleDetailPrefix, i), "{}", 0)
+	}
+
+	SyncSeries(s.ID)
+	global.DB.Take(&s, s.ID)
+	if s.ArticleCount != 3 {
+		t.Errorf("ArticleCount = %d, want 3", s.ArticleCount)
+	}
+	keys, _ := global.Redis.Keys(string(redis_cache.CacheArticleDetailPrefix) + "*").Result()
+	if len(keys) != 0 {
+		t.Errorf("系列中的文章缓存没有清除: %v", keys)
+	}
+
+	// 发布草稿、删除一篇，通过文章同步
+	global.DB.Model(&models.ArticleModel{}).Where("id = ?", 2).Update("status", enum.ArticleStatusPublish)
+	global.DB.Delete(&models.ArticleModel{}, 3)
+	SyncArticleSeries(2)
+	global.DB.Take(&s, s.ID)
+	if s.ArticleCount != 3 {
+		t.Errorf("发布一篇删除一篇后 ArticleCount = %d, want 3", s.ArticleCount)
+	}
+
+	global.DB.Model(&models.ArticleModel{}).Where("id = ?", 1).Update("status", enum.ArticleStatusReview)
+	SyncArticleSeries(1)
+	global.DB.Take(&s, s.ID)
+	if s.ArticleCount != 2 {
+		t.Errorf("撤回审核后 ArticleCount = %d, want 2", s.ArticleCount)
+	}
+}
@@ -0,0 +1,146 @@
+// Path: ./service/static_service/enter.go
+
+// Package static_service 把已发布的文章、分类、标签、用户主页渲染为静态网站，用于归档和维护期间的临时托管
+// 页面地址和前端保持一致（/article/1 对应 article/1/index.html），分类和标签在前端是查询参数，这里改为 /category/1 /tag/xx
+package static_service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// manifestName 上次导出的记录，增量导出时据此判断哪些文章需要重新生成
+const manifestName = ".blogx_static.json"
+
+type manifest struct {
+	ExportedAt time.Time     `json:"exportedAt"`
+	Articles   map[uint]bool `json:"articles"` // 已生成的文章
+	Files      []string      `json:"files"`    // 已生成的列表页、订阅源等，下次没有再生成的会被删除
+}
+
+// Result 导出的统计
+type Result struct {
+	Articles  int // 生成的文章页
+	Unchanged int // 增量导出时没有变化而跳过的文章页
+	Removed   int // 删除的文章页（已删除或不再公开的文章）
+	Pages     int // 列表页、订阅源、sitemap 等
+	Images    int // 复制的图片
+}
+
+// Export 导出到 dir，incremental 为 true 时只重新生成上次导出之后修改过的文章
+// 首页、列表页、订阅源、sitemap 依赖所有文章，每次都重新生成
+func Export(dir string, incremental bool) (r Result, err error) {
+	if dir == "" {
+		return r, errors.New("请指定导出目录")
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	var last manifest
+	if incremental {
+		if byteData, err := os.ReadFile(filepath.Join(dir, manifestName)); err == nil {
+			_ = json.Unmarshal(byteData, &last)
+		}
+	}
+	startAt := time.Now()
+
+	s, err := loadSite()
+	if err != nil {
+		return
+	}
+	w := &writer{dir: dir}
+
+	// 文章
+	next := manifest{ExportedAt: startAt, Articles: map[uint]bool{}}
+	for _, a := range s.articles {
+		next.Articles[a.ID] = true
+		file := articlePath(a.ID)
+		if incremental && last.Articles[a.ID] && !a.UpdatedAt.After(last.ExportedAt) && w.exists(file) {
+			r.Unchanged++
+			continue
+		}
+		if err = w.write(file, s.renderArticle(a)); err != nil {
+			return
+		}
+		r.Articles++
+	}
+	for id := range last.Articles {
+		if !next.Articles[id] {
+			_ = os.RemoveAll(filepath.Join(dir, filepath.Dir(filepath.FromSlash(articlePath(id)))))
+			r.Removed++
+		}
+	}
+
+	// 列表页、订阅源、sitemap
+	pages, err := s.renderPages()
+	if err != nil {
+		return
+	}
+	for _, p := range pages {
+		if err = w.write(p.path, p.data); err != nil {
+			return
+		}
+		next.Files = append(next.Files, p.path)
+	}
+	r.Pages = len(pages)
+	current := map[string]bool{}
+	for _, f := range next.Files {
+		current[f] = true
+	}
+	for _, f := range last.Files {
+		if !current[f] {
+			w.remove(f)
+		}
+	}
+
+	// 图片
+	r.Images, err = copyImages(dir, s.imageRefs())
+	if err != nil {
+		return
+	}
+
+	sort.Strings(next.Files)
+	byteData, err := json.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return
+	}
+	err = os.WriteFile(filepath.Join(dir, manifestName), byteData, 0644)
+	return
+}
+
+// writer 写文件时自动创建目录，路径都是相对于导出目录的 / 分隔的路径
+type writer struct {
+	dir string
+}
+
+func (w *writer) full(p string) string {
+	return filepath.Join(w.dir, filepath.FromSlash(p))
+}
+
+func (w *writer) exists(p string) bool {
+	_, err := os.Stat(w.full(p))
+	return err == nil
+}
+
+func (w *writer) write(p string, data []byte) error {
+	full := w.full(p)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(full, data, 0644)
+}
+
+// remove 删除文件，目录 index.html 所在的目录空了也一起删除
+func (w *writer) remove(p string) {
+	full := w.full(p)
+	_ = os.Remove(full)
+	if strings.HasSuffix(p, "/index.html") {
+		_ = os.Remove(filepath.Dir(full))
+	}
+}
@@ -0,0 +1,123 @@
+// Path: ./service/static_service/enter_test.go
+
+package static_service
+
+import (
+	"blogX_server/conf"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUploadRefs(t *testing.T) {
+	text := `![a](/uploads/images/a.png) <img src="uploads/b.jpg?x=1"> ![c](/uploads/../settings.yaml) https://cdn.com/x.png`
+	got := strings.Join(uploadRefs(text), ",")
+	if got != "uploads/images/a.png,uploads/b.jpg" {
+		t.Errorf("uploadRefs() = %s", got)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		url, path string
+	}{
+		{articleURL(1), "article/1/index.html"},
+		{pageURL(1), "index.html"},
+		{pageURL(3), "page/3/index.html"},
+		{tagURL("Go/Web"), "tag/Go-Web/index.html"},
+		{tagURL("中文 标签"), "tag/中文 标签/index.html"},
+		{tagURL("100%"), "tag/100%/index.html"},
+	}
+	for _, tt := range tests {
+		if got := indexPath(tt.url); got != tt.path {
+			t.Errorf("indexPath(%q) = %q, want %q", tt.url, got, tt.path)
+		}
+	}
+	if u := tagURL("中文 标签"); u != "/tag/%E4%B8%AD%E6%96%87%20%E6%A0%87%E7%AD%BE/" {
+		t.Errorf("tagURL() = %s", u)
+	}
+}
+
+func testSite() *site {
+	global.Config = &conf.Config{}
+	global.Config.Site.SiteInfo.Title = "BlogX"
+	u := models.UserModel{Model: models.Model{ID: 2}, Nickname: "作者"}
+	cat := &models.CategoryModel{Model: models.Model{ID: 3}, Name: "Go", UserID: 2}
+	t := time.Date(2025, 1, 2, 9, 0, 0, 0, time.Local)
+	var articles []models.ArticleModel
+	for i := 25; i >= 1; i-- {
+		articles = append(articles, models.ArticleModel{
+			Model:         models.Model{ID: uint(i), CreatedAt: t, UpdatedAt: t},
+			Title:         "文章<" + string(rune('A'+i%26)) + ">",
+			Content:       "# 标题\n\n正文 ![img](/uploads/a.png)",
+			Tags:          ctype.List{"Go"},
+			UserID:        2,
+			CategoryID:    &cat.ID,
+			Status:        enum.ArticleStatusPublish,
+			UserModel:     u,
+			CategoryModel: cat,
+		})
+	}
+	return &site{
+		baseURL:    "https://blog.example.com",
+		articles:   articles,
+		users:      map[uint]models.UserModel{2: u},
+		categories: map[uint]models.CategoryModel{3: *cat},
+	}
+}
+
+func TestRenderArticle(t *testing.T) {
+	s := testSite()
+	html := string(s.renderArticle(s.articles[0]))
+	for _, want := range []string{
+		"<title>文章&lt;Z&gt; - BlogX</title>",
+		`<h1 id="标题">标题</h1>`,
+		`<a href="/user/2/">作者</a>`,
+		`<a href="/category/3/">Go</a>`,
+		`<a class="tag" href="/tag/Go/">#Go</a>`,
+		`<script type="application/ld+json">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("文章页中没有 %s\n%s", want, html)
+		}
+	}
+}
+
+func TestRenderPages(t *testing.T) {
+	s := testSite()
+	pages, err := s.renderPages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, p := range pages {
+		files[p.path] = string(p.data)
+	}
+	for _, name := range []string{
+		"index.html", "page/2/index.html", "user/2/index.html", "category/3/index.html", "tag/Go/index.html",
+		"feed.xml", "atom.xml", "feed.json", "sitemap.xml", "sitemap/article-1.xml", "robots.txt", "static.css",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("没有生成 %s", name)
+		}
+	}
+	if _, ok := files["page/3/index.html"]; ok {
+		t.Error("25 篇文章不应该有第 3 页")
+	}
+	if !strings.Contains(files["index.html"], `<a href="/page/2/">下一页</a>`) {
+		t.Error("首页没有下一页")
+	}
+	if !strings.Contains(files["feed.xml"], "https://blog.example.com/article/25/") {
+		t.Error("订阅源中的文章地址不对")
+	}
+	if !strings.Contains(files["sitemap.xml"], "https://blog.example.com/sitemap/article-1.xml") {
+		t.Error("sitemap 索引不对")
+	}
+	if refs := s.imageRefs(); len(refs) != 25 || refs[0] != "uploads/a.png" {
+		t.Errorf("imageRefs() = %v", refs)
+	}
+}
@@ -0,0 +1,72 @@
+// Path: ./service/static_service/images.go
+
+package static_service
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// uploadRe 站内上传的图片，地址形如 /uploads/images/xx.png
+var uploadRe = regexp.MustCompile(`/?uploads/[^\s"'()<>?#\\]+`)
+
+// uploadRefs 找出文本中引用的本地图片，返回规范化后的相对路径，只允许 uploads 目录下的文件
+func uploadRefs(text string) (list []string) {
+	for _, m := range uploadRe.FindAllString(text, -1) {
+		p := strings.TrimPrefix(path.Clean("/"+m), "/")
+		if strings.HasPrefix(p, "uploads/") {
+			list = append(list, p)
+		}
+	}
+	return
+}
+
+// copyImages 把图片复制到导出目录的同一路径下，已存在且大小相同的跳过，本地不存在的（如已删除）忽略
+func copyImages(dir string, list []string) (count int, err error) {
+	seen := map[string]bool{}
+	for _, p := range list {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		src := filepath.FromSlash(p)
+		info, err := os.Stat(src)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		dst := filepath.Join(dir, src)
+		if dstInfo, err := os.Stat(dst); err == nil && dstInfo.Size() == info.Size() {
+			continue
+		}
+		if err = copyFile(src, dst); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
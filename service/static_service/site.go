@@ -0,0 +1,312 @@
+// Path: ./service/static_service/site.go
+
+package static_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/feed_service"
+	"blogX_server/service/seo_service"
+	"blogX_server/utils/markdown"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pageSize 首页每页的文章数
+const pageSize = 20
+
+// feedLimit 订阅源中的文章数，和订阅接口一致
+const feedLimit = 20
+
+// 页面地址，都以 / 结尾，对应目录下的 index.html
+
+func articleURL(id uint) string  { return fmt.Sprintf("/article/%d/", id) }
+func userURL(id uint) string     { return fmt.Sprintf("/user/%d/", id) }
+func categoryURL(id uint) string { return fmt.Sprintf("/category/%d/", id) }
+func tagURL(tag string) string   { return "/tag/" + url.PathEscape(tagDir(tag)) + "/" }
+func articlePath(id uint) string { return indexPath(articleURL(id)) }
+
+// indexPath 页面地址对应的文件，tag 的地址是转义过的，文件名用原始的
+func indexPath(u string) string {
+	if p, err := url.PathUnescape(u); err == nil {
+		u = p
+	}
+	return strings.TrimPrefix(u, "/") + "index.html"
+}
+
+// pageURL 首页的第 n 页
+func pageURL(n int) string {
+	if n <= 1 {
+		return "/"
+	}
+	return fmt.Sprintf("/page/%d/", n)
+}
+
+// tagDir 标签作为目录名，去掉文件系统不允许的字符
+func tagDir(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '-'
+		}
+		if r < ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(tag))
+}
+
+type site struct {
+	baseURL    string
+	articles   []models.ArticleModel // 按发布时间倒序
+	users      map[uint]models.UserModel
+	categories map[uint]models.CategoryModel
+}
+
+// loadSite 查询所有已发布的文章，用户和分类从文章中推导
+func loadSite() (*site, error) {
+	s := &site{
+		baseURL:    global.Config.Site.BaseURL(),
+		users:      map[uint]models.UserModel{},
+		categories: map[uint]models.CategoryModel{},
+	}
+	err := global.DB.Preload("UserModel").Preload("CategoryModel").
+		Where("status = ?", enum.ArticleStatusPublish).
+		Order("created_at desc").Find(&s.articles).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range s.articles {
+		s.users[a.UserID] = a.UserModel
+		if a.CategoryModel != nil {
+			s.categories[a.CategoryModel.ID] = *a.CategoryModel
+		}
+	}
+	return s, nil
+}
+
+// abs 订阅源和 sitemap 需要完整地址，没有配置站点地址时只能用相对地址
+func (s *site) abs(u string) string {
+	return s.baseURL + u
+}
+
+type page struct {
+	path string
+	data []byte
+}
+
+func (s *site) renderArticle(a models.ArticleModel) []byte {
+	var categoryName string
+	if a.CategoryModel != nil {
+		categoryName = a.CategoryModel.Name
+	}
+	seo := seo_service.ArticleMeta(a, a.UserModel, categoryName)
+	data := articleData{
+		layoutData: s.layout(a.Title),
+		Article:    s.item(a),
+		Content:    markdownHTML(a.Content),
+	}
+	data.Meta = seo.Meta
+	data.Canonical = seo.Canonical
+	data.JsonLd = seo.JsonLd
+	if a.CategoryModel != nil {
+		data.Category = &link{Title: a.CategoryModel.Name, URL: categoryURL(a.CategoryModel.ID)}
+	}
+	return render(articleTmpl, data)
+}
+
+// renderPages 首页（分页）、用户主页、分类页、标签页、订阅源、sitemap、robots.txt 和样式
+func (s *site) renderPages() (pages []page, err error) {
+	// 首页
+	for i := 0; i == 0 || i < len(s.articles); i += pageSize {
+		n := i/pageSize + 1
+		data := s.list(s.layout(""), s.articles[i:min(i+pageSize, len(s.articles))])
+		data.Heading = global.Config.Site.SiteInfo.Slogan
+		if n > 1 {
+			data.Title = fmt.Sprintf("第 %d 页 - %s", n, data.Title)
+			data.Prev = pageURL(n - 1)
+		}
+		if i+pageSize < len(s.articles) {
+			data.Next = pageURL(n + 1)
+		}
+		pages = append(pages, page{path: indexPath(pageURL(n)), data: render(listTmpl, data)})
+	}
+
+	// 用户、分类、标签
+	byUser := map[uint][]models.ArticleModel{}
+	byCategory := map[uint][]models.ArticleModel{}
+	byTag := map[string][]models.ArticleModel{}
+	for _, a := range s.articles {
+		byUser[a.UserID] = append(byUser[a.UserID], a)
+		if a.CategoryID != nil {
+			byCategory[*a.CategoryID] = append(byCategory[*a.CategoryID], a)
+		}
+		for _, tag := range a.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" && tagDir(tag) != "" {
+				byTag[tag] = append(byTag[tag], a)
+			}
+		}
+	}
+	for uid, list := range byUser {
+		u := s.users[uid]
+		data := s.list(s.layout(u.Nickname), list)
+		data.Heading = u.Nickname
+		data.Description = u.Bio
+		data.Avatar = u.AvatarURL
+		pages = append(pages, page{path: indexPath(userURL(uid)), data: render(listTmpl, data)})
+	}
+	for cid, list := range byCategory {
+		cat := s.categories[cid]
+		u := s.users[cat.UserID]
+		data := s.list(s.layout(cat.Name), list)
+		data.Heading = "分类：" + cat.Name
+		data.Description = u.Nickname
+		pages = append(pages, page{path: indexPath(categoryURL(cid)), data: render(listTmpl, data)})
+	}
+	for tag, list := range byTag {
+		data := s.list(s.layout("#"+tag), list)
+		data.Heading = "#" + tag
+		pages = append(pages, page{path: indexPath(tagURL(tag)), data: render(listTmpl, data)})
+	}
+
+	// 订阅源
+	f := s.feed()
+	for _, item := range []struct {
+		path   string
+		render func(*feed_service.Feed) ([]byte, error)
+	}{
+		{"feed.xml", (*feed_service.Feed).RSS},
+		{"atom.xml", (*feed_service.Feed).Atom},
+		{"feed.json", (*feed_service.Feed).JSON},
+	} {
+		byteData, err := item.render(f)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page{path: item.path, data: byteData})
+	}
+
+	// sitemap，格式和在线站点一致：sitemap.xml 为索引，各类页面分别分页
+	sitemapPages := seo_service.SplitSitemap(s.sitemap(byUser, byCategory, byTag), seo_service.SitemapPageSize)
+	index, err := seo_service.RenderSitemapIndex(s.abs("/sitemap/"), sitemapPages)
+	if err != nil {
+		return nil, err
+	}
+	pages = append(pages, page{path: "sitemap.xml", data: index})
+	for _, p := range sitemapPages {
+		byteData, err := seo_service.RenderURLSet(p.URLs)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page{path: "sitemap/" + p.Name, data: byteData})
+	}
+	var sitemapURL string
+	if s.baseURL != "" {
+		sitemapURL = s.abs("/sitemap.xml")
+	}
+	pages = append(pages,
+		page{path: "robots.txt", data: []byte(seo_service.Robots(global.Config.Site.Seo.Robots, sitemapURL))},
+		page{path: "static.css", data: []byte(styleCSS)},
+	)
+
+	// map 遍历无序，排序后记录的文件列表才稳定
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].path < pages[j].path
+	})
+	return pages, nil
+}
+
+func (s *site) feed() *feed_service.Feed {
+	info := global.Config.Site
+	f := &feed_service.Feed{
+		Title:       info.SiteInfo.Title,
+		Link:        s.abs("/"),
+		FeedURL:     s.abs("/feed.xml"),
+		Description: info.Seo.Description,
+		Language:    "zh-CN",
+		Updated:     time.Unix(0, 0),
+	}
+	if f.Description == "" {
+		f.Description = info.SiteInfo.Slogan
+	}
+	for _, a := range s.articles[:min(feedLimit, len(s.articles))] {
+		link := s.abs(articleURL(a.ID))
+		f.Items = append(f.Items, feed_service.Item{
+			ID:          link,
+			Title:       a.Title,
+			Link:        link,
+			Summary:     a.Abstract,
+			ContentHTML: markdown.MdToHTML(a.Content),
+			Author:      a.UserModel.Nickname,
+			Image:       seo_service.AbsURL(a.CoverURL),
+			Tags:        a.Tags,
+			Published:   a.CreatedAt,
+			Updated:     a.UpdatedAt,
+		})
+	}
+	return f
+}
+
+func (s *site) sitemap(byUser, byCategory map[uint][]models.ArticleModel, byTag map[string][]models.ArticleModel) []seo_service.SitemapGroup {
+	var home time.Time
+	articleURLs := make([]seo_service.SitemapURL, 0, len(s.articles))
+	for _, a := range s.articles {
+		articleURLs = append(articleURLs, seo_service.SitemapURL{Loc: s.abs(articleURL(a.ID)), LastMod: a.UpdatedAt, ChangeFreq: "weekly", Priority: 0.8})
+		home = latest(home, a.UpdatedAt)
+	}
+	group := func(m map[string][]models.ArticleModel, freq string, priority float64) (list []seo_service.SitemapURL) {
+		for u, articles := range m {
+			var t time.Time
+			for _, a := range articles {
+				t = latest(t, a.UpdatedAt)
+			}
+			list = append(list, seo_service.SitemapURL{Loc: s.abs(u), LastMod: t, ChangeFreq: freq, Priority: priority})
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Loc < list[j].Loc
+		})
+		return
+	}
+	users, categories, tags := map[string][]models.ArticleModel{}, map[string][]models.ArticleModel{}, map[string][]models.ArticleModel{}
+	for id, l := range byUser {
+		users[userURL(id)] = l
+	}
+	for id, l := range byCategory {
+		categories[categoryURL(id)] = l
+	}
+	for tag, l := range byTag {
+		tags[tagURL(tag)] = l
+	}
+	return []seo_service.SitemapGroup{
+		{Kind: "page", URLs: []seo_service.SitemapURL{{Loc: s.abs("/"), LastMod: home, ChangeFreq: "daily", Priority: 1}}},
+		{Kind: "article", URLs: articleURLs},
+		{Kind: "user", URLs: group(users, "daily", 0.6)},
+		{Kind: "category", URLs: group(categories, "weekly", 0.5)},
+		{Kind: "tag", URLs: group(tags, "weekly", 0.4)},
+	}
+}
+
+// imageRefs 所有页面引用的本地图片：正文、封面、头像、站点 logo
+func (s *site) imageRefs() []string {
+	var list []string
+	list = append(list, uploadRefs(global.Config.Site.SiteInfo.LogoURL)...)
+	for _, u := range s.users {
+		list = append(list, uploadRefs(u.AvatarURL)...)
+	}
+	for _, a := range s.articles {
+		list = append(list, uploadRefs(a.CoverURL)...)
+		list = append(list, uploadRefs(a.Content)...)
+	}
+	return list
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
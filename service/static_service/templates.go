@@ -0,0 +1,226 @@
+// Path: ./service/static_service/templates.go
+
+package static_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/service/seo_service"
+	"blogX_server/utils/markdown"
+	"bytes"
+	"github.com/sirupsen/logrus"
+	"html/template"
+	"time"
+)
+
+type link struct {
+	Title string
+	URL   string
+}
+
+// layoutData 所有页面共用的头部和页脚
+type layoutData struct {
+	Title     string
+	SiteTitle string
+	Slogan    string
+	Logo      string
+	ICP       string
+	Meta      []seo_service.MetaTag
+	Canonical string
+	JsonLd    map[string]any
+	Year      int
+}
+
+// item 列表中的一篇文章
+type item struct {
+	ID       uint
+	Title    string
+	URL      string
+	Abstract string
+	Cover    string
+	Date     time.Time
+	Author   link
+	Tags     []link
+}
+
+type listData struct {
+	layoutData
+	Heading     string
+	Description string
+	Avatar      string
+	Items       []item
+	Prev, Next  string
+}
+
+type articleData struct {
+	layoutData
+	Article  item
+	Category *link
+	Content  template.HTML
+}
+
+func (s *site) layout(title string) layoutData {
+	info := global.Config.Site.SiteInfo
+	data := layoutData{
+		Title:     info.Title,
+		SiteTitle: info.Title,
+		Slogan:    info.Slogan,
+		Logo:      info.LogoURL,
+		Year:      time.Now().Year(),
+	}
+	if title != "" {
+		data.Title = title + " - " + info.Title
+	}
+	if info.EnableICP {
+		data.ICP = info.ICP
+	}
+	return data
+}
+
+func (s *site) item(a models.ArticleModel) item {
+	it := item{
+		ID:       a.ID,
+		Title:    a.Title,
+		URL:      articleURL(a.ID),
+		Abstract: a.Abstract,
+		Cover:    a.CoverURL,
+		Date:     a.CreatedAt,
+		Author:   link{Title: a.UserModel.Nickname, URL: userURL(a.UserID)},
+	}
+	for _, tag := range a.Tags {
+		if tagDir(tag) != "" {
+			it.Tags = append(it.Tags, link{Title: tag, URL: tagURL(tag)})
+		}
+	}
+	return it
+}
+
+func (s *site) list(layout layoutData, articles []models.ArticleModel) listData {
+	data := listData{layoutData: layout}
+	for _, a := range articles {
+		data.Items = append(data.Items, s.item(a))
+	}
+	return data
+}
+
+// markdownHTML 正文在保存时已经过滤过 xss，这里直接作为 HTML 输出
+func markdownHTML(md string) template.HTML {
+	return template.HTML(markdown.MdToHTML(md))
+}
+
+func render(t *template.Template, data any) []byte {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "layout", data); err != nil {
+		// 模板是写死的，出错只可能是代码问题
+		logrus.Errorf("静态页面渲染失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+var funcMap = template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("2006-01-02") },
+}
+
+const layoutTmpl = `{{define "layout"}}<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{- range .Meta}}
+{{- if .Property}}
+<meta property="{{.Property}}" content="{{.Content}}">
+{{- else}}
+<meta name="{{.Name}}" content="{{.Content}}">
+{{- end}}
+{{- end}}
+{{- if .Canonical}}
+<link rel="canonical" href="{{.Canonical}}">
+{{- end}}
+{{- if .JsonLd}}
+<script type="application/ld+json">{{.JsonLd}}</script>
+{{- end}}
+<link rel="alternate" type="application/rss+xml" title="{{.SiteTitle}}" href="/feed.xml">
+<link rel="alternate" type="application/atom+xml" title="{{.SiteTitle}}" href="/atom.xml">
+<link rel="alternate" type="application/feed+json" title="{{.SiteTitle}}" href="/feed.json">
+<link rel="stylesheet" href="/static.css">
+</head>
+<body>
+<header class="site">
+<a class="brand" href="/">{{if .Logo}}<img src="{{.Logo}}" alt="">{{end}}{{.SiteTitle}}</a>
+<nav><a href="/">首页</a><a href="/feed.xml">订阅</a></nav>
+</header>
+<main>
+{{template "content" .}}
+</main>
+<footer class="site">
+<p>© {{.Year}} {{.SiteTitle}}{{if .ICP}} · <a href="https://beian.miit.gov.cn/" rel="nofollow">{{.ICP}}</a>{{end}}</p>
+<p>这是站点的静态存档</p>
+</footer>
+</body>
+</html>
+{{end}}`
+
+const listContentTmpl = `{{define "content"}}
+<section class="heading">
+{{- if .Avatar}}<img class="avatar" src="{{.Avatar}}" alt="">{{end}}
+{{- if .Heading}}<h1>{{.Heading}}</h1>{{end}}
+{{- if .Description}}<p>{{.Description}}</p>{{end}}
+</section>
+{{- range .Items}}
+<article class="item">
+{{- if .Cover}}<a href="{{.URL}}"><img class="cover" src="{{.Cover}}" alt="" loading="lazy"></a>{{end}}
+<h2><a href="{{.URL}}">{{.Title}}</a></h2>
+<p class="info"><a href="{{.Author.URL}}">{{.Author.Title}}</a> · {{date .Date}}{{range .Tags}} <a class="tag" href="{{.URL}}">#{{.Title}}</a>{{end}}</p>
+{{- if .Abstract}}<p>{{.Abstract}}</p>{{end}}
+</article>
+{{- else}}
+<p>暂无文章</p>
+{{- end}}
+{{- if or .Prev .Next}}
+<nav class="pager">{{if .Prev}}<a href="{{.Prev}}">上一页</a>{{end}}{{if .Next}}<a href="{{.Next}}">下一页</a>{{end}}</nav>
+{{- end}}
+{{end}}`
+
+const articleContentTmpl = `{{define "content"}}
+<article class="article">
+<h1>{{.Article.Title}}</h1>
+<p class="info"><a href="{{.Article.Author.URL}}">{{.Article.Author.Title}}</a> · {{date .Article.Date}}
+{{- if .Category}} · <a href="{{.Category.URL}}">{{.Category.Title}}</a>{{end}}
+{{- range .Article.Tags}} <a class="tag" href="{{.URL}}">#{{.Title}}</a>{{end}}</p>
+{{- if .Article.Cover}}
+<img class="cover" src="{{.Article.Cover}}" alt="">
+{{- end}}
+<div class="content">
+{{.Content}}
+</div>
+</article>
+{{end}}`
+
+var (
+	listTmpl    = template.Must(template.New("list").Funcs(funcMap).Parse(layoutTmpl + listContentTmpl))
+	articleTmpl = template.Must(template.New("article").Funcs(funcMap).Parse(layoutTmpl + articleContentTmpl))
+)
+
+const styleCSS = `body{margin:0;font:16px/1.7 -apple-system,"PingFang SC","Microsoft YaHei",sans-serif;color:#222;background:#fafafa}
+a{color:#1e6bd6;text-decoration:none}
+a:hover{text-decoration:underline}
+header.site,footer.site,main{max-width:820px;margin:0 auto;padding:16px}
+header.site{display:flex;justify-content:space-between;align-items:center}
+header.site .brand{font-size:20px;font-weight:bold;color:#222;display:flex;align-items:center;gap:8px}
+header.site .brand img{height:32px}
+header.site nav a{margin-left:16px}
+footer.site{color:#888;font-size:14px;text-align:center}
+.heading .avatar{width:64px;height:64px;border-radius:50%}
+.item{background:#fff;border-radius:8px;padding:16px;margin-bottom:16px}
+.item h2{margin:0 0 4px;font-size:20px}
+.info{color:#888;font-size:14px}
+.tag{margin-left:4px}
+.cover{max-width:100%;border-radius:6px}
+.article{background:#fff;border-radius:8px;padding:24px}
+.content img{max-width:100%}
+.content pre{background:#f4f4f4;padding:12px;overflow:auto}
+.content table{border-collapse:collapse}
+.content td,.content th{border:1px solid #ddd;padding:4px 8px}
+.pager{display:flex;justify-content:space-between}
+`
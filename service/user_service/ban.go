@@ -0,0 +1,58 @@
+// Path: ./service/user_service/ban.go
+
+package user_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/access_token_service"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LogoutAll 下线用户的所有设备，之前签发的 token、refresh token 和访问令牌全部失效
+func LogoutAll(userID uint) error {
+	err := global.DB.Model(&models.UserLoginModel{}).Where("user_id = ? and revoked_at is null", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	if err = access_token_service.RevokeAll(userID); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%dpassword_update", userID)
+	return global.Redis.Set(key, time.Now().Unix(), global.Config.Jwt.SessionExpiry()).Err()
+}
+
+// Ban 封禁用户 days 天并立即下线，封禁期内不能登录
+func Ban(userID uint, days int, reason string) (until time.Time, err error) {
+	var user models.UserModel
+	if err = global.DB.Take(&user, userID).Error; err != nil {
+		return until, errors.New("用户不存在")
+	}
+	if user.Role == enum.AdminRoleType {
+		return until, errors.New("不能封禁管理员")
+	}
+
+	until = time.Now().AddDate(0, 0, days)
+	err = global.DB.Model(&user).Updates(map[string]any{
+		"banned_until": until,
+		"ban_reason":   reason,
+	}).Error
+	if err != nil {
+		return
+	}
+	err = LogoutAll(userID)
+	return
+}
+
+// BanMsg 登录时提示封禁的原因和到期时间
+func BanMsg(u *models.UserModel) string {
+	msg := fmt.Sprintf("账号已被封禁至 %s", u.BannedUntil.Format("2006-01-02 15:04"))
+	if u.BanReason != "" {
+		msg += "，原因：" + u.BanReason
+	}
+	return msg
+}
@@ -0,0 +1,35 @@
+// Path: ./service/user_service/login.go
+
+package user_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// Login 校验通过后创建登录设备并颁发 token，更新最后登录信息，记录登录日志
+func Login(user models.UserModel, loginType enum.LoginType, c *gin.Context) (token LoginToken, err error) {
+	token, err = NewSession(user, loginType, c)
+	if err != nil {
+		return
+	}
+
+	global.DB.Model(&user).Updates(map[string]interface{}{
+		"last_login_ip":   c.ClientIP(),
+		"last_login_time": time.Now(),
+	})
+	log_service.NewLoginSuccess(user, loginType, c)
+	return
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,207 @@
+// Path: ./service/user_service/session.go
+
+package user_service
+
+import (
+	"blogX_server/core"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/log_service"
+	"blogX_server/service/redis_service/redis_jwt"
+	"blogX_server/utils/jwts"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RefreshReuseGrace 同一个 refresh token 在这个时间内重复提交不算泄露，比如多个标签页同时刷新
+const RefreshReuseGrace = 10 * time.Second
+
+var errInvalidRefresh = errors.New("登录已失效，请重新登录")
+
+// LoginToken 登录后返回给前端，token 过期前用 refreshToken 换新的
+type LoginToken struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"` // 为空时沿用前端已有的 refresh token
+	ExpiresAt    int64  `json:"expiresAt"`              // token 的过期时间，秒级时间戳
+}
+
+// NewSession 创建登录设备并颁发 token
+func NewSession(user models.UserModel, loginType enum.LoginType, c *gin.Context) (token LoginToken, err error) {
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+	ip := c.ClientIP()
+	location, _ := core.GetLocationFromIP(ip)
+	now := time.Now()
+	expiresAt := now.Add(global.Config.Jwt.SessionExpiry())
+	s := models.UserLoginModel{
+		UserID:       user.ID,
+		IP:           ip,
+		IPLocation:   location,
+		UA:           truncate(c.Request.UserAgent(), 256),
+		LoginType:    loginType,
+		RefreshSalt:  hex.EncodeToString(salt),
+		LastActiveAt: &now,
+		ExpiresAt:    &expiresAt,
+	}
+	if err = global.DB.Create(&s).Error; err != nil {
+		return
+	}
+	return issue(user, s)
+}
+
+// Refresh 用 refresh token 换新的 token，refresh token 同时轮换，旧的作废
+// 已经轮换过的 refresh token 再次出现说明可能被盗用，直接下线这个设备
+func Refresh(refreshToken string, c *gin.Context) (token LoginToken, err error) {
+	parts := strings.Split(refreshToken, ".")
+	if len(parts) != 3 {
+		return token, errInvalidRefresh
+	}
+	id, err1 := strconv.ParseUint(parts[0], 10, 64)
+	gen, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return token, errInvalidRefresh
+	}
+	var s models.UserLoginModel
+	if err = global.DB.Take(&s, id).Error; err != nil || s.RefreshSalt == "" {
+		return token, errInvalidRefresh
+	}
+	// 签名不对的是伪造的，不影响这个设备
+	if !hmac.Equal([]byte(refreshSign(s.ID, gen, s.RefreshSalt)), []byte(parts[2])) {
+		return token, errInvalidRefresh
+	}
+	if !s.Active() {
+		return token, errors.New("登录已过期，请重新登录")
+	}
+
+	var user models.UserModel
+	if err = global.DB.Take(&user, s.UserID).Error; err != nil {
+		return token, errInvalidRefresh
+	}
+	if user.IsBanned() {
+		_ = RevokeSession(user.ID, s.ID)
+		return token, errors.New(BanMsg(&user))
+	}
+
+	if gen != s.RefreshGen {
+		// 宽限期内只颁发新的 token，不返回当前的 refresh token，它只属于完成轮换的那次请求
+		if gen == s.RefreshGen-1 && s.LastActiveAt != nil && time.Since(*s.LastActiveAt) < RefreshReuseGrace {
+			return issueAccess(user, s)
+		}
+		revokeReused(user, s, c)
+		return token, errInvalidRefresh
+	}
+
+	ip := c.ClientIP()
+	location, _ := core.GetLocationFromIP(ip)
+	now := time.Now()
+	expiresAt := now.Add(global.Config.Jwt.SessionExpiry())
+	// 带上轮换次数的条件，同一个 refresh token 并发提交时只有一次生效
+	result := global.DB.Model(&s).Where("refresh_gen = ? and revoked_at is null", gen).Updates(map[string]any{
+		"refresh_gen":    gen + 1,
+		"last_active_at": now,
+		"expires_at":     expiresAt,
+		"ip":             ip,
+		"ip_location":    location,
+	})
+	if result.Error != nil {
+		return token, result.Error
+	}
+	if result.RowsAffected == 0 {
+		revokeReused(user, s, c)
+		return token, errInvalidRefresh
+	}
+	s.RefreshGen = gen + 1
+	return issue(user, s)
+}
+
+// ListSessions 用户还在登录中的设备，最近活跃的在前
+func ListSessions(userID uint) (list []models.UserLoginModel) {
+	global.DB.Where("user_id = ? and revoked_at is null and expires_at > ?", userID, time.Now()).
+		Order("last_active_at desc").Find(&list)
+	return
+}
+
+// RevokeSession 下线用户的一个设备
+func RevokeSession(userID, sessionID uint) error {
+	result := global.DB.Model(&models.UserLoginModel{}).
+		Where("id = ? and user_id = ? and revoked_at is null", sessionID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("设备不存在或已下线")
+	}
+	redis_jwt.BlockSession(sessionID)
+	return nil
+}
+
+// RevokeOtherSessions 下线除了当前设备之外的所有设备
+func RevokeOtherSessions(userID, currentID uint) (count int64, err error) {
+	var idList []uint
+	global.DB.Model(&models.UserLoginModel{}).
+		Where("user_id = ? and id <> ? and revoked_at is null and expires_at > ?", userID, currentID, time.Now()).
+		Pluck("id", &idList)
+	if len(idList) == 0 {
+		return
+	}
+	result := global.DB.Model(&models.UserLoginModel{}).
+		Where("id in ? and revoked_at is null", idList).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	for _, id := range idList {
+		redis_jwt.BlockSession(id)
+	}
+	return result.RowsAffected, nil
+}
+
+// issue 给登录设备颁发 token 和当前的 refresh token
+func issue(user models.UserModel, s models.UserLoginModel) (token LoginToken, err error) {
+	token, err = issueAccess(user, s)
+	if err != nil {
+		return
+	}
+	token.RefreshToken = fmt.Sprintf("%d.%d.%s", s.ID, s.RefreshGen, refreshSign(s.ID, s.RefreshGen, s.RefreshSalt))
+	return
+}
+
+// issueAccess 只颁发 token，refresh token 为空
+func issueAccess(user models.UserModel, s models.UserLoginModel) (token LoginToken, err error) {
+	token.Token, err = jwts.GenerateToken(jwts.Claims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		SessionID: s.ID,
+	})
+	if err != nil {
+		return
+	}
+	token.ExpiresAt = time.Now().Add(global.Config.Jwt.AccessExpiry()).Unix()
+	return
+}
+
+// refreshSign refresh token 的签名，同时用到 jwt 密钥和设备的 salt，只泄露数据库不能伪造
+func refreshSign(sessionID uint, gen int, salt string) string {
+	mac := hmac.New(sha256.New, []byte(global.Config.Jwt.Secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%d.%s", sessionID, gen, salt)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// revokeReused 旧的 refresh token 被再次使用，下线设备并记录
+func revokeReused(user models.UserModel, s models.UserLoginModel, c *gin.Context) {
+	_ = RevokeSession(user.ID, s.ID)
+	log_service.NewSecurityLog(user, "refresh token 重复使用",
+		fmt.Sprintf("设备 %d（%s %s）可能被盗用，已下线", s.ID, s.IP, s.IPLocation), false, c)
+}
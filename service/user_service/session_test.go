@@ -0,0 +1,160 @@
+// Path: ./service/user_service/session_test.go
+
+package user_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/access_token_service"
+	"blogX_server/service/redis_service/redis_jwt"
+	"blogX_server/utils/testutil"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newContext() *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/user/refresh", nil)
+	// 内网地址不用查 ip 库
+	c.Request.RemoteAddr = "127.0.0.1:1234"
+	return c
+}
+
+// setupSession 一个已登录的用户，返回登录时拿到的 token
+func setupSession(t *testing.T) (models.UserModel, LoginToken) {
+	testutil.Setup(t, &models.UserModel{}, &models.UserLoginModel{}, &models.LogModel{}, &models.AccessTokenModel{})
+	global.Config.Jwt.Secret = "test-secret"
+	user := models.UserModel{Username: "user", Email: "user@example.com", Role: enum.UserRoleType}
+	global.DB.Create(&user)
+	token, err := NewSession(user, enum.UsernamePasswordLoginType, newContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, token
+}
+
+// backdate 把设备的最后活跃时间往前调，模拟宽限期已过
+func backdate(t *testing.T) {
+	t.Helper()
+	err := global.DB.Model(&models.UserLoginModel{}).Where("1 = 1").
+		Update("last_active_at", time.Now().Add(-RefreshReuseGrace-time.Second)).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefreshRotate(t *testing.T) {
+	_, login := setupSession(t)
+
+	token, err := Refresh(login.RefreshToken, newContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token == "" || token.RefreshToken == "" || token.RefreshToken == login.RefreshToken {
+		t.Fatalf("轮换后 token = %+v", token)
+	}
+	var s models.UserLoginModel
+	global.DB.Take(&s)
+	if s.RefreshGen != 1 || !s.Active() {
+		t.Errorf("轮换后 refreshGen = %d, active = %v", s.RefreshGen, s.Active())
+	}
+
+	backdate(t)
+	next, err := Refresh(token.RefreshToken, newContext())
+	if err != nil || next.RefreshToken == "" {
+		t.Fatalf("新的 refresh token 可以继续轮换, token = %+v, err = %v", next, err)
+	}
+}
+
+func TestRefreshGrace(t *testing.T) {
+	_, login := setupSession(t)
+
+	rotated, err := Refresh(login.RefreshToken, newContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 多个标签页同时刷新，宽限期内旧的 refresh token 只换到新的 token
+	token, err := Refresh(login.RefreshToken, newContext())
+	if err != nil {
+		t.Fatalf("宽限期内重复提交 err = %v", err)
+	}
+	if token.Token == "" || token.RefreshToken != "" {
+		t.Errorf("宽限期内不应该返回当前的 refresh token, token = %+v", token)
+	}
+
+	var s models.UserLoginModel
+	global.DB.Take(&s)
+	if s.RefreshGen != 1 || !s.Active() {
+		t.Fatalf("宽限期内不轮换也不下线, refreshGen = %d, active = %v", s.RefreshGen, s.Active())
+	}
+	if _, err = Refresh(rotated.RefreshToken, newContext()); err != nil {
+		t.Errorf("完成轮换的那次拿到的 refresh token 还能用, err = %v", err)
+	}
+}
+
+func TestRefreshReuseRevokes(t *testing.T) {
+	user, login := setupSession(t)
+
+	rotated, err := Refresh(login.RefreshToken, newContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backdate(t)
+	if _, err = Refresh(login.RefreshToken, newContext()); err == nil {
+		t.Fatal("宽限期过后重复使用旧的 refresh token 应该失败")
+	}
+
+	var s models.UserLoginModel
+	global.DB.Take(&s)
+	if s.Active() {
+		t.Error("重复使用后设备应该下线")
+	}
+	if _, blocked := redis_jwt.IsBlockedJWTToken(rotated.Token); !blocked {
+		t.Error("下线设备的 token 应该失效")
+	}
+	if _, err = Refresh(rotated.RefreshToken, newContext()); err == nil {
+		t.Error("下线后新的 refresh token 也不能用")
+	}
+	var count int64
+	global.DB.Model(&models.LogModel{}).Where("user_id = ? and title = ?", user.ID, "refresh token 重复使用").Count(&count)
+	if count != 1 {
+		t.Errorf("安全日志 %d 条，want 1", count)
+	}
+
+	// 伪造的签名不影响设备
+	setupSession(t)
+	if _, err = Refresh("1.0.bad", newContext()); err == nil {
+		t.Fatal("签名不对应该失败")
+	}
+	s = models.UserLoginModel{}
+	global.DB.Take(&s)
+	if !s.Active() {
+		t.Error("签名不对不应该下线设备")
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	user, login := setupSession(t)
+	pat, _ := access_token_service.Generate()
+	global.DB.Create(&models.AccessTokenModel{
+		UserID:    user.ID,
+		Name:      "ci",
+		TokenHash: access_token_service.Hash(pat),
+		Scopes:    []enum.AccessScope{enum.ScopeRead},
+	})
+
+	// 修改密码、管理员下线所有设备和封禁都会调用
+	if err := LogoutAll(user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Refresh(login.RefreshToken, newContext()); err == nil {
+		t.Error("下线后 refresh token 不能再用")
+	}
+	if _, _, err := access_token_service.Verify(pat, "127.0.0.1"); err == nil {
+		t.Error("下线后访问令牌不能再用")
+	}
+}
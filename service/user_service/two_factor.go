@@ -0,0 +1,307 @@
+// Path: ./service/user_service/two_factor.go
+
+package user_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/utils/totp"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// TwoFactorTicketExpiry 密码等校验通过后，输入两步验证码的有效时间
+const TwoFactorTicketExpiry = 5 * time.Minute
+
+// TwoFactorMaxFails 一个凭证最多输错几次，超过后要重新登录
+const TwoFactorMaxFails = 5
+
+// RecoveryCodeCount 每次生成的恢复码个数
+const RecoveryCodeCount = 10
+
+const twoFactorTicketPrefix = "2fa_ticket_"
+
+// TwoFactorTicket 需要两步验证时登录接口返回的内容，带着凭证和验证码调用第二步完成登录
+type TwoFactorTicket struct {
+	Ticket string `json:"ticket"`
+	Enroll bool   `json:"enroll"`           // 站点要求管理员开启两步验证但还没有绑定，要先扫码绑定
+	Secret string `json:"secret,omitempty"` // 绑定时不能扫码的手动输入
+	URI    string `json:"uri,omitempty"`    // 绑定时前端生成二维码
+}
+
+// twoFactorPending 凭证对应的登录
+type twoFactorPending struct {
+	UserID    uint           `json:"userID"`
+	LoginType enum.LoginType `json:"loginType"`
+	Enroll    bool           `json:"enroll"`
+}
+
+// TwoFactorResult 第二步验证通过后的结果
+type TwoFactorResult struct {
+	User          models.UserModel
+	LoginType     enum.LoginType
+	RecoveryCodes []string // 登录时完成绑定的，返回新生成的恢复码
+	UsedRecovery  bool     // 用的是恢复码
+}
+
+// GetTwoFactor 用户的两步验证，没有绑定过时返回 nil
+func GetTwoFactor(userID uint) *models.UserTwoFactorModel {
+	var tf models.UserTwoFactorModel
+	if err := global.DB.Take(&tf, "user_id = ?", userID).Error; err != nil {
+		return nil
+	}
+	return &tf
+}
+
+// TwoFactorEnabled 用户是否已开启两步验证
+func TwoFactorEnabled(userID uint) bool {
+	tf := GetTwoFactor(userID)
+	return tf != nil && tf.Enabled
+}
+
+// TwoFactorForced 站点是否要求这个用户必须开启两步验证
+func TwoFactorForced(user *models.UserModel) bool {
+	return user.Role == enum.AdminRoleType && global.Config.Site.Login.ForceAdmin2FA
+}
+
+// NewTwoFactorTicket 密码或第三方登录校验通过后调用
+// 需要两步验证时返回凭证，不需要时返回 nil，直接颁发 token
+func NewTwoFactorTicket(user models.UserModel, loginType enum.LoginType) (ticket *TwoFactorTicket, err error) {
+	pending := twoFactorPending{UserID: user.ID, LoginType: loginType}
+	ticket = &TwoFactorTicket{}
+	if !TwoFactorEnabled(user.ID) {
+		if !TwoFactorForced(&user) {
+			return nil, nil
+		}
+		pending.Enroll = true
+		ticket.Enroll = true
+		ticket.Secret, ticket.URI, err = EnrollTwoFactor(user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return nil, err
+	}
+	ticket.Ticket = hex.EncodeToString(b)
+	byteData, _ := json.Marshal(pending)
+	err = global.Redis.Set(twoFactorTicketPrefix+ticket.Ticket, byteData, TwoFactorTicketExpiry).Err()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// VerifyTwoFactorTicket 校验登录凭证和验证码，通过后凭证作废
+// 验证码错误时也返回用户，方便记录登录日志；错误次数过多凭证作废
+func VerifyTwoFactorTicket(ticket, code string) (r TwoFactorResult, err error) {
+	key := twoFactorTicketPrefix + ticket
+	failKey := key + "_fail"
+	val, err := global.Redis.Get(key).Result()
+	if err != nil {
+		return r, errors.New("验证已过期，请重新登录")
+	}
+	var pending twoFactorPending
+	if err = json.Unmarshal([]byte(val), &pending); err != nil {
+		return
+	}
+	r.LoginType = pending.LoginType
+	if err = global.DB.Take(&r.User, pending.UserID).Error; err != nil {
+		return r, errors.New("用户不存在")
+	}
+
+	if pending.Enroll {
+		r.RecoveryCodes, err = ConfirmTwoFactor(pending.UserID, code)
+	} else {
+		r.UsedRecovery, err = VerifyTwoFactor(pending.UserID, code)
+	}
+	if err != nil {
+		n, _ := global.Redis.Incr(failKey).Result()
+		global.Redis.Expire(failKey, TwoFactorTicketExpiry)
+		if n >= TwoFactorMaxFails {
+			global.Redis.Del(key, failKey)
+			err = errors.New("验证码错误次数过多，请重新登录")
+		}
+		return
+	}
+
+	// 同一个凭证并发提交时只有一次生效
+	if n, _ := global.Redis.Del(key).Result(); n == 0 {
+		return r, errors.New("验证已过期，请重新登录")
+	}
+	global.Redis.Del(failKey)
+	return
+}
+
+// EnrollTwoFactor 生成新的密钥，确认第一个验证码后才开启，没确认的密钥重新绑定时直接覆盖
+func EnrollTwoFactor(user models.UserModel) (secret, uri string, err error) {
+	tf := GetTwoFactor(user.ID)
+	if tf != nil && tf.Enabled {
+		return "", "", errors.New("已开启两步验证")
+	}
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return
+	}
+
+	if tf == nil {
+		err = global.DB.Create(&models.UserTwoFactorModel{UserID: user.ID, Secret: secret}).Error
+	} else {
+		result := global.DB.Model(tf).Where("enabled = ?", false).Update("secret", secret)
+		err = result.Error
+		if err == nil && result.RowsAffected == 0 {
+			err = errors.New("已开启两步验证")
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	issuer := global.Config.Site.SiteInfo.Title
+	if issuer == "" {
+		issuer = "blogX"
+	}
+	uri = totp.URI(issuer, user.Username, secret)
+	return
+}
+
+// ConfirmTwoFactor 用验证器上的第一个验证码确认绑定，开启两步验证并生成恢复码
+func ConfirmTwoFactor(userID uint, code string) (codes []string, err error) {
+	tf := GetTwoFactor(userID)
+	if tf == nil {
+		return nil, errors.New("请先绑定两步验证")
+	}
+	if tf.Enabled {
+		return nil, errors.New("已开启两步验证")
+	}
+	step, ok := totp.Validate(tf.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, errors.New("验证码错误")
+	}
+
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(tf).Where("enabled = ?", false).Updates(map[string]any{
+			"enabled":    true,
+			"enabled_at": time.Now(),
+			"last_step":  step,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("已开启两步验证")
+		}
+		var err error
+		codes, err = newRecoveryCodes(tx, userID)
+		return err
+	})
+	return
+}
+
+// VerifyTwoFactor 校验验证器上的验证码或者一个恢复码，recovery 表示用掉了一个恢复码
+func VerifyTwoFactor(userID uint, code string) (recovery bool, err error) {
+	tf := GetTwoFactor(userID)
+	if tf == nil || !tf.Enabled {
+		return false, errors.New("未开启两步验证")
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != totp.Digits {
+		return true, useRecoveryCode(userID, code)
+	}
+	step, ok := totp.Validate(tf.Secret, code, time.Now())
+	if !ok {
+		return false, errors.New("验证码错误")
+	}
+	// 带上周期条件，同一个验证码只能用一次
+	result := global.DB.Model(tf).Where("last_step < ?", step).Update("last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, errors.New("验证码已使用，请等待下一个验证码")
+	}
+	return false, nil
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码，之前的全部作废
+func RegenerateRecoveryCodes(userID uint) (codes []string, err error) {
+	if !TwoFactorEnabled(userID) {
+		return nil, errors.New("未开启两步验证")
+	}
+	err = global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = newRecoveryCodes(tx, userID)
+		return err
+	})
+	return
+}
+
+// RecoveryCodesLeft 还没用过的恢复码个数
+func RecoveryCodesLeft(userID uint) (count int64) {
+	global.DB.Model(&models.UserRecoveryCodeModel{}).Where("user_id = ? and used_at is null", userID).Count(&count)
+	return
+}
+
+// DisableTwoFactor 关闭两步验证，删除密钥和恢复码
+func DisableTwoFactor(userID uint) error {
+	return global.DBMaster.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTwoFactorModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCodeModel{}).Error
+	})
+}
+
+// useRecoveryCode 用掉一个恢复码，带上未使用的条件，并发时只有一次生效
+func useRecoveryCode(userID uint, code string) error {
+	result := global.DB.Model(&models.UserRecoveryCodeModel{}).
+		Where("user_id = ? and code_hash = ? and used_at is null", userID, recoveryCodeHash(code)).
+		Limit(1).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("验证码错误")
+	}
+	return nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes 删除旧的恢复码并生成新的，明文只在这里返回一次
+func newRecoveryCodes(tx *gorm.DB, userID uint) (codes []string, err error) {
+	if err = tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCodeModel{}).Error; err != nil {
+		return
+	}
+	var list []models.UserRecoveryCodeModel
+	b := make([]byte, 8)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		if _, err = rand.Read(b); err != nil {
+			return nil, err
+		}
+		s := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+		code := s[:5] + "-" + s[5:]
+		codes = append(codes, code)
+		list = append(list, models.UserRecoveryCodeModel{UserID: userID, CodeHash: recoveryCodeHash(code)})
+	}
+	err = tx.Create(&list).Error
+	return
+}
+
+// recoveryCodeHash 恢复码是随机生成的，不需要慢哈希；忽略大小写和分隔符
+func recoveryCodeHash(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
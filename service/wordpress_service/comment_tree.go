@@ -0,0 +1,72 @@
+// Path: ./service/wordpress_service/comment_tree.go
+
+package wordpress_service
+
+import "sort"
+
+// CommentNode 整理后的一条评论，Parent 为父评论在列表中的下标，根评论为 -1
+type CommentNode struct {
+	Comment
+	Parent     int
+	Depth      int
+	ReplyCount int // 所有子孙回复数
+}
+
+// BuildCommentTree 只保留审核通过的普通评论，按时间排序并保证父评论在子评论之前
+// 父评论没有导入（未通过审核、是 pingback 等）的作为根评论
+// 超过 maxDepth 层的挂到第 maxDepth-1 层的祖先上，保证导入后都能显示
+func BuildCommentTree(list []Comment, maxDepth int) []CommentNode {
+	approved := map[int]Comment{}
+	for _, c := range list {
+		if c.Approved == "1" && (c.Type == "" || c.Type == "comment") {
+			approved[c.ID] = c
+		}
+	}
+
+	children := map[int][]Comment{}
+	var roots []Comment
+	for _, c := range approved {
+		if _, ok := approved[c.Parent]; ok && c.Parent != c.ID {
+			children[c.Parent] = append(children[c.Parent], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+	byTime := func(s []Comment) {
+		sort.Slice(s, func(i, j int) bool {
+			ti, tj := s[i].CreatedAt(), s[j].CreatedAt()
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return s[i].ID < s[j].ID
+		})
+	}
+
+	var nodes []CommentNode
+	var walk func(c Comment, parent, depth int)
+	walk = func(c Comment, parent, depth int) {
+		if maxDepth > 0 && depth >= maxDepth {
+			// 挂到祖先上，和原来的父评论同一层
+			depth = maxDepth - 1
+			parent = nodes[parent].Parent
+		}
+		i := len(nodes)
+		nodes = append(nodes, CommentNode{Comment: c, Parent: parent, Depth: depth})
+		kids := children[c.ID]
+		byTime(kids)
+		for _, kid := range kids {
+			walk(kid, i, depth+1)
+		}
+	}
+	byTime(roots)
+	for _, c := range roots {
+		walk(c, -1, 0)
+	}
+
+	for i := range nodes {
+		for p := nodes[i].Parent; p >= 0; p = nodes[p].Parent {
+			nodes[p].ReplyCount++
+		}
+	}
+	return nodes
+}
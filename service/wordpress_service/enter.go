@@ -0,0 +1,149 @@
+// Path: ./service/wordpress_service/enter.go
+
+// Package wordpress_service 导入 WordPress 导出的 WXR 文件
+// 导入在后台执行，进度保存在 ImportJobModel 中，服务重启或出错后可以从断点继续
+package wordpress_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"bytes"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// jobDir 上传的 WXR 文件保存的目录，不能放在 uploads 下（会被公开访问）
+const jobDir = "imports"
+
+// maxJobErrors 任务中最多记录的错误数
+const maxJobErrors = 100
+
+// running 正在执行的任务，同一个任务同时只能有一个协程在执行
+var running sync.Map
+
+// CreateJob 保存 WXR 文件并创建导入任务，文件会先解析一次，格式不对的直接报错
+func CreateJob(data []byte, fileName string, uid uint, dryRun bool) (job models.ImportJobModel, err error) {
+	posts, err := ParseWXR(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	if len(posts) == 0 {
+		err = fmt.Errorf("文件中没有可以导入的文章")
+		return
+	}
+
+	if err = os.MkdirAll(jobDir, 0755); err != nil {
+		return
+	}
+	filePath := filepath.Join(jobDir, fmt.Sprintf("%d_%d.xml", uid, time.Now().UnixNano()))
+	if err = os.WriteFile(filePath, data, 0644); err != nil {
+		return
+	}
+
+	job = models.ImportJobModel{
+		UserID:   uid,
+		FileName: truncate(fileName, 128),
+		FilePath: filePath,
+		DryRun:   dryRun,
+		Status:   enum.ImportJobStatusPending,
+		Total:    len(posts),
+	}
+	if err = global.DB.Create(&job).Error; err != nil {
+		_ = os.Remove(filePath)
+	}
+	return
+}
+
+// Start 在后台执行任务，已经在执行的任务直接返回 false
+func Start(jobID uint) bool {
+	if _, loaded := running.LoadOrStore(jobID, struct{}{}); loaded {
+		return false
+	}
+	go func() {
+		defer running.Delete(jobID)
+		run(jobID)
+	}()
+	return true
+}
+
+// IsRunning 任务是否正在执行
+func IsRunning(jobID uint) bool {
+	_, ok := running.Load(jobID)
+	return ok
+}
+
+// ResumeJobs 服务启动时继续执行上次没有执行完的任务
+func ResumeJobs() {
+	var list []uint
+	global.DB.Model(&models.ImportJobModel{}).
+		Where("status in ?", []enum.ImportJobStatus{enum.ImportJobStatusPending, enum.ImportJobStatusRunning}).
+		Pluck("id", &list)
+	for _, id := range list {
+		logrus.Infof("继续执行导入任务 %d", id)
+		Start(id)
+	}
+}
+
+func run(jobID uint) {
+	var job models.ImportJobModel
+	if err := global.DB.Take(&job, jobID).Error; err != nil {
+		logrus.Errorf("导入任务 %d 不存在: %v", jobID, err)
+		return
+	}
+	if job.Status == enum.ImportJobStatusDone {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			failJob(&job, fmt.Sprint(r))
+		}
+	}()
+
+	data, err := os.ReadFile(job.FilePath)
+	if err != nil {
+		failJob(&job, "文件读取失败: "+err.Error())
+		return
+	}
+	posts, err := ParseWXR(bytes.NewReader(data))
+	if err != nil {
+		failJob(&job, err.Error())
+		return
+	}
+	err = global.DB.Model(&job).Updates(map[string]any{
+		"status": enum.ImportJobStatusRunning,
+		"total":  len(posts),
+		"error":  "",
+	}).Error
+	if err != nil {
+		logrus.Errorf("导入任务 %d 状态更新失败: %v", job.ID, err)
+		return
+	}
+
+	im := newImporter(&job)
+	for job.Cursor < len(posts) {
+		im.importPost(posts[job.Cursor])
+	}
+
+	now := time.Now()
+	global.DB.Model(&job).Updates(map[string]any{
+		"status":      enum.ImportJobStatusDone,
+		"finished_at": &now,
+	})
+	_ = os.Remove(job.FilePath)
+	im.finish()
+	logrus.Infof("导入任务 %d 完成: 导入 %d, 跳过 %d, 失败 %d, 评论 %d",
+		job.ID, job.Created, job.Skipped, job.Failed, job.Comments)
+}
+
+func failJob(job *models.ImportJobModel, msg string) {
+	logrus.Errorf("导入任务 %d 失败: %s", job.ID, msg)
+	global.DB.Model(job).Updates(map[string]any{
+		"status": enum.ImportJobStatusFailed,
+		"error":  truncate(msg, 1024),
+	})
+}
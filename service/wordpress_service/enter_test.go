@@ -0,0 +1,158 @@
+// Path: ./service/wordpress_service/enter_test.go
+
+package wordpress_service
+
+import (
+	"strings"
+	"testing"
+)
+
+const testWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>旧博客</title>
+	<wp:category><wp:term_id>1</wp:term_id><wp:cat_name><![CDATA[Go]]></wp:cat_name></wp:category>
+	<item>
+		<title>封面</title>
+		<wp:post_id>9</wp:post_id>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:status><![CDATA[inherit]]></wp:status>
+		<wp:attachment_url><![CDATA[https://old.blog/wp-content/uploads/cover.png]]></wp:attachment_url>
+	</item>
+	<item>
+		<title>第一篇</title>
+		<pubDate>Fri, 01 Mar 2019 10:00:00 +0000</pubDate>
+		<content:encoded><![CDATA[<p>正文 &amp; <strong>加粗</strong></p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[摘要]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date><![CDATA[2019-03-01 18:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2019-03-01 10:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<wp:post_password><![CDATA[]]></wp:post_password>
+		<category domain="category" nicename="go"><![CDATA[Go]]></category>
+		<category domain="category" nicename="uncategorized"><![CDATA[Uncategorized]]></category>
+		<category domain="post_tag" nicename="web"><![CDATA[Web]]></category>
+		<wp:postmeta><wp:meta_key><![CDATA[_thumbnail_id]]></wp:meta_key><wp:meta_value><![CDATA[9]]></wp:meta_value></wp:postmeta>
+		<wp:comment>
+			<wp:comment_id>1</wp:comment_id>
+			<wp:comment_author><![CDATA[张三]]></wp:comment_author>
+			<wp:comment_author_email><![CDATA[a@b.com]]></wp:comment_author_email>
+			<wp:comment_date_gmt><![CDATA[2019-03-02 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[好文]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+	</item>
+	<item>
+		<title>草稿</title>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:post_date><![CDATA[2020-01-01 08:00:00]]></wp:post_date>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>回收站</title>
+		<wp:post_id>12</wp:post_id>
+		<wp:status><![CDATA[trash]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>关于</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func TestParseWXR(t *testing.T) {
+	list, err := ParseWXR(strings.NewReader(testWXR))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ParseWXR() 得到 %d 篇文章, want 2", len(list))
+	}
+
+	p := list[0]
+	if p.Title != "第一篇" || p.Status != "publish" || p.Password {
+		t.Errorf("post = %+v", p)
+	}
+	if p.Content != "<p>正文 &amp; <strong>加粗</strong></p>" || p.Excerpt != "摘要" {
+		t.Errorf("content = %q, excerpt = %q", p.Content, p.Excerpt)
+	}
+	if got := p.Date.UTC().Format("2006-01-02 15:04:05"); got != "2019-03-01 10:00:00" {
+		t.Errorf("date = %s", got)
+	}
+	if len(p.Categories) != 1 || p.Categories[0] != "Go" || len(p.Tags) != 1 || p.Tags[0] != "Web" {
+		t.Errorf("categories = %v, tags = %v", p.Categories, p.Tags)
+	}
+	if p.Cover != "https://old.blog/wp-content/uploads/cover.png" {
+		t.Errorf("cover = %q", p.Cover)
+	}
+	if len(p.Comments) != 1 || p.Comments[0].Author != "张三" || p.Comments[0].Approved != "1" {
+		t.Errorf("comments = %+v", p.Comments)
+	}
+
+	// 草稿没有 UTC 时间，取本地时间
+	if got := list[1].Date.Format("2006-01-02 15:04:05"); got != "2020-01-01 08:00:00" {
+		t.Errorf("draft date = %s", got)
+	}
+}
+
+func TestParseWXRInvalid(t *testing.T) {
+	if _, err := ParseWXR(strings.NewReader("not xml")); err == nil {
+		t.Error("ParseWXR() 应该报错")
+	}
+}
+
+func TestBuildCommentTree(t *testing.T) {
+	c := func(id, parent int, date string) Comment {
+		return Comment{ID: id, Parent: parent, DateGmt: date, Approved: "1"}
+	}
+	list := []Comment{
+		c(3, 2, "2020-01-03 00:00:00"),
+		c(2, 1, "2020-01-02 00:00:00"),
+		c(1, 0, "2020-01-01 00:00:00"),
+		c(4, 3, "2020-01-04 00:00:00"), // 第 4 层，超过限制
+		c(5, 0, "2019-12-31 00:00:00"),
+		{ID: 6, Parent: 1, Approved: "0"},                   // 未通过
+		{ID: 7, Parent: 1, Approved: "1", Type: "pingback"}, // pingback
+		c(8, 6, "2020-01-05 00:00:00"),                      // 父评论未通过，作为根评论
+	}
+	nodes := BuildCommentTree(list, 3)
+
+	type want struct{ id, parentID, depth, replies int }
+	wants := []want{
+		{5, 0, 0, 0},
+		{1, 0, 0, 3},
+		{2, 1, 1, 2},
+		{3, 2, 2, 0},
+		{4, 2, 2, 0}, // 挂到 2 上，和 3 同一层
+		{8, 0, 0, 0},
+	}
+	if len(nodes) != len(wants) {
+		t.Fatalf("BuildCommentTree() 得到 %d 条评论, want %d", len(nodes), len(wants))
+	}
+	for i, w := range wants {
+		n := nodes[i]
+		parentID := 0
+		if n.Parent >= 0 {
+			if n.Parent >= i {
+				t.Fatalf("评论 %d 的父评论排在它后面", n.ID)
+			}
+			parentID = nodes[n.Parent].ID
+		}
+		if n.ID != w.id || parentID != w.parentID || n.Depth != w.depth || n.ReplyCount != w.replies {
+			t.Errorf("nodes[%d] = {id:%d parent:%d depth:%d replies:%d}, want %+v",
+				i, n.ID, parentID, n.Depth, n.ReplyCount, w)
+		}
+	}
+}
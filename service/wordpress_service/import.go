@@ -0,0 +1,328 @@
+// Path: ./service/wordpress_service/import.go
+
+package wordpress_service
+
+import (
+	"blogX_server/common/transaction"
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/ctype"
+	"blogX_server/models/enum"
+	"blogX_server/service/comment_service"
+	"blogX_server/service/message_service"
+	"blogX_server/service/redis_service/redis_cache"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/hash"
+	"blogX_server/utils/markdown"
+	"blogX_server/utils/xss"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// captionRe WordPress 的 [caption] 短代码，只保留其中的内容
+var captionRe = regexp.MustCompile(`\[/?caption[^\]]*\]`)
+
+type importer struct {
+	job        *models.ImportJobModel
+	admin      bool // 上传文件的是管理员，评论者的邮箱才能对应到站内用户
+	categories map[string]*uint
+	users      map[string]models.UserModel // 评论者（邮箱或昵称）-> 用户
+	published  bool
+}
+
+func newImporter(job *models.ImportJobModel) *importer {
+	var u models.UserModel
+	global.DB.Take(&u, job.UserID)
+	return &importer{
+		job:        job,
+		admin:      u.Role == enum.AdminRoleType,
+		categories: map[string]*uint{},
+		users:      map[string]models.UserModel{},
+	}
+}
+
+// importPost 导入一篇文章，处理完之后 job.Cursor 前进一位
+// 已有同名文章的跳过；试运行时只统计，不写入任何数据
+func (im *importer) importPost(p Post) {
+	job := im.job
+	job.Cursor++
+
+	title := truncate(p.Title, 128)
+	if title == "" {
+		title = fmt.Sprintf("无标题 %d", p.ID)
+	}
+	var count int64
+	global.DB.Model(&models.ArticleModel{}).Where("user_id = ? and title = ?", job.UserID, title).Count(&count)
+	if count > 0 {
+		job.Skipped++
+		im.save()
+		return
+	}
+
+	article, err := im.buildArticle(p, title)
+	if err != nil {
+		im.fail(title, err)
+		return
+	}
+	nodes := BuildCommentTree(p.Comments, global.Config.Site.Article.CommentDepth)
+	comments, parents, err := im.buildComments(article, nodes)
+	if err != nil {
+		im.fail(title, err)
+		return
+	}
+
+	if job.DryRun {
+		job.Created++
+		job.Comments += len(comments)
+		im.save()
+		return
+	}
+
+	job.Created++
+	job.Comments += len(comments)
+	err = transaction.ImportArticleTx(&article, comments, parents, job)
+	if err != nil {
+		job.Created--
+		job.Comments -= len(comments)
+		im.fail(title, err)
+		return
+	}
+	if article.Status == enum.ArticleStatusPublish {
+		im.published = true
+	}
+}
+
+func (im *importer) buildArticle(p Post, title string) (a models.ArticleModel, err error) {
+	content := xss.Filter(markdown.HTMLToMd(captionRe.ReplaceAllString(p.Content, "")))
+
+	abstract := p.Excerpt
+	if abstract == "" {
+		abstract = content
+	} else {
+		abstract = markdown.HTMLToMd(abstract)
+	}
+	abstract, err = markdown.ExtractContent(xss.Filter(abstract), 100)
+	if err != nil {
+		return
+	}
+	// 敏感词和发布文章时一样：有拦截词的不导入，需要审核的词不走自动审核
+	review, err := sensitive_service.Filter(&title, &abstract, &content)
+	if err != nil {
+		return
+	}
+
+	// 第一个分类作为文章分类，其余的分类和标签一起作为标签
+	var categoryID *uint
+	tags := p.Tags
+	if len(p.Categories) > 0 {
+		categoryID, err = im.category(p.Categories[0])
+		if err != nil {
+			return
+		}
+		tags = append(append([]string{}, p.Categories[1:]...), tags...)
+	}
+
+	// 和其他导入方式一样：已发布的文章开启免审核时直接发布，否则进入审核；有密码保护的文章作为草稿
+	status := enum.ArticleStatusDraft
+	if p.Status == "publish" && !p.Password {
+		status = enum.ArticleStatusReview
+		if global.Config.Site.Article.AutoApprove && !review {
+			status = enum.ArticleStatusPublish
+		}
+	}
+
+	a = models.ArticleModel{
+		Title:          title,
+		Abstract:       abstract,
+		CoverURL:       truncate(p.Cover, 256),
+		Content:        content,
+		CategoryID:     categoryID,
+		Tags:           ctype.List(cleanTags(tags)),
+		UserID:         im.job.UserID,
+		Status:         status,
+		OpenForComment: true,
+	}
+	// 保留原来的发布时间
+	if !p.Date.IsZero() && p.Date.Before(time.Now()) {
+		a.CreatedAt = p.Date
+		a.UpdatedAt = p.Date
+		if p.Modified.After(p.Date) && p.Modified.Before(time.Now()) {
+			a.UpdatedAt = p.Modified
+		}
+	}
+	return
+}
+
+// buildComments 按 BuildCommentTree 的顺序生成评论
+// 和发表评论一样经过敏感词过滤和先审后发，父评论没有发布的回复也不发布
+// 回复数和文章的评论数只算已发布的
+func (im *importer) buildComments(a models.ArticleModel, nodes []CommentNode) (list []models.CommentModel, parents []int, err error) {
+	for _, n := range nodes {
+		u, err := im.commenter(n.Comment)
+		if err != nil {
+			return nil, nil, err
+		}
+		content := xss.Filter(markdown.HTMLToMd(n.Content))
+		if content == "" {
+			content = "-"
+		}
+		status := enum.CommentStatusPublished
+		review, err := sensitive_service.Filter(&content)
+		switch {
+		case err != nil:
+			status = enum.CommentStatusRejected
+		case review || comment_service.NeedModeration(a, u, u.Role, content):
+			status = enum.CommentStatusPending
+		case n.Parent >= 0 && list[n.Parent].Status != enum.CommentStatusPublished:
+			status = enum.CommentStatusPending
+		}
+		cmt := models.CommentModel{
+			Content: content,
+			UserID:  u.ID,
+			Depth:   n.Depth,
+			Status:  status,
+		}
+		if t := n.CreatedAt(); !t.IsZero() {
+			cmt.CreatedAt = t
+			cmt.UpdatedAt = t
+		}
+		list = append(list, cmt)
+		parents = append(parents, n.Parent)
+	}
+	for i := range list {
+		if list[i].Status != enum.CommentStatusPublished {
+			continue
+		}
+		for p := parents[i]; p >= 0; p = parents[p] {
+			list[p].ReplyCount++
+		}
+	}
+	return
+}
+
+// commenter 管理员导入时，评论者的邮箱是站内用户的，直接用这个用户
+// 否则为其创建一个占位用户，用户名由邮箱（没有邮箱时由昵称）决定，重复导入时复用
+// 普通用户导入的文件可以随意填写邮箱，不能让评论冒用别人的账号
+// 占位用户不使用评论者的真实邮箱，以免占用别人的邮箱导致对方无法注册
+func (im *importer) commenter(c Comment) (u models.UserModel, err error) {
+	email := strings.ToLower(strings.TrimSpace(c.AuthorEmail))
+	nickname := strings.TrimSpace(c.Author)
+	key := email
+	if key == "" {
+		key = "name:" + nickname
+	}
+	if u, ok := im.users[key]; ok {
+		return u, nil
+	}
+
+	if im.admin && email != "" && global.DB.Take(&u, "email = ?", email).Error == nil {
+		im.users[key] = u
+		return u, nil
+	}
+	username := "wp_" + hash.Md5([]byte(key))[:16]
+	if global.DB.Take(&u, "username = ?", username).Error == nil {
+		im.users[key] = u
+		return u, nil
+	}
+
+	im.job.Users++
+	if nickname == "" {
+		nickname = "匿名"
+	}
+	u = models.UserModel{
+		Username:       username,
+		Email:          username + "@wordpress.invalid",
+		Nickname:       truncate(nickname, 32),
+		RegisterSource: enum.RegisterSourceImportType,
+		Role:           enum.UserRoleType,
+	}
+	if im.job.DryRun {
+		u.CreatedAt = time.Now()
+		im.users[key] = u
+		return u, nil
+	}
+	err = transaction.CreateUserAndUserConfigTx(u)
+	if err == nil {
+		err = global.DB.Take(&u, "username = ?", username).Error
+	}
+	if err != nil {
+		im.job.Users--
+		return u, fmt.Errorf("评论者 %s 创建失败: %w", nickname, err)
+	}
+	im.users[key] = u
+	return u, nil
+}
+
+// category 分类按名称匹配用户已有的分类，没有就创建，试运行时不创建
+func (im *importer) category(name string) (*uint, error) {
+	name = truncate(name, 32)
+	if id, ok := im.categories[name]; ok {
+		return id, nil
+	}
+	var cat models.CategoryModel
+	err := global.DB.Take(&cat, "user_id = ? and name = ?", im.job.UserID, name).Error
+	if err != nil {
+		if im.job.DryRun {
+			im.categories[name] = nil
+			return nil, nil
+		}
+		cat = models.CategoryModel{Name: name, UserID: im.job.UserID}
+		if err = global.DB.Create(&cat).Error; err != nil {
+			return nil, fmt.Errorf("分类 %s 创建失败: %w", name, err)
+		}
+	}
+	im.categories[name] = &cat.ID
+	return &cat.ID, nil
+}
+
+func (im *importer) fail(title string, err error) {
+	im.job.Failed++
+	if len(im.job.Errors) < maxJobErrors {
+		im.job.Errors = append(im.job.Errors, fmt.Sprintf("%s: %s", title, err.Error()))
+	}
+	im.save()
+}
+
+func (im *importer) save() {
+	global.DB.Model(im.job).Select(models.ImportJobProgressColumns).Updates(im.job)
+}
+
+// finish 任务完成后清理缓存并通知用户
+func (im *importer) finish() {
+	job := im.job
+	if im.published {
+		redis_cache.CacheCloseAll(redis_cache.CacheFeedPrefix)
+	}
+	title := "WordPress 导入完成"
+	if job.DryRun {
+		title = "WordPress 导入试运行完成"
+	}
+	content := fmt.Sprintf("文件 %s 共 %d 篇文章：导入 %d 篇，跳过同名文章 %d 篇，失败 %d 篇；评论 %d 条，新建评论者 %d 个",
+		job.FileName, job.Total, job.Created, job.Skipped, job.Failed, job.Comments, job.Users)
+	_ = message_service.SendSystemNotify(job.UserID, title, content, "", "")
+}
+
+// cleanTags 去重，标签中不能有逗号（标签按逗号存储）
+func cleanTags(tags []string) (list []string) {
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = truncate(strings.TrimSpace(strings.ReplaceAll(t, ",", " ")), 32)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		list = append(list, t)
+	}
+	return
+}
+
+// truncate 按字符截断
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,101 @@
+// Path: ./service/wordpress_service/import_test.go
+
+package wordpress_service
+
+import (
+	"blogX_server/global"
+	"blogX_server/models"
+	"blogX_server/models/enum"
+	"blogX_server/service/sensitive_service"
+	"blogX_server/utils/testutil"
+	"strings"
+	"testing"
+)
+
+// setupImport 管理员 1，普通用户 2，站内用户 3 的邮箱是 real@example.com
+func setupImport(t *testing.T) {
+	testutil.Setup(t, &models.UserModel{}, &models.UserConfigModel{}, &models.UserMessageConfModel{}, &models.SensitiveWordModel{})
+	global.DB.Create(&[]models.UserModel{
+		{Username: "admin", Email: "admin@example.com", Role: enum.AdminRoleType},
+		{Username: "user", Email: "user@example.com", Role: enum.UserRoleType},
+		{Username: "real", Email: "real@example.com", Role: enum.UserRoleType},
+	})
+}
+
+func TestCommenter(t *testing.T) {
+	setupImport(t)
+	c := Comment{Author: "张三", AuthorEmail: "Real@Example.com"}
+
+	im := newImporter(&models.ImportJobModel{UserID: 1})
+	u, err := im.commenter(c)
+	if err != nil || u.ID != 3 {
+		t.Fatalf("管理员导入时应该对应到站内用户, got %d, %v", u.ID, err)
+	}
+
+	im = newImporter(&models.ImportJobModel{UserID: 2})
+	u, err = im.commenter(c)
+	if err != nil || u.ID == 3 || !strings.HasPrefix(u.Username, "wp_") {
+		t.Fatalf("普通用户导入时应该用占位用户, got %+v, %v", u, err)
+	}
+	if im.job.Users != 1 || u.Email == "real@example.com" {
+		t.Errorf("users = %d, email = %s", im.job.Users, u.Email)
+	}
+	// 同一个评论者复用
+	if u2, _ := im.commenter(c); u2.ID != u.ID || im.job.Users != 1 {
+		t.Errorf("重复的评论者没有复用: %d != %d", u2.ID, u.ID)
+	}
+}
+
+func TestBuildComments(t *testing.T) {
+	setupImport(t)
+	global.DB.Create(&[]models.SensitiveWordModel{
+		{Word: "赌博", Category: "违法", Action: enum.SensitiveActionBlock},
+		{Word: "加微信", Category: "广告", Action: enum.SensitiveActionReview},
+	})
+	if err := sensitive_service.Load(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		global.DB.Where("1 = 1").Delete(&models.SensitiveWordModel{})
+		_ = sensitive_service.Load()
+	})
+
+	c := func(id, parent int, email, content string) Comment {
+		return Comment{ID: id, Parent: parent, Author: email, AuthorEmail: email, Content: content, Approved: "1"}
+	}
+	nodes := BuildCommentTree([]Comment{
+		c(1, 0, "a@example.com", "好文"),
+		c(2, 1, "b@example.com", "同意"),
+		c(3, 0, "c@example.com", "加微信"),
+		c(4, 3, "a@example.com", "回复待审核的评论"),
+		c(5, 0, "d@example.com", "一起赌博"),
+	}, 0)
+
+	im := newImporter(&models.ImportJobModel{UserID: 2})
+	list, _, err := im.buildComments(models.ArticleModel{UserID: 2}, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		status  enum.CommentStatus
+		replies int
+	}{
+		{enum.CommentStatusPublished, 1},
+		{enum.CommentStatusPublished, 0},
+		{enum.CommentStatusPending, 0}, // 需要审核的词
+		{enum.CommentStatusPending, 0}, // 父评论没有发布
+		{enum.CommentStatusRejected, 0},
+	}
+	for i, w := range want {
+		if list[i].Status != w.status || list[i].ReplyCount != w.replies {
+			t.Errorf("list[%d] status = %d, replies = %d, want %+v", i, list[i].Status, list[i].ReplyCount, w)
+		}
+	}
+
+	// 全部先审后发时，占位用户的评论都要审核
+	global.Config.Site.Article.CommentModeration = enum.CommentModerationAll
+	list, _, _ = im.buildComments(models.ArticleModel{UserID: 2}, nodes[:1])
+	if list[0].Status != enum.CommentStatusPending {
+		t.Errorf("先审后发时 status = %d", list[0].Status)
+	}
+}
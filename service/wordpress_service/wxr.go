@@ -0,0 +1,177 @@
+// Path: ./service/wordpress_service/wxr.go
+
+package wordpress_service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WXR 的标签带有 wp: content: excerpt: 等命名空间，版本不同命名空间的地址也不同（1.0 / 1.1 / 1.2）
+// 所以这里只按标签名匹配，content:encoded 和 excerpt:encoded 同名，按命名空间区分
+
+type wxrFile struct {
+	Channel struct {
+		Items []wxrItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type wxrItem struct {
+	Title       string        `xml:"title"`
+	PubDate     string        `xml:"pubDate"`
+	Encoded     []wxrEncoded  `xml:"encoded"`
+	PostID      int           `xml:"post_id"`
+	PostDate    string        `xml:"post_date"`
+	PostDateGmt string        `xml:"post_date_gmt"`
+	ModifiedGmt string        `xml:"post_modified_gmt"`
+	Status      string        `xml:"status"`
+	PostType    string        `xml:"post_type"`
+	Password    string        `xml:"post_password"`
+	AttachURL   string        `xml:"attachment_url"`
+	Categories  []wxrCategory `xml:"category"`
+	Meta        []wxrMeta     `xml:"postmeta"`
+	Comments    []Comment     `xml:"comment"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrCategory struct {
+	Domain string `xml:"domain,attr"` // category 或 post_tag
+	Name   string `xml:",chardata"`
+}
+
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// Post WXR 中的一篇文章
+type Post struct {
+	ID         int
+	Title      string
+	Content    string // HTML
+	Excerpt    string
+	Status     string // publish draft pending private future
+	Password   bool   // 有密码保护的文章
+	Date       time.Time
+	Modified   time.Time
+	Categories []string
+	Tags       []string
+	Cover      string // 特色图片的地址
+	Comments   []Comment
+}
+
+// Comment WXR 中的一条评论
+type Comment struct {
+	ID          int    `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	Date        string `xml:"comment_date"`
+	DateGmt     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"` // 空或 comment 为普通评论，pingback trackback 不导入
+	Parent      int    `xml:"comment_parent"`
+}
+
+// CreatedAt 评论时间
+func (c Comment) CreatedAt() time.Time {
+	return parseDate(c.DateGmt, c.Date, "")
+}
+
+// ParseWXR 解析 WordPress 导出的 WXR 文件，只返回需要导入的文章（回收站、自动草稿、页面、附件等不导入）
+func ParseWXR(r io.Reader) (list []Post, err error) {
+	d := xml.NewDecoder(r)
+	// 部分导出文件不是严格的 XML，比如正文外带了 HTML 实体
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "utf8") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("不支持的编码 %s", charset)
+	}
+
+	var f wxrFile
+	if err = d.Decode(&f); err != nil {
+		return nil, fmt.Errorf("WXR 文件解析失败: %w", err)
+	}
+
+	// 特色图片存的是附件的 id
+	attachments := map[string]string{}
+	for _, item := range f.Channel.Items {
+		if item.PostType == "attachment" && item.AttachURL != "" {
+			attachments[fmt.Sprint(item.PostID)] = strings.TrimSpace(item.AttachURL)
+		}
+	}
+
+	for _, item := range f.Channel.Items {
+		if item.PostType != "post" {
+			continue
+		}
+		switch item.Status {
+		case "publish", "draft", "pending", "private", "future":
+		default:
+			continue
+		}
+		p := Post{
+			ID:       item.PostID,
+			Title:    strings.TrimSpace(item.Title),
+			Status:   item.Status,
+			Password: item.Password != "",
+			Date:     parseDate(item.PostDateGmt, item.PostDate, item.PubDate),
+			Modified: parseDate(item.ModifiedGmt, "", ""),
+			Comments: item.Comments,
+		}
+		for _, e := range item.Encoded {
+			if strings.Contains(e.XMLName.Space, "excerpt") {
+				p.Excerpt = strings.TrimSpace(e.Value)
+			} else {
+				p.Content = e.Value
+			}
+		}
+		for _, c := range item.Categories {
+			name := strings.TrimSpace(c.Name)
+			if name == "" {
+				continue
+			}
+			switch c.Domain {
+			case "category":
+				// WordPress 的默认分类没有实际意义
+				if name != "Uncategorized" && name != "未分类" {
+					p.Categories = append(p.Categories, name)
+				}
+			case "post_tag":
+				p.Tags = append(p.Tags, name)
+			}
+		}
+		for _, m := range item.Meta {
+			if m.Key == "_thumbnail_id" {
+				p.Cover = attachments[strings.TrimSpace(m.Value)]
+			}
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// parseDate 优先取 UTC 时间，草稿的 UTC 时间是 0000-00-00 00:00:00，这时取本地时间，最后取 RSS 的 pubDate
+func parseDate(gmt, local, pubDate string) time.Time {
+	const layout = "2006-01-02 15:04:05"
+	if t, err := time.Parse(layout, strings.TrimSpace(gmt)); err == nil && t.Year() > 1 {
+		return t.Local()
+	}
+	if t, err := time.ParseInLocation(layout, strings.TrimSpace(local), time.Local); err == nil && t.Year() > 1 {
+		return t
+	}
+	if t, err := time.Parse(time.RFC1123Z, strings.TrimSpace(pubDate)); err == nil && t.Year() > 1 {
+		return t.Local()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,155 @@
+// Path: ./utils/diff/enter.go
+
+package diff
+
+import "strings"
+
+type OpType string
+
+const (
+	OpEqual  OpType = "equal"
+	OpInsert OpType = "insert"
+	OpDelete OpType = "delete"
+)
+
+// Line 行级别的差异，OldNo / NewNo 为行号（从 1 开始），不存在时为 0
+type Line struct {
+	Type  OpType `json:"type"`
+	OldNo int    `json:"oldNo,omitempty"`
+	NewNo int    `json:"newNo,omitempty"`
+	Text  string `json:"text"`
+}
+
+// Lines 按行比较两段文本，返回差异列表
+// 先去掉相同的首尾，中间部分用 LCS（最长公共子序列）求解
+func Lines(oldText, newText string) (list []Line) {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// 相同的前缀
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	// 相同的后缀
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for i := 0; i < prefix; i++ {
+		list = append(list, Line{Type: OpEqual, OldNo: i + 1, NewNo: i + 1, Text: a[i]})
+	}
+
+	list = append(list, lcs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, prefix)...)
+
+	for i := suffix; i > 0; i-- {
+		oi, ni := len(a)-i, len(b)-i
+		list = append(list, Line{Type: OpEqual, OldNo: oi + 1, NewNo: ni + 1, Text: a[oi]})
+	}
+	return
+}
+
+// lcs 对去掉首尾之后的中间部分求差异，offset 用于还原行号
+func lcs(a, b []string, oldOffset, newOffset int) (list []Line) {
+	n, m := len(a), len(b)
+	// dp[i][j] 表示 a[i:] 与 b[j:] 的最长公共子序列长度
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			list = append(list, Line{Type: OpEqual, OldNo: oldOffset + i + 1, NewNo: newOffset + j + 1, Text: a[i]})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			list = append(list, Line{Type: OpDelete, OldNo: oldOffset + i + 1, Text: a[i]})
+			i++
+		default:
+			list = append(list, Line{Type: OpInsert, NewNo: newOffset + j + 1, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		list = append(list, Line{Type: OpDelete, OldNo: oldOffset + i + 1, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		list = append(list, Line{Type: OpInsert, NewNo: newOffset + j + 1, Text: b[j]})
+	}
+	return
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+// Section 文章的一个章节（小标题 + 正文）
+type Section struct {
+	Head string `json:"head"`
+	Body string `json:"body"`
+}
+
+type SectionOpType string
+
+const (
+	SectionEqual    SectionOpType = "equal"
+	SectionAdded    SectionOpType = "added"
+	SectionRemoved  SectionOpType = "removed"
+	SectionModified SectionOpType = "modified"
+)
+
+// SectionChange 章节级别的差异，修改过的章节附带正文的行级差异
+type SectionChange struct {
+	Type  SectionOpType `json:"type"`
+	Head  string        `json:"head"`
+	Lines []Line        `json:"lines,omitempty"`
+}
+
+// Sections 按章节比较，章节以小标题作为匹配依据
+// 先对小标题序列求 LCS，匹配上的视为同一章节，再比较正文
+func Sections(oldList, newList []Section) (list []SectionChange) {
+	var oldHeads, newHeads []string
+	for _, s := range oldList {
+		oldHeads = append(oldHeads, s.Head)
+	}
+	for _, s := range newList {
+		newHeads = append(newHeads, s.Head)
+	}
+
+	for _, op := range lcs(oldHeads, newHeads, 0, 0) {
+		switch op.Type {
+		case OpEqual:
+			o, n := oldList[op.OldNo-1], newList[op.NewNo-1]
+			if o.Body == n.Body {
+				list = append(list, SectionChange{Type: SectionEqual, Head: n.Head})
+				continue
+			}
+			list = append(list, SectionChange{Type: SectionModified, Head: n.Head, Lines: Lines(o.Body, n.Body)})
+		case OpDelete:
+			o := oldList[op.OldNo-1]
+			list = append(list, SectionChange{Type: SectionRemoved, Head: o.Head, Lines: Lines(o.Body, "")})
+		case OpInsert:
+			n := newList[op.NewNo-1]
+			list = append(list, SectionChange{Type: SectionAdded, Head: n.Head, Lines: Lines("", n.Body)})
+		}
+	}
+	return
+}
@@ -0,0 +1,68 @@
+// Path: ./utils/diff/enter_test.go
+
+package diff
+
+import "testing"
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []OpType
+	}{
+		{name: "相同", old: "a\nb", new: "a\nb", want: []OpType{OpEqual, OpEqual}},
+		{name: "新增", old: "a\nc", new: "a\nb\nc", want: []OpType{OpEqual, OpInsert, OpEqual}},
+		{name: "删除", old: "a\nb\nc", new: "a\nc", want: []OpType{OpEqual, OpDelete, OpEqual}},
+		{name: "修改", old: "a\nb\nc", new: "a\nx\nc", want: []OpType{OpEqual, OpDelete, OpInsert, OpEqual}},
+		{name: "空到有", old: "", new: "a", want: []OpType{OpInsert}},
+		{name: "有到空", old: "a", new: "", want: []OpType{OpDelete}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lines(tt.old, tt.new)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Lines() = %+v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Type != tt.want[i] {
+					t.Errorf("Lines()[%d] = %+v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLinesNumbers(t *testing.T) {
+	got := Lines("a\nb\nc\nd", "a\nc\nd\ne")
+	// a = ; b - ; c = ; d = ; e +
+	if got[1].Type != OpDelete || got[1].OldNo != 2 || got[1].NewNo != 0 {
+		t.Errorf("delete line = %+v", got[1])
+	}
+	if got[2].Type != OpEqual || got[2].OldNo != 3 || got[2].NewNo != 2 {
+		t.Errorf("equal line = %+v", got[2])
+	}
+	if got[4].Type != OpInsert || got[4].NewNo != 4 {
+		t.Errorf("insert line = %+v", got[4])
+	}
+}
+
+func TestSections(t *testing.T) {
+	oldList := []Section{{Head: "标题", Body: "x"}, {Head: "一", Body: "1"}, {Head: "二", Body: "2"}}
+	newList := []Section{{Head: "标题", Body: "x"}, {Head: "二", Body: "22"}, {Head: "三", Body: "3"}}
+
+	got := Sections(oldList, newList)
+	want := []SectionChange{
+		{Type: SectionEqual, Head: "标题"},
+		{Type: SectionRemoved, Head: "一"},
+		{Type: SectionModified, Head: "二"},
+		{Type: SectionAdded, Head: "三"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Sections() = %+v", got)
+	}
+	for i := range want {
+		if got[i].Type != want[i].Type || got[i].Head != want[i].Head {
+			t.Errorf("Sections()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -14,9 +14,10 @@ import (
 )
 
 type Claims struct {
-	UserID   uint          `json:"userID"`
-	Username string        `json:"username"`
-	Role     enum.RoleType `json:"role"`
+	UserID    uint          `json:"userID"`
+	Username  string        `json:"username"`
+	Role      enum.RoleType `json:"role"`
+	SessionID uint          `json:"sessionID,omitempty"` // 登录设备，下线设备时这个设备的 token 同时失效
 }
 
 type MyClaims struct {
@@ -41,14 +42,15 @@ func (m MyClaims) MustGetUserFromClaims() (user *models.UserModel) {
 func GenerateToken(claims Claims) (string, error) {
 	cla := MyClaims{
 		Claims: Claims{
-			UserID:   claims.UserID,
-			Username: claims.Username,
-			Role:     claims.Role,
+			UserID:    claims.UserID,
+			Username:  claims.Username,
+			Role:      claims.Role,
+			SessionID: claims.SessionID,
 		},
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Duration(global.Config.Jwt.Expire) * time.Hour).Unix(), // 过期时间
-			Issuer:    global.Config.Jwt.Issuer,                                                   // 签发人
-			IssuedAt:  time.Now().Unix(),                                                          // 签发时间
+			ExpiresAt: time.Now().Add(global.Config.Jwt.AccessExpiry()).Unix(), // 过期时间，过期后用 refresh token 换新的
+			Issuer:    global.Config.Jwt.Issuer,                                // 签发人
+			IssuedAt:  time.Now().Unix(),                                       // 签发时间
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, cla)
@@ -94,6 +96,10 @@ func GetTokenFromRequest(c *gin.Context) (string, error) {
 	if token == "" {
 		token = c.Query("token")
 	}
+	if token == "" {
+		// 脚本和 CI 习惯用 Authorization: Bearer 传访问令牌
+		token = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	}
 	if token == "" {
 		return "", errors.New("no token found")
 	}
@@ -0,0 +1,316 @@
+// Path: ./utils/markdown/html_to_md.go
+
+package markdown
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"regexp"
+	"strings"
+)
+
+var (
+	blankLinesRe = regexp.MustCompile(`\n{3,}`)
+	paragraphRe  = regexp.MustCompile(`[ \t\r]*\n[ \t\r]*\n\s*`)
+	spaceRe      = regexp.MustCompile(`\s+`)
+	brushRe      = regexp.MustCompile(`brush:\s*([\w+#-]+)`)
+)
+
+// HTMLToMd 把 HTML 转为 markdown，用于导入其他博客系统（如 WordPress）的文章
+// 和 WordPress 的 wpautop 一样，文本中的空行视为分段；不认识的标签只保留其中的内容
+func HTMLToMd(src string) string {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return src
+	}
+	md := convertChildren(doc, false)
+
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+		// 保留行尾的两个空格（强制换行）
+		if strings.HasSuffix(line, "  ") && strings.TrimSpace(line) != "" {
+			lines[i] += "  "
+		}
+	}
+	md = blankLinesRe.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(md)
+}
+
+func convertChildren(n *html.Node, inTable bool) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(convertNode(c, inTable))
+	}
+	return sb.String()
+}
+
+func convertNode(n *html.Node, inTable bool) string {
+	switch n.Type {
+	case html.TextNode:
+		// 空行分段，其余空白合并为一个空格
+		parts := paragraphRe.Split(n.Data, -1)
+		for i, p := range parts {
+			parts[i] = spaceRe.ReplaceAllString(p, " ")
+		}
+		if inTable {
+			return strings.Join(parts, " ")
+		}
+		return strings.Join(parts, "\n\n")
+	case html.DocumentNode:
+		return convertChildren(n, inTable)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	inner := func() string { return convertChildren(n, inTable) }
+	block := func(s string) string {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return ""
+		}
+		return "\n\n" + s + "\n\n"
+	}
+
+	switch n.Data {
+	case "script", "style", "head", "noscript", "template":
+		return ""
+	case "br":
+		if inTable {
+			return " "
+		}
+		return "  \n"
+	case "hr":
+		return "\n\n---\n\n"
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := oneLine(inner())
+		if text == "" {
+			return ""
+		}
+		return "\n\n" + strings.Repeat("#", int(n.Data[1]-'0')) + " " + text + "\n\n"
+	case "p", "div", "section", "article", "header", "footer", "figure", "main", "aside", "dl", "dd", "dt":
+		if inTable {
+			return " " + inner() + " "
+		}
+		return block(inner())
+	case "figcaption":
+		text := oneLine(inner())
+		if text == "" {
+			return ""
+		}
+		return "\n\n*" + text + "*\n\n"
+	case "strong", "b":
+		return wrapInline(inner(), "**")
+	case "em", "i":
+		return wrapInline(inner(), "*")
+	case "del", "s", "strike":
+		return wrapInline(inner(), "~~")
+	case "code", "kbd", "tt":
+		return inlineCode(textContent(n))
+	case "a":
+		text := inner()
+		href := attr(n, "href")
+		if href == "" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return text
+		}
+		if strings.TrimSpace(text) == "" {
+			text = href
+		}
+		if title := attr(n, "title"); title != "" {
+			return fmt.Sprintf("[%s](%s \"%s\")", strings.TrimSpace(text), href, strings.ReplaceAll(title, `"`, `'`))
+		}
+		return fmt.Sprintf("[%s](%s)", strings.TrimSpace(text), href)
+	case "img":
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return fmt.Sprintf("![%s](%s)", oneLine(attr(n, "alt")), src)
+	case "iframe", "video", "audio", "embed":
+		src := attr(n, "src")
+		if src == "" {
+			return inner()
+		}
+		return block(fmt.Sprintf("[%s](%s)", src, src))
+	case "pre":
+		return block(codeBlock(n))
+	case "blockquote":
+		text := blankLinesRe.ReplaceAllString(strings.TrimSpace(inner()), "\n\n")
+		if text == "" {
+			return ""
+		}
+		return block(prefixLines(text, "> ", "> "))
+	case "ul", "ol":
+		return "\n\n" + convertList(n, inTable) + "\n\n"
+	case "table":
+		return block(convertTable(n))
+	}
+	return inner()
+}
+
+// convertList 列表项的后续行按标记的宽度缩进，嵌套列表同理
+func convertList(n *html.Node, inTable bool) string {
+	var items []string
+	i := 1
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", i)
+		}
+		i++
+		text := blankLinesRe.ReplaceAllString(strings.TrimSpace(convertChildren(c, inTable)), "\n\n")
+		items = append(items, prefixLines(text, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// convertTable 转为 GFM 表格，第一行作为表头
+func convertTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.Data != "tr" {
+				walk(c)
+				continue
+			}
+			var row []string
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					text := oneLine(convertChildren(cell, true))
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			if len(row) > 0 {
+				rows = append(rows, row)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	var sb strings.Builder
+	for i, row := range rows {
+		for len(row) < cols {
+			row = append(row, "")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// codeBlock pre 转为围栏代码块，语言取自 language-xx / lang-xx 或 SyntaxHighlighter 的 brush: xx
+func codeBlock(n *html.Node) string {
+	code := strings.Trim(textContent(n), "\n")
+	lang := codeLanguage(attr(n, "class"))
+	for c := n.FirstChild; c != nil && lang == ""; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "code" {
+			lang = codeLanguage(attr(c, "class"))
+		}
+	}
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+func codeLanguage(class string) string {
+	if m := brushRe.FindStringSubmatch(class); m != nil {
+		return m[1]
+	}
+	for _, f := range strings.Fields(class) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(f, prefix) {
+				return strings.TrimPrefix(f, prefix)
+			}
+		}
+	}
+	return ""
+}
+
+func inlineCode(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if s == "" {
+		return ""
+	}
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+// wrapInline 强调等标记不能紧挨空白，把首尾的空白移到标记外面
+func wrapInline(s, mark string) string {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return s
+	}
+	lead := s[:strings.Index(s, text)]
+	trail := s[len(lead)+len(text):]
+	return lead + mark + text + mark + trail
+}
+
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		p := rest
+		if i == 0 {
+			p = first
+		}
+		if line == "" {
+			lines[i] = strings.TrimRight(p, " ")
+			continue
+		}
+		lines[i] = p + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func oneLine(s string) string {
+	return strings.TrimSpace(spaceRe.ReplaceAllString(s, " "))
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "br" {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+// Path: ./utils/markdown/html_to_md_test.go
+
+package markdown
+
+import "testing"
+
+func TestHTMLToMd(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{name: "空行分段", html: "第一段\n第二行\n\n第二段", want: "第一段 第二行\n\n第二段"},
+		{name: "标题和段落", html: "<h2>标题</h2><p>内容 <strong>加粗</strong> <em>斜体</em></p>", want: "## 标题\n\n内容 **加粗** *斜体*"},
+		{name: "Gutenberg 注释", html: "<!-- wp:paragraph -->\n<p>正文</p>\n<!-- /wp:paragraph -->", want: "正文"},
+		{name: "链接和图片", html: `<p><a href="https://a.com" title="A">链接</a><img src="/a.png" alt="图"></p>`, want: `[链接](https://a.com "A")![图](/a.png)`},
+		{name: "代码块", html: `<pre class="brush: go; title: ;">fmt.Println("&lt;x&gt;")</pre>`, want: "```go\nfmt.Println(\"<x>\")\n```"},
+		{name: "代码块语言", html: `<pre><code class="language-js">a()
+b()</code></pre>`, want: "```js\na()\nb()\n```"},
+		{name: "行内代码", html: "<p>调用 <code>a`b</code></p>", want: "调用 ``a`b``"},
+		{name: "嵌套列表", html: "<ul><li>a<ul><li>b</li></ul></li><li>c</li></ul>", want: "- a\n\n  - b\n- c"},
+		{name: "有序列表", html: "<ol><li>a</li><li>b</li></ol>", want: "1. a\n2. b"},
+		{name: "引用", html: "<blockquote><p>a</p><p>b</p></blockquote>", want: "> a\n>\n> b"},
+		{name: "表格", html: "<table><tr><th>a</th><th>b</th></tr><tr><td>1|2</td></tr></table>", want: "| a | b |\n| --- | --- |\n| 1\\|2 |  |"},
+		{name: "换行", html: "a<br>b", want: "a  \nb"},
+		{name: "脚本", html: "<p>a</p><script>alert(1)</script>", want: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMd(tt.html); got != tt.want {
+				t.Errorf("HTMLToMd() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Path: ./utils/sensitive/enter.go
+
+package sensitive
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Matcher 基于 Aho-Corasick 自动机的多模式匹配，一次扫描找出文本中所有敏感词
+// 匹配前会做归一化：转小写、全角转半角、忽略空白和标点，"敏 感-词" 也能命中 "敏感词"
+// 构建后只读，可以并发使用
+type Matcher struct {
+	nodes   []node
+	lengths []int // 每个词归一化后的字符数
+}
+
+type node struct {
+	next map[rune]int32
+	fail int32
+	out  []int // 在这个节点结束的词，包括通过 fail 指针可达的
+}
+
+// Hit 一次命中，Start、End 是原文中的字节偏移，Index 是词在 New 传入列表中的下标
+type Hit struct {
+	Index int
+	Start int
+	End   int
+}
+
+// New 用词表构建自动机，归一化后为空的词忽略
+func New(words []string) *Matcher {
+	m := &Matcher{
+		nodes:   []node{{next: map[rune]int32{}}},
+		lengths: make([]int, len(words)),
+	}
+	for i, w := range words {
+		runes := normalize(w)
+		if len(runes) == 0 {
+			continue
+		}
+		var cur int32
+		for _, r := range runes {
+			nxt, ok := m.nodes[cur].next[r]
+			if !ok {
+				nxt = int32(len(m.nodes))
+				m.nodes = append(m.nodes, node{next: map[rune]int32{}})
+				m.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+		}
+		m.nodes[cur].out = append(m.nodes[cur].out, i)
+		m.lengths[i] = len(runes)
+	}
+	m.build()
+	return m
+}
+
+// build 按层序计算 fail 指针，并把 fail 节点的输出合并进来
+func (m *Matcher) build() {
+	var queue []int32
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for f != 0 {
+				if _, ok := m.nodes[f].next[r]; ok {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			if nxt, ok := m.nodes[f].next[r]; ok && nxt != child {
+				m.nodes[child].fail = nxt
+			}
+			fail := m.nodes[child].fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll 返回所有命中，按结束位置排序，可能互相重叠
+func (m *Matcher) FindAll(text string) (hits []Hit) {
+	if m == nil || len(m.nodes) == 1 {
+		return nil
+	}
+	// pos 记录归一化后每个字符在原文中的起止位置
+	type span struct{ start, end int }
+	var pos []span
+	var cur int32
+	for i, r := range text {
+		r, ok := fold(r)
+		if !ok {
+			continue
+		}
+		// 按原文中的字符长度记录，fold 可能把全角转成了半角
+		_, size := utf8.DecodeRuneInString(text[i:])
+		pos = append(pos, span{i, i + size})
+
+		for cur != 0 {
+			if _, ok := m.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		if nxt, ok := m.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, idx := range m.nodes[cur].out {
+			n := len(pos)
+			hits = append(hits, Hit{
+				Index: idx,
+				Start: pos[n-m.lengths[idx]].start,
+				End:   pos[n-1].end,
+			})
+		}
+	}
+	return hits
+}
+
+// Mask 把命中的部分替换为 *，每个字符一个 *，中间夹杂的符号一并替换
+func Mask(text string, hits []Hit) string {
+	if len(hits) == 0 {
+		return text
+	}
+	masked := make([]bool, len(text))
+	for _, h := range hits {
+		for i := h.Start; i < h.End; i++ {
+			masked[i] = true
+		}
+	}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for i, r := range text {
+		if masked[i] {
+			if !unicode.IsSpace(r) {
+				sb.WriteByte('*')
+				continue
+			}
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func normalize(s string) (runes []rune) {
+	for _, r := range s {
+		if r, ok := fold(r); ok {
+			runes = append(runes, r)
+		}
+	}
+	return
+}
+
+// fold 全角转半角并转小写，空白、标点和符号不参与匹配
+func fold(r rune) (rune, bool) {
+	if r >= 0xFF01 && r <= 0xFF5E {
+		r -= 0xFEE0
+	}
+	if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		return r, false
+	}
+	return unicode.ToLower(r), true
+}
@@ -0,0 +1,63 @@
+// Path: ./utils/sensitive/enter_test.go
+
+package sensitive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func words(m *Matcher, text string, list []string) (got []string) {
+	for _, h := range m.FindAll(text) {
+		got = append(got, list[h.Index]+"="+text[h.Start:h.End])
+	}
+	return
+}
+
+func TestFindAll(t *testing.T) {
+	list := []string{"he", "she", "his", "hers", "敏感词", "ＡＢＣ", "", " . "}
+	m := New(list)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"ushers", []string{"she=she", "he=he", "hers=hers"}},
+		{"HIS", []string{"his=HIS"}},
+		{"这是敏 感-词吗", []string{"敏感词=敏 感-词"}},
+		{"abc", []string{"ＡＢＣ=abc"}},
+		{"ａｂｃ", []string{"ＡＢＣ=ａｂｃ"}},
+		{"没有命中", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := words(m, tt.text, list); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEmptyMatcher(t *testing.T) {
+	var m *Matcher
+	if hits := m.FindAll("abc"); hits != nil {
+		t.Errorf("nil Matcher 不应该命中: %v", hits)
+	}
+	if hits := New(nil).FindAll("abc"); hits != nil {
+		t.Errorf("空词表不应该命中: %v", hits)
+	}
+}
+
+func TestMask(t *testing.T) {
+	m := New([]string{"敏感词", "bad", "ad"})
+	tests := []struct {
+		text, want string
+	}{
+		{"这是敏 感-词吗", "这是* ***吗"},
+		{"a bad day", "a *** day"},
+		{"nothing", "nothing"},
+	}
+	for _, tt := range tests {
+		if got := Mask(tt.text, m.FindAll(tt.text)); got != tt.want {
+			t.Errorf("Mask(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
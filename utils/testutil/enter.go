@@ -0,0 +1,50 @@
+// Path: ./utils/testutil/enter.go
+
+// Package testutil 给需要数据库和 redis 的单元测试用，只在 _test.go 中引入
+// 数据库用临时目录中的 sqlite，redis 用 miniredis，每个测试都是独立的一份
+package testutil
+
+import (
+	"blogX_server/conf"
+	"blogX_server/global"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"path/filepath"
+	"testing"
+)
+
+// Setup 初始化 global.DB、global.Redis 和一份空的 global.Config，并迁移传入的表
+// 返回的 miniredis 可以用 FastForward 模拟 key 过期
+func Setup(t *testing.T, dst ...any) *miniredis.Miniredis {
+	t.Helper()
+
+	// 用临时文件而不是内存库：事务中还会用 global.DB 查询，需要多个连接共享同一个库
+	// WAL 模式下读写互不阻塞，并发写入时等待锁释放，和 mysql 的表现接近
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err = db.AutoMigrate(dst...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	oldDB, oldMaster, oldRedis, oldConfig := global.DB, global.DBMaster, global.Redis, global.Config
+	t.Cleanup(func() {
+		global.DB, global.DBMaster, global.Redis, global.Config = oldDB, oldMaster, oldRedis, oldConfig
+	})
+	global.DB, global.DBMaster, global.Redis = db, db, client
+	global.Config = &conf.Config{}
+	return mr
+}
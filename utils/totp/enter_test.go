@@ -0,0 +1,91 @@
+// Path: ./utils/totp/enter_test.go
+
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 的 SHA1 密钥 "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeAt(t *testing.T) {
+	// 附录 B 给的是 8 位，取后 6 位
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("CodeAt(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	prev, _ := CodeAt(rfcSecret, step-1)
+	next, _ := CodeAt(rfcSecret, step+1)
+	old, _ := CodeAt(rfcSecret, step-2)
+
+	if s, ok := Validate(rfcSecret, "050471", now); !ok || s != step {
+		t.Errorf("当前周期 = %d %v", s, ok)
+	}
+	if s, ok := Validate(rfcSecret, prev, now); !ok || s != step-1 {
+		t.Errorf("上一个周期 = %d %v", s, ok)
+	}
+	if s, ok := Validate(rfcSecret, next, now); !ok || s != step+1 {
+		t.Errorf("下一个周期 = %d %v", s, ok)
+	}
+	if _, ok := Validate(rfcSecret, old, now); ok {
+		t.Error("超出误差的验证码不应通过")
+	}
+	for _, code := range []string{"", "05047", "0504711", "abcdef"} {
+		if _, ok := Validate(rfcSecret, code, now); ok {
+			t.Errorf("格式错误的验证码 %q 不应通过", code)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("len = %d", len(secret))
+	}
+	code, err := CodeAt(secret, Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Validate(secret, code, time.Now()); !ok {
+		t.Error("新密钥生成的验证码应通过")
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("My Blog", "admin", rfcSecret)
+	if !strings.HasPrefix(got, "otpauth://totp/My%20Blog:admin?") {
+		t.Errorf("URI = %s", got)
+	}
+	for _, s := range []string{"secret=" + rfcSecret, "issuer=My+Blog", "digits=6", "period=30"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("URI = %s, 缺少 %s", got, s)
+		}
+	}
+}
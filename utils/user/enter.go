@@ -9,6 +9,7 @@ import (
 	"errors"
 	"gorm.io/gorm"
 	"regexp"
+	"strings"
 )
 
 // IsValidUsername validates the given username to ensure it contains only alphanumeric characters and underscores.
@@ -28,9 +29,15 @@ func IsValidUsername(username string) (msg string, ok bool) {
 	return "", true
 }
 
+// GuestUsernamePrefix 游客占位用户的用户名前缀，注册时不能使用，以免和占位用户混在一起
+const GuestUsernamePrefix = "guest_"
+
 // IsAvailableUsername checks if a given username is available for registration by querying the database.
 // It returns a message and a boolean indicating whether the username is available.
 func IsAvailableUsername(username string) (msg string, ok bool) {
+	if strings.HasPrefix(strings.ToLower(username), GuestUsernamePrefix) {
+		return "用户名不能以 " + GuestUsernamePrefix + " 开头", false
+	}
 	var user models.UserModel
 	err := global.DB.Take(&user, "username = ?", username).Error
 	if err == nil {